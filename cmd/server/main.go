@@ -3,11 +3,23 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/choi-jiwoong/go-quickstart/internal/api"
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/authn"
+	"github.com/choi-jiwoong/go-quickstart/internal/authz"
 	"github.com/choi-jiwoong/go-quickstart/internal/config"
 	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/mail"
 	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls"
+	"github.com/choi-jiwoong/go-quickstart/internal/oauth"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml"
+	"github.com/choi-jiwoong/go-quickstart/internal/signup"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,6 +30,82 @@ func main() {
 	// 데이터베이스 초기화
 	database.InitDB(cfg)
 
+	// JWT 서명 키 초기화
+	if err := auth.InitJWT(cfg); err != nil {
+		log.Fatalf("JWT 초기화 실패: %v", err)
+	}
+
+	// OAuth2 인가 서버 서명 키 초기화
+	if err := oauth.Init(cfg); err != nil {
+		log.Fatalf("OAuth 서명 키 초기화 실패: %v", err)
+	}
+
+	// 비밀번호 해싱 설정
+	if err := password.Init(cfg); err != nil {
+		log.Fatalf("비밀번호 해싱 초기화 실패: %v", err)
+	}
+	if err := password.InitPolicy(cfg); err != nil {
+		log.Fatalf("비밀번호 정책 초기화 실패: %v", err)
+	}
+
+	// 계정 잠금 정책 설정
+	auth.InitLockout(cfg)
+
+	// 서명 요청의 허용 시각 차이 설정
+	auth.InitSignatures(cfg)
+
+	// 역할과 권한 등록
+	if err := auth.InitRBAC(cfg); err != nil {
+		log.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+
+	// 기본 조직 등록
+	if err := tenant.Init(cfg); err != nil {
+		log.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	// 권한 정책 로드
+	if err := authz.Init(cfg); err != nil {
+		log.Fatalf("권한 정책 로드 실패: %v", err)
+	}
+
+	// 회원 가입 정책 설정
+	if err := signup.Init(cfg); err != nil {
+		log.Fatalf("회원 가입 정책 초기화 실패: %v", err)
+	}
+
+	// 외부 로그인 공급자 설정
+	if err := oidc.Init(cfg); err != nil {
+		log.Fatalf("외부 로그인 공급자 설정 실패: %v", err)
+	}
+
+	// SAML IdP 설정
+	if err := saml.Init(cfg); err != nil {
+		log.Fatalf("SAML IdP 설정 실패: %v", err)
+	}
+
+	// 비밀번호 로그인 인증 방식 설정
+	if err := authn.Init(cfg); err != nil {
+		log.Fatalf("인증 방식 설정 실패: %v", err)
+	}
+
+	// 클라이언트 인증서로 인증하는 서비스 계정 설정
+	if err := mtls.Init(cfg); err != nil {
+		log.Fatalf("서비스 계정 설정 실패: %v", err)
+	}
+
+	// 토큰 폐기 목록 로드
+	if err := auth.InitRevocations(cfg); err != nil {
+		log.Fatalf("토큰 폐기 목록 로드 실패: %v", err)
+	}
+
+	// 핸들러 설정
+	api.Init(cfg)
+
+	// 메일 발송기 시작
+	mail.Init(cfg)
+	mail.StartDispatcher()
+
 	// Gin 모드 설정
 	gin.SetMode(cfg.GinMode)
 
@@ -37,30 +125,193 @@ func main() {
 	
 	// 인증 API 라우트 등록
 	router.POST("/login", api.Login)
+	router.POST("/login/mfa", api.LoginMFA)
+	router.POST("/token/refresh", api.RefreshToken)
+	router.POST("/password/forgot", api.ForgotPassword)
+	router.POST("/password/reset", api.ResetPassword)
+	router.GET("/oidc/:provider/login", api.OIDCLogin)
+	router.GET("/oidc/:provider/callback", api.OIDCCallback)
+	router.GET("/saml/:provider/metadata", api.SAMLMetadata)
+	router.GET("/saml/:provider/login", api.SAMLLogin)
+	router.POST("/saml/:provider/acs", api.SAMLACS)
 	
+	// OAuth2/OpenID Connect 인가 서버 라우트 등록
+	router.GET("/.well-known/openid-configuration", api.OpenIDConfiguration)
+	router.GET("/oauth/jwks", api.JWKS)
+	router.GET("/oauth/authorize", api.Authorize)
+	router.POST("/oauth/token", api.OAuthToken)
+	router.GET("/oauth/userinfo", api.UserInfo)
+	router.POST("/oauth/userinfo", api.UserInfo)
+
+	// 회원 가입 API 라우트 등록
+	router.POST("/register", api.Register)
+	router.POST("/register/verify", api.VerifyEmail)
+	router.POST("/register/resend", api.ResendVerification)
+	router.POST("/invitations/accept", api.AcceptInvitation)
+
+	// SCIM 2.0 프로비저닝 API (스키마 조회 외에는 SCIM 토큰 필요)
+	scimGroup := router.Group("/scim/v2")
+	{
+		scimGroup.GET("/ServiceProviderConfig", api.SCIMServiceProviderConfig)
+		scimGroup.GET("/ResourceTypes", api.SCIMResourceTypes)
+		scimGroup.GET("/ResourceTypes/:id", api.SCIMResourceType)
+		scimGroup.GET("/Schemas", api.SCIMSchemas)
+		scimGroup.GET("/Schemas/:id", api.SCIMSchema)
+
+		provisioningGroup := scimGroup.Group("")
+		provisioningGroup.Use(middleware.RequireSCIMToken())
+		{
+			provisioningGroup.GET("/Users", api.SCIMGetUsers)
+			provisioningGroup.POST("/Users", api.SCIMCreateUser)
+			provisioningGroup.GET("/Users/:id", api.SCIMGetUser)
+			provisioningGroup.PUT("/Users/:id", api.SCIMReplaceUser)
+			provisioningGroup.PATCH("/Users/:id", api.SCIMPatchUser)
+			provisioningGroup.DELETE("/Users/:id", api.SCIMDeleteUser)
+			provisioningGroup.GET("/Groups", api.SCIMGetGroups)
+			provisioningGroup.POST("/Groups", api.SCIMCreateGroup)
+			provisioningGroup.GET("/Groups/:id", api.SCIMGetGroup)
+			provisioningGroup.PUT("/Groups/:id", api.SCIMReplaceGroup)
+			provisioningGroup.PATCH("/Groups/:id", api.SCIMPatchGroup)
+			provisioningGroup.DELETE("/Groups/:id", api.SCIMDeleteGroup)
+		}
+	}
+
 	// 인증이 필요한 API 그룹
+	// 개인 액세스 토큰으로 호출할 때 필요한 권한 범위를 라우트마다 지정
 	authGroup := router.Group("")
 	authGroup.Use(middleware.RequireAuth())
 	{
 		// 사용자 조회 API
-		authGroup.GET("/user/:id", api.GetUser)
+		authGroup.GET("/user/:id", middleware.RequireScope(auth.ScopeUsersRead), api.GetUser)
 		
 		// 사용자 정보 업데이트 API
-		authGroup.PUT("/user/:id", api.UpdateUser)
+		authGroup.PUT("/user/:id", middleware.RequireScope(auth.ScopeUsersWrite), api.UpdateUser)
 		
-		// 관리자 전용 API 그룹
-		adminGroup := authGroup.Group("")
-		adminGroup.Use(middleware.RequireAdmin())
+		// 로그인 기록 조회 API (본인 또는 logins:read 권한)
+		authGroup.GET("/user/:id/logins", middleware.RequireScope(auth.ScopeLoginsRead), api.GetUserLogins)
+
+		// 로그인 세션으로만 사용할 수 있는 API 그룹
+		sessionGroup := authGroup.Group("")
+		sessionGroup.Use(middleware.RequireSession())
+		{
+			// 로그아웃 API (가장 토큰도 로그아웃으로 폐기 가능)
+			sessionGroup.POST("/logout", api.Logout)
+
+			// 내 세션 목록 조회 및 세션 종료 API
+			sessionGroup.GET("/sessions", api.ListSessions)
+			sessionGroup.DELETE("/sessions/:id", api.RevokeSession)
+
+			// 인증 수단을 관리하는 API 그룹 (관리자가 가장한 상태에서는 사용 불가)
+			credentialGroup := sessionGroup.Group("")
+			credentialGroup.Use(middleware.DenyImpersonation())
+			{
+				// TOTP 2단계 인증 관리 API
+				credentialGroup.POST("/mfa/totp/enroll", api.EnrollTOTP)
+				credentialGroup.POST("/mfa/totp/verify", api.VerifyTOTP)
+				credentialGroup.POST("/mfa/totp/disable", api.DisableTOTP)
+
+				// 개인 액세스 토큰 관리 API
+				credentialGroup.POST("/tokens", api.CreatePersonalAccessToken)
+				credentialGroup.GET("/tokens", api.ListPersonalAccessTokens)
+				credentialGroup.DELETE("/tokens/:id", api.RevokePersonalAccessToken)
+
+				// 서명 요청용 API 키 관리 API
+				credentialGroup.POST("/api-keys", api.CreateAPIKey)
+				credentialGroup.GET("/api-keys", api.ListAPIKeys)
+				credentialGroup.DELETE("/api-keys/:id", api.RevokeAPIKey)
+
+				// 로그인한 사용자가 OAuth2 클라이언트에 인가 코드 발급
+				credentialGroup.POST("/oauth/authorize", api.ApproveAuthorization)
+
+				// 다른 사용자로 가장하는 토큰 발급 API (가장 권한 필요)
+				credentialGroup.POST("/user/:id/impersonate", middleware.RequirePermission(auth.PermUsersImpersonate), api.ImpersonateUser)
+			}
+		}
+
+		// 사용자 관리 API (역할에 권한 필요)
+		authGroup.GET("/users", middleware.RequirePermission(auth.PermUsersRead), middleware.RequireScope(auth.ScopeUsersRead), api.GetUsers)
+		authGroup.POST("/user", middleware.RequirePermission(auth.PermUsersCreate), middleware.RequireScope(auth.ScopeUsersWrite), api.CreateUser)
+		authGroup.DELETE("/user/:id", middleware.RequirePermission(auth.PermUsersDelete), middleware.RequireScope(auth.ScopeUsersWrite), api.DeleteUser)
+		authGroup.DELETE("/user/:id/sessions", middleware.RequirePermission(auth.PermSessionsRevoke), middleware.RequireScope(auth.ScopeUsersWrite), api.RevokeUserSessions)
+		authGroup.GET("/user/:id/sessions", middleware.RequirePermission(auth.PermUsersRead), middleware.RequireScope(auth.ScopeUsersRead), api.GetUserSessions)
+		authGroup.DELETE("/user/:id/sessions/:sessionId", middleware.RequirePermission(auth.PermSessionsRevoke), middleware.RequireScope(auth.ScopeUsersWrite), api.RevokeUserSession)
+		authGroup.POST("/user/:id/unlock", middleware.RequirePermission(auth.PermUsersUnlock), middleware.RequireScope(auth.ScopeUsersWrite), api.UnlockUser)
+
+		// 역할 관리 API (로그인 세션과 역할 관리 권한 필요, 관리자가 가장한 상태에서는 사용 불가)
+		roleGroup := authGroup.Group("")
+		roleGroup.Use(middleware.RequireSession(), middleware.DenyImpersonation(), middleware.RequirePermission(auth.PermRolesManage))
 		{
-			// 모든 사용자 목록 조회
-			adminGroup.GET("/users", api.GetUsers)
-			
-			// 사용자 생성
-			adminGroup.POST("/user", api.CreateUser)
-			
-			// 사용자 삭제
-			adminGroup.DELETE("/user/:id", api.DeleteUser)
+			roleGroup.GET("/permissions", api.GetPermissions)
+			roleGroup.GET("/roles", api.GetRoles)
+			roleGroup.POST("/roles", api.CreateRole)
+			roleGroup.PUT("/roles/:name/permissions", api.UpdateRolePermissions)
+			roleGroup.DELETE("/roles/:name", api.DeleteRole)
+			roleGroup.PUT("/user/:id/role", api.AssignUserRole)
+			roleGroup.PUT("/groups/:id/role", api.AssignGroupRole)
+		}
+
+		// OAuth2 클라이언트 관리 API (로그인 세션과 클라이언트 관리 권한 필요)
+		clientGroup := authGroup.Group("")
+		clientGroup.Use(middleware.RequireSession(), middleware.RequirePermission(auth.PermClientsManage))
+		{
+			clientGroup.GET("/oauth/clients", api.GetOAuthClients)
+			clientGroup.POST("/oauth/clients", api.CreateOAuthClient)
+			clientGroup.DELETE("/oauth/clients/:id", api.DeleteOAuthClient)
+		}
+
+		// SCIM 토큰 관리 API (로그인 세션과 SCIM 관리 권한 필요)
+		scimTokenGroup := authGroup.Group("")
+		scimTokenGroup.Use(middleware.RequireSession(), middleware.RequirePermission(auth.PermSCIMManage))
+		{
+			scimTokenGroup.GET("/scim/tokens", api.GetSCIMTokens)
+			scimTokenGroup.POST("/scim/tokens", api.CreateSCIMToken)
+			scimTokenGroup.DELETE("/scim/tokens/:id", api.RevokeSCIMToken)
+		}
+
+		// 초대 API
+		authGroup.GET("/invitations", middleware.RequirePermission(auth.PermUsersCreate), middleware.RequireScope(auth.ScopeUsersRead), api.GetInvitations)
+		authGroup.POST("/invitations", middleware.RequirePermission(auth.PermUsersCreate), middleware.RequireScope(auth.ScopeUsersWrite), api.CreateInvitation)
+		authGroup.POST("/invitations/:id/resend", middleware.RequirePermission(auth.PermUsersCreate), middleware.RequireScope(auth.ScopeUsersWrite), api.ResendInvitation)
+		authGroup.DELETE("/invitations/:id", middleware.RequirePermission(auth.PermUsersCreate), middleware.RequireScope(auth.ScopeUsersWrite), api.RevokeInvitation)
+
+		// 그룹 API
+		authGroup.GET("/groups", middleware.RequirePermission(auth.PermUsersRead), middleware.RequireScope(auth.ScopeUsersRead), api.GetGroups)
+		authGroup.GET("/groups/:id", middleware.RequirePermission(auth.PermUsersRead), middleware.RequireScope(auth.ScopeUsersRead), api.GetGroup)
+		authGroup.POST("/groups", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.CreateGroup)
+		authGroup.PUT("/groups/:id", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.UpdateGroup)
+		authGroup.DELETE("/groups/:id", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.DeleteGroup)
+		authGroup.POST("/groups/:id/members", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.AddGroupMember)
+		authGroup.DELETE("/groups/:id/members/users/:userId", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.RemoveGroupUser)
+		authGroup.DELETE("/groups/:id/members/groups/:groupId", middleware.RequirePermission(auth.PermGroupsManage), middleware.RequireScope(auth.ScopeUsersWrite), api.RemoveSubgroup)
+
+		// 조직 API
+		authGroup.GET("/organization", middleware.RequireScope(auth.ScopeUsersRead), api.GetMyOrganization)
+
+		// 조직 관리 API (로그인 세션과 조직 관리 권한 필요)
+		orgGroup := authGroup.Group("")
+		orgGroup.Use(middleware.RequireSession(), middleware.RequirePermission(auth.PermOrgsManage))
+		{
+			orgGroup.GET("/organizations", api.GetOrganizations)
+			orgGroup.POST("/organizations", api.CreateOrganization)
+			orgGroup.PUT("/organizations/:id", api.UpdateOrganization)
+			orgGroup.DELETE("/organizations/:id", api.DeleteOrganization)
+			orgGroup.PUT("/user/:id/organization", api.AssignUserOrganization)
+		}
+	}
+
+	// 서비스 간 호출을 받는 mTLS 리스너 시작 (같은 라우트를 제공하며 인증서로 서비스 계정 인증)
+	if cfg.MTLSPort != "" {
+		tlsConfig, err := mtls.ServerTLSConfig(cfg)
+		if err != nil {
+			log.Fatalf("mTLS 리스너 설정 실패: %v", err)
 		}
+		mtlsServer := &http.Server{Addr: fmt.Sprintf(":%s", cfg.MTLSPort), Handler: router, TLSConfig: tlsConfig}
+		go func() {
+			log.Printf("mTLS 리스너가 %s 포트에서 시작됩니다...", cfg.MTLSPort)
+			if err := mtlsServer.ListenAndServeTLS("", ""); err != nil {
+				log.Fatalf("mTLS 리스너 시작 실패: %v", err)
+			}
+		}()
 	}
 
 	// 서버 시작
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKey는 인증된 사용자의 서명 요청용 API 키를 생성합니다.
+// 비밀 값은 이 응답에서만 확인할 수 있습니다.
+func CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":  "지원하지 않는 권한 범위입니다: " + scope,
+				"scopes": auth.Scopes,
+			})
+			return
+		}
+	}
+
+	lifetime := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	if lifetime > appConfig.APIKeyMaxLifetime {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "API 키 유효 기간이 허용된 최대 기간을 초과합니다",
+		})
+		return
+	}
+
+	keyID, secret, err := auth.GenerateAPIKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "API 키 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	key := models.APIKey{
+		UserID:    authUser.ID,
+		Name:      req.Name,
+		KeyID:     keyID,
+		Secret:    secret,
+		Scopes:    uniqueStrings(req.Scopes),
+		ExpiresAt: time.Now().Add(lifetime),
+	}
+
+	if err := repository.CreateAPIKey(&key); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "API 키 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreateAPIKeyResponse{
+		APIKey: key,
+		Secret: secret,
+	})
+}
+
+// ListAPIKeys는 인증된 사용자의 API 키 목록을 반환합니다.
+func ListAPIKeys(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	keys, err := repository.GetAPIKeysByUser(authUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "API 키 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey는 인증된 사용자의 API 키를 폐기합니다.
+func RevokeAPIKey(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 API 키 ID 형식입니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+
+	revoked, err := repository.RevokeAPIKey(id, authUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "API 키 폐기 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !revoked {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "API 키를 찾을 수 없습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API 키가 폐기되었습니다",
+	})
+}
@@ -1,14 +1,18 @@
 package api
 
 import (
-	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/authn"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
 	"github.com/choi-jiwoong/go-quickstart/internal/repository"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // Login은 사용자 로그인을 처리합니다.
@@ -21,22 +25,35 @@ func Login(c *gin.Context) {
 		return
 	}
 
-	// 사용자 조회
+	// 잠금 확인을 위해 로컬 사용자 조회 (LDAP 사용자가 처음 로그인하는 경우에는 없음)
 	user, err := repository.GetUserByUsername(req.Username)
-	if err != nil {
-		// 로그인 실패 기록
-		recordLoginAttempt(c, 0, false)
-		
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "사용자명 또는 비밀번호가 올바르지 않습니다",
+	userFound := err == nil
+
+	lockedUntil, locked, lockErr := auth.CheckLockout(user)
+	if userFound && lockErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다",
 		})
 		return
 	}
 
-	// 비밀번호 검증
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
-	if err != nil {
-		// 로그인 실패 기록
+	// 계정 잠금 중에는 비밀번호가 맞더라도 로그인 거부
+	// 응답 시간으로 비밀번호 일치 여부를 알 수 없도록 검증에 걸리는 시간만큼 기다린 뒤 응답
+	if userFound && locked {
+		password.VerifyDummy(req.Password)
+		respondLocked(c, lockedUntil)
+		return
+	}
+
+	// 설정된 인증 방식을 순서대로 시도
+	authenticated, err := authn.Authenticate(req.Username, req.Password)
+	if err == authn.ErrUnavailable {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "인증 서버에 연결할 수 없습니다. 잠시 후 다시 시도하세요",
+		})
+		return
+	} else if err != nil {
+		// 로그인 실패 기록 (존재하지 않는 사용자는 user_id 없이 기록)
 		recordLoginAttempt(c, user.ID, false)
 		
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -44,43 +61,221 @@ func Login(c *gin.Context) {
 		})
 		return
 	}
+	user = authenticated
+
+	// 이메일 인증을 마치지 않았거나 비활성화된 계정은 로그인 불가 (비밀번호가 맞은 경우에만 알려줌)
+	if !user.IsActive() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": inactiveMessage(user),
+		})
+		return
+	}
+
+	// 2단계 인증이 활성화된 사용자는 챌린지 토큰을 발급하고 /login/mfa에서 로그인을 완료
+	if user.MFAEnabled {
+		issueMFAChallenge(c, user)
+		return
+	}
 
 	// 로그인 성공 기록
 	recordLoginAttempt(c, user.ID, true)
 
-	// 토큰 생성 (실제 구현에서는 JWT 토큰 생성 필요)
-	var token string
-	if user.Role == "ADMIN" {
-		token = "admin-token"
-	} else {
-		token = fmt.Sprintf("user-token-%d", user.ID)
+	// 토큰 발급
+	response, err := startSession(c, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
 	}
 
-	// 응답 생성
-	response := models.LoginResponse{
-		ID:       user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Role:     user.Role,
-		Token:    token,
+	c.JSON(http.StatusOK, response)
+}
+
+// RefreshToken은 리프레시 토큰을 새 액세스 토큰과 리프레시 토큰으로 교체합니다.
+// 이미 사용된 리프레시 토큰이 다시 제출되면 탈취로 간주하고 같은 계열의 토큰을 모두 폐기합니다.
+func RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	stored, err := repository.GetRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+	if err != nil || stored.RevokedAt != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "유효하지 않은 리프레시 토큰입니다",
+		})
+		return
+	}
+
+	if stored.UsedAt != nil {
+		revokeTokenFamily(c, stored.UserID, stored.FamilyID)
+		return
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "만료된 리프레시 토큰입니다",
+		})
+		return
+	}
+
+	// 동시 요청에서도 한 번만 교체되도록 사용 표시
+	marked, err := repository.MarkRefreshTokenUsed(stored.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 갱신 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !marked {
+		revokeTokenFamily(c, stored.UserID, stored.FamilyID)
+		return
+	}
+
+	user, err := repository.GetUserByID(stored.UserID)
+	if err != nil || !user.IsActive() {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "유효하지 않은 리프레시 토큰입니다",
+		})
+		return
+	}
+
+	response, err := issueTokens(user, stored.FamilyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 세션 만료 시각을 새 리프레시 토큰에 맞춤
+	now := time.Now()
+	if err := repository.RenewSession(stored.FamilyID, now, now.Add(auth.RefreshTokenTTL())); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 갱신 중 오류가 발생했습니다",
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
-// recordLoginAttempt는 로그인 시도를 기록합니다.
+// Logout은 현재 액세스 토큰과 같은 세션의 리프레시 토큰을 폐기합니다.
+func Logout(c *gin.Context) {
+	claims, ok := middleware.GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "인증이 필요합니다",
+		})
+		return
+	}
+
+	if err := auth.RevokeToken(claims); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그아웃 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 같은 세션에서 발급된 다른 액세스 토큰과 리프레시 토큰도 함께 폐기
+	if claims.SessionID != "" {
+		authUser, _ := middleware.GetAuthUser(c)
+		if err := endSession(models.Session{UserID: authUser.ID, FamilyID: claims.SessionID}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "로그아웃 처리 중 오류가 발생했습니다",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "로그아웃되었습니다",
+	})
+}
+
+// revokeTokenFamily는 재사용이 감지된 토큰 계열과 그 세션에서 발급된 액세스 토큰을 폐기하고 오류를 응답합니다.
+func revokeTokenFamily(c *gin.Context, userID int64, familyID string) {
+	if err := endSession(models.Session{UserID: userID, FamilyID: familyID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 갱신 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"error": "이미 사용된 리프레시 토큰입니다. 보안을 위해 모든 관련 세션이 종료되었습니다",
+	})
+}
+
+// issueTokens는 사용자에게 액세스 토큰과 리프레시 토큰을 발급하고 로그인 응답을 생성합니다.
+// familyID는 토큰 계열이자 세션 ID이며, 새 세션은 startSession으로 시작합니다.
+func issueTokens(user models.User, familyID string) (models.LoginResponse, error) {
+	accessToken, expiresAt, err := auth.GenerateAccessToken(user, familyID)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	refreshToken, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	stored := models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(auth.RefreshTokenTTL()),
+	}
+	if err := repository.CreateRefreshToken(&stored); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	return models.LoginResponse{
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		Role:         user.Role,
+		Token:        accessToken,
+		ExpiresAt:    &expiresAt,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// respondLocked는 계정 잠금 응답과 Retry-After 헤더를 설정합니다.
+func respondLocked(c *gin.Context, lockedUntil time.Time) {
+	retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":        "로그인 실패가 반복되어 계정이 일시적으로 잠겼습니다",
+		"locked_until": lockedUntil,
+	})
+}
+
+// recordLoginAttempt는 로그인 시도를 기록합니다. userID가 0이면 존재하지 않는 사용자의 시도로 기록합니다.
 func recordLoginAttempt(c *gin.Context, userID int64, success bool) {
 	now := time.Now()
 	history := models.LoginHistory{
 		IPAddress: c.ClientIP(),
 		LoginTime: &now,
 		Success:   success,
-		UserAgent: c.Request.UserAgent(),
-		UserID:    userID,
+		UserAgent: truncate(c.Request.UserAgent(), 255),
+	}
+	if userID != 0 {
+		history.UserID = &userID
 	}
 
 	// 비동기적으로 로그인 기록 저장
-	go func() {
-		repository.CreateLoginHistory(&history)
-	}()
-}
\ No newline at end of file
+	go repository.CreateLoginHistory(&history)
+}
+
+// inactiveMessage는 로그인할 수 없는 계정 상태에 맞는 오류 메시지를 반환합니다.
+func inactiveMessage(user models.User) string {
+	if user.Status == models.UserStatusDisabled {
+		return "비활성화된 계정입니다"
+	}
+	return "이메일 인증이 완료되지 않은 계정입니다"
+}
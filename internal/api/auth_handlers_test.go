@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -18,6 +23,11 @@ func TestLogin(t *testing.T) {
 	router, mockRepo := setupTest(t)
 	router.POST("/login", Login)
 
+	// JWT 서명 키 초기화
+	if err := auth.InitJWT(config.NewConfig()); err != nil {
+		t.Fatalf("JWT 초기화 실패: %v", err)
+	}
+
 	// 비밀번호 해시 생성
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
 
@@ -45,6 +55,9 @@ func TestLogin(t *testing.T) {
 				}
 				mockRepo.On("GetUserByUsername", "testuser").Return(user, nil)
 				mockRepo.On("CreateLoginHistory", mock.AnythingOfType("*models.LoginHistory")).Return(nil)
+				mockRepo.On("GetLoginFailureStreak", mock.Anything, mock.Anything).Return(int64(0), (*time.Time)(nil), nil)
+				mockRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+				mockRepo.On("CreateSession", mock.AnythingOfType("*models.Session")).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   `"username":"testuser"`,
@@ -58,6 +71,7 @@ func TestLogin(t *testing.T) {
 			setupMock: func() {
 				mockRepo.On("GetUserByUsername", "nonexistent").Return(models.User{}, assert.AnError)
 				mockRepo.On("CreateLoginHistory", mock.AnythingOfType("*models.LoginHistory")).Return(nil)
+				mockRepo.On("GetLoginFailureStreak", mock.Anything, mock.Anything).Return(int64(0), (*time.Time)(nil), nil)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "사용자명 또는 비밀번호가 올바르지 않습니다",
@@ -78,6 +92,7 @@ func TestLogin(t *testing.T) {
 				}
 				mockRepo.On("GetUserByUsername", "testuser").Return(user, nil)
 				mockRepo.On("CreateLoginHistory", mock.AnythingOfType("*models.LoginHistory")).Return(nil)
+				mockRepo.On("GetLoginFailureStreak", mock.Anything, mock.Anything).Return(int64(0), (*time.Time)(nil), nil)
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody:   "사용자명 또는 비밀번호가 올바르지 않습니다",
@@ -101,6 +116,92 @@ func TestLogin(t *testing.T) {
 			// 응답 검증
 			assert.Equal(t, tt.expectedStatus, w.Code)
 			assert.Contains(t, w.Body.String(), tt.expectedBody)
+
+			// 성공 시 발급된 토큰이 검증 가능한지 확인
+			if w.Code == http.StatusOK {
+				var response models.LoginResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+				claims, err := auth.ParseAccessToken(response.Token)
+				assert.NoError(t, err)
+				assert.Equal(t, "1", claims.Subject)
+				assert.NotEmpty(t, response.RefreshToken)
+			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// TestLoginRehashesOutdatedPassword는 오래된 매개변수로 저장된 비밀번호가 로그인 시 재해싱되는지 테스트합니다.
+func TestLoginRehashesOutdatedPassword(t *testing.T) {
+	router, mockRepo := setupTest(t)
+	router.POST("/login", Login)
+
+	if err := auth.InitJWT(config.NewConfig()); err != nil {
+		t.Fatalf("JWT 초기화 실패: %v", err)
+	}
+
+	// 현재 기본 비용보다 낮은 비용으로 저장된 해시
+	outdatedHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := models.User{
+		ID:       5,
+		Username: "olduser",
+		Email:    "old@example.com",
+		Password: string(outdatedHash),
+		Role:     "USER",
+	}
+	mockRepo.On("GetUserByUsername", "olduser").Return(user, nil)
+	mockRepo.On("CreateLoginHistory", mock.AnythingOfType("*models.LoginHistory")).Return(nil)
+	mockRepo.On("GetLoginFailureStreak", mock.Anything, mock.Anything).Return(int64(0), (*time.Time)(nil), nil)
+	mockRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+	mockRepo.On("CreateSession", mock.AnythingOfType("*models.Session")).Return(nil)
+	mockRepo.On("UpdateUserPassword", int64(5), mock.MatchedBy(func(hash string) bool {
+		return !password.NeedsRehash(hash)
+	})).Return(nil)
+
+	body, _ := json.Marshal(models.LoginRequest{Username: "olduser", Password: "password123"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockRepo.AssertCalled(t, "UpdateUserPassword", int64(5), mock.AnythingOfType("string"))
+}
+
+// TestLoginLockout은 연속 로그인 실패로 잠긴 계정의 로그인 거부를 테스트합니다.
+func TestLoginLockout(t *testing.T) {
+	router, mockRepo := setupTest(t)
+	router.POST("/login", Login)
+
+	if err := auth.InitJWT(config.NewConfig()); err != nil {
+		t.Fatalf("JWT 초기화 실패: %v", err)
+	}
+	auth.InitLockout(&config.Config{
+		LockoutThreshold:  5,
+		LockoutBaseWindow: time.Minute,
+		LockoutMaxWindow:  time.Hour,
+	})
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := models.User{ID: 9, Username: "lockeduser", Password: string(hashedPassword), Role: "USER"}
+	lastFailure := time.Now().Add(-10 * time.Second)
+
+	mockRepo.On("GetUserByUsername", "lockeduser").Return(user, nil)
+	mockRepo.On("GetLoginFailureStreak", int64(9), (*time.Time)(nil)).Return(int64(5), &lastFailure, nil)
+
+	// 비밀번호가 맞더라도 잠금 기간에는 거부
+	body, _ := json.Marshal(models.LoginRequest{Username: "lockeduser", Password: "password123"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "계정이 일시적으로 잠겼습니다")
+
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.InDelta(t, 50, retryAfter, 2)
+
+	mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+}
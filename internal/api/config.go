@@ -0,0 +1,12 @@
+package api
+
+import "github.com/choi-jiwoong/go-quickstart/internal/config"
+
+// appConfig는 핸들러에서 사용하는 애플리케이션 설정입니다.
+// 서버 시작 시 Init으로 교체되며, 테스트에서는 환경 변수 기반 기본 설정을 사용합니다.
+var appConfig = config.NewConfig()
+
+// Init은 핸들러에서 사용할 설정을 지정합니다.
+func Init(cfg *config.Config) {
+	appConfig = cfg
+}
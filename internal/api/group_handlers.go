@@ -0,0 +1,345 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetGroups는 같은 조직의 모든 그룹 목록을 반환합니다.
+func GetGroups(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	groups, err := repository.GetGroups(tenant.Of(authUser))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+// GetGroup은 그룹 정보를 구성원과 하위 그룹과 함께 반환합니다.
+func GetGroup(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// CreateGroup은 새 그룹을 생성합니다.
+func CreateGroup(c *gin.Context) {
+	var req models.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	organizationID, ok := resolveOrganization(c, authUser, req.OrganizationID)
+	if !ok {
+		return
+	}
+
+	if !checkGroupName(c, organizationID, req.Name) {
+		return
+	}
+
+	group := models.Group{
+		OrganizationID: organizationID,
+		Name:           req.Name,
+		Description:    req.Description,
+	}
+	if err := repository.CreateGroup(&group); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// UpdateGroup은 그룹 이름과 설명을 변경합니다.
+func UpdateGroup(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if req.Name != "" && req.Name != group.Name {
+		if !checkGroupName(c, group.OrganizationID, req.Name) {
+			return
+		}
+		group.Name = req.Name
+	}
+	if req.Description != "" {
+		group.Description = req.Description
+	}
+
+	if err := repository.UpdateGroup(&group); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 수정 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup은 그룹을 삭제합니다. 구성원과 하위 그룹은 삭제되지 않고 그룹에서만 제외됩니다.
+func DeleteGroup(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteGroup(group.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "그룹이 삭제되었습니다",
+	})
+}
+
+// AddGroupMember는 사용자나 다른 그룹을 그룹의 구성원으로 추가합니다.
+// 구성원은 그룹과 같은 조직에 속해야 하며, 그룹이 자신의 하위 그룹이 되는 순환 구조는 허용하지 않습니다.
+func AddGroupMember(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	var req models.AddGroupMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.UserID == 0) == (req.GroupID == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "user_id와 group_id 중 하나만 지정해야 합니다",
+		})
+		return
+	}
+
+	scope := repository.Tenant{OrganizationID: group.OrganizationID}
+
+	if req.UserID != 0 {
+		if _, err := repository.GetTenantUserByID(scope, req.UserID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "그룹과 같은 조직의 사용자만 추가할 수 있습니다",
+			})
+			return
+		}
+		if err := repository.AddGroupUser(group.ID, req.UserID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "그룹 구성원 추가 중 오류가 발생했습니다",
+			})
+			return
+		}
+	} else {
+		if _, err := repository.GetTenantGroupByID(scope, req.GroupID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "같은 조직의 그룹만 하위 그룹으로 추가할 수 있습니다",
+			})
+			return
+		}
+		if !checkGroupCycle(c, group.ID, req.GroupID) {
+			return
+		}
+		if err := repository.AddSubgroup(group.ID, req.GroupID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "그룹 구성원 추가 중 오류가 발생했습니다",
+			})
+			return
+		}
+	}
+
+	group, ok = findGroup(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, group)
+}
+
+// RemoveGroupUser는 사용자를 그룹에서 제외합니다.
+func RemoveGroupUser(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	if err := repository.RemoveGroupUser(group.ID, userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 구성원 제외 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "그룹에서 제외되었습니다",
+	})
+}
+
+// RemoveSubgroup은 하위 그룹을 그룹에서 제외합니다.
+func RemoveSubgroup(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	childID, err := strconv.ParseInt(c.Param("groupId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 그룹 ID 형식입니다",
+		})
+		return
+	}
+
+	if err := repository.RemoveSubgroup(group.ID, childID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 구성원 제외 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "그룹에서 제외되었습니다",
+	})
+}
+
+// AssignGroupRole은 그룹에 역할을 지정합니다. 그룹과 하위 그룹의 모든 구성원이 역할의 권한을 추가로 가집니다.
+func AssignGroupRole(c *gin.Context) {
+	group, ok := findGroup(c)
+	if !ok {
+		return
+	}
+
+	var req models.GroupRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if req.Role != "" && !auth.RoleExists(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "존재하지 않는 역할입니다",
+		})
+		return
+	}
+	authUser, _ := middleware.GetAuthUser(c)
+	if req.Role != "" && req.Role != group.Role && !checkGrantableRole(c, authUser, req.Role) {
+		return
+	}
+
+	group.Role = req.Role
+	if err := repository.UpdateGroup(&group); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 지정 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// findGroup은 경로의 그룹 ID로 같은 조직의 그룹을 조회하고, 없으면 오류를 응답합니다.
+func findGroup(c *gin.Context) (models.Group, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 그룹 ID 형식입니다",
+		})
+		return models.Group{}, false
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	group, err := repository.GetTenantGroupByID(tenant.Of(authUser), id)
+	if err == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "그룹을 찾을 수 없습니다",
+		})
+		return group, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 조회 중 오류가 발생했습니다",
+		})
+		return group, false
+	}
+	return group, true
+}
+
+// checkGroupName은 조직 안에서 그룹 이름이 이미 사용 중인지 확인합니다.
+func checkGroupName(c *gin.Context, organizationID int64, name string) bool {
+	_, err := repository.GetGroupByName(organizationID, name)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 사용 중인 그룹 이름입니다",
+		})
+		return false
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 확인 중 오류가 발생했습니다",
+		})
+		return false
+	}
+	return true
+}
+
+// checkGroupCycle은 child를 parent의 하위 그룹으로 추가해도 순환이 생기지 않는지 확인합니다.
+func checkGroupCycle(c *gin.Context, parentID, childID int64) bool {
+	ancestors, err := repository.GetGroupAncestorIDs(parentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "그룹 확인 중 오류가 발생했습니다",
+		})
+		return false
+	}
+
+	if childID == parentID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "그룹을 자신의 하위 그룹으로 추가할 수 없습니다",
+		})
+		return false
+	}
+	for _, id := range ancestors {
+		if id == childID {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "상위 그룹을 하위 그룹으로 추가할 수 없습니다",
+			})
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonateUser는 관리자가 대상 사용자로 가장하여 API를 호출할 수 있는 단기 액세스 토큰을 발급합니다.
+// users:impersonate 권한이 필요하며, 자신에게 없는 권한을 가진 사용자로는 가장할 수 없습니다.
+// 발급된 토큰에는 관리자가 함께 기록되어 요청 로그와 권한 결정 로그에 실제로 요청한 관리자가 남습니다.
+// 가장한 관리자, 대상 사용자, 이유, 만료 시각과 세션은 감사 기록으로 저장됩니다.
+func ImpersonateUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	var req models.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	if id == authUser.ID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "자기 자신으로는 가장할 수 없습니다",
+		})
+		return
+	}
+
+	// 사용자 존재 여부 확인 (다른 조직의 사용자는 찾을 수 없음)
+	target, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+	if !target.IsActive() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "활성 상태가 아닌 사용자로는 가장할 수 없습니다",
+		})
+		return
+	}
+	if !checkManageable(c, authUser, target) {
+		return
+	}
+
+	// 가장으로 권한이 늘어나지 않도록 대상 사용자의 권한이 모두 관리자에게 있는지 확인
+	groups, err := repository.GetUserGroups(target.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+	target.Groups = groups
+	for _, p := range auth.Permissions {
+		if auth.HasPermission(target, p.Name) && !auth.HasPermission(authUser, p.Name) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "자신에게 없는 권한을 가진 사용자로는 가장할 수 없습니다: " + p.Name,
+			})
+			return
+		}
+	}
+
+	// 가장 토큰도 세션으로 기록하여 대상 사용자와 관리자가 목록에서 확인하고 종료할 수 있게 함
+	sessionID, err := auth.RandomHex(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 발급 중 오류가 발생했습니다",
+		})
+		return
+	}
+	token, expiresAt, err := auth.GenerateImpersonationToken(target, authUser, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 발급 중 오류가 발생했습니다",
+		})
+		return
+	}
+	now := time.Now()
+	session := models.Session{
+		UserID:         target.ID,
+		FamilyID:       sessionID,
+		IPAddress:      c.ClientIP(),
+		UserAgent:      truncate(c.Request.UserAgent(), 255),
+		ImpersonatorID: authUser.ID,
+		LastUsedAt:     now,
+		ExpiresAt:      expiresAt,
+	}
+	audit := models.ImpersonationAudit{
+		ActorID:   authUser.ID,
+		TargetID:  target.ID,
+		SessionID: sessionID,
+		Reason:    req.Reason,
+		IPAddress: session.IPAddress,
+		ExpiresAt: expiresAt,
+	}
+	if err := repository.CreateImpersonationSession(&session, &audit); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 발급 중 오류가 발생했습니다",
+		})
+		return
+	}
+	log.Printf("사용자 가장 토큰 발급: 관리자 %d(%s) → 사용자 %d(%s), 이유 %q, 만료 %s",
+		authUser.ID, authUser.Username, target.ID, target.Username, req.Reason, expiresAt.Format("2006-01-02 15:04:05"))
+
+	c.JSON(http.StatusOK, models.ImpersonationResponse{
+		LoginResponse: models.LoginResponse{
+			ID:        target.ID,
+			Username:  target.Username,
+			Email:     target.Email,
+			Role:      target.Role,
+			Token:     token,
+			ExpiresAt: &expiresAt,
+		},
+		ActorID: authUser.ID,
+	})
+}
@@ -2,15 +2,42 @@ package api
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/authn"
+	"github.com/choi-jiwoong/go-quickstart/internal/authn/ldaptest"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
 	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/mail"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls/mtlstest"
+	"github.com/choi-jiwoong/go-quickstart/internal/oauth"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc/oidctest"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml/samltest"
+	"github.com/choi-jiwoong/go-quickstart/internal/scim"
+	"github.com/choi-jiwoong/go-quickstart/internal/signup"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/choi-jiwoong/go-quickstart/pkg/signing"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -27,8 +54,10 @@ func setupIntegrationTest() *gin.Engine {
 		panic("테스트 데이터베이스 연결 실패")
 	}
 	
-	// 테이블 생성
-	err = database.DB.AutoMigrate(&models.User{})
+	// 테이블 생성 (사용자를 삭제할 때 함께 삭제되는 테이블 포함)
+	err = database.DB.AutoMigrate(&models.User{}, &models.Identity{}, &models.RefreshToken{}, &models.Session{},
+		&models.RecoveryCode{}, &models.PasswordResetToken{}, &models.EmailVerificationToken{},
+		&models.PersonalAccessToken{}, &models.APIKey{}, &models.LoginHistory{})
 	if err != nil {
 		panic("테스트 테이블 마이그레이션 실패")
 	}
@@ -55,6 +84,7 @@ func setupIntegrationTest() *gin.Engine {
 	
 	// 라우터 설정
 	router := gin.New()
+	router.Use(withAuthUser(testAdmin))
 	router.GET("/users", GetUsers)
 	router.GET("/user/:id", GetUser)
 	router.POST("/user", CreateUser)
@@ -163,4 +193,2600 @@ func TestUserCRUDIntegration(t *testing.T) {
 		router.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
-}
\ No newline at end of file
+}
+
+// setupTokenIntegrationTest는 토큰 관련 통합 테스트를 위한 라우터와 사용자를 설정합니다.
+func setupTokenIntegrationTest(t *testing.T) (*gin.Engine, models.User) {
+	gin.SetMode(gin.TestMode)
+
+	var err error
+	database.DB, err = gorm.Open(sqlite.Open("file:refresh?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("테스트 데이터베이스 연결 실패: %v", err)
+	}
+	if err := database.DB.AutoMigrate(&models.User{}, &models.Identity{}, &models.RefreshToken{}, &models.Session{}, &models.TokenRevocation{},
+		&models.RecoveryCode{}, &models.PasswordResetToken{}, &models.EmailVerificationToken{},
+		&models.PersonalAccessToken{}, &models.APIKey{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM token_revocations")
+	database.DB.Exec("DELETE FROM refresh_tokens")
+	database.DB.Exec("DELETE FROM sessions")
+	database.DB.Exec("DELETE FROM users")
+
+	if err := auth.InitJWT(config.NewConfig()); err != nil {
+		t.Fatalf("JWT 초기화 실패: %v", err)
+	}
+
+	user := models.User{Username: "refreshuser", Email: "refresh@example.com", Password: "unused", Role: "USER"}
+	database.DB.Create(&user)
+
+	router := gin.New()
+	router.POST("/token/refresh", RefreshToken)
+
+	authGroup := router.Group("")
+	authGroup.Use(middleware.RequireAuth())
+	authGroup.GET("/user/:id", GetUser)
+	authGroup.PUT("/user/:id", UpdateUser)
+	authGroup.POST("/logout", Logout)
+	authGroup.DELETE("/user/:id/sessions", RevokeUserSessions)
+	return router, user
+}
+
+// loginSession은 사용자가 로그인한 것처럼 새 세션을 시작하고 토큰을 발급합니다.
+func loginSession(user models.User) (models.LoginResponse, error) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/login", nil)
+	return startSession(c, user)
+}
+
+// postRefresh는 리프레시 토큰으로 갱신 요청을 보냅니다.
+func postRefresh(router *gin.Engine, refreshToken string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: refreshToken})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestRefreshTokenRotationIntegration은 리프레시 토큰 교체와 재사용 감지를 통합 테스트합니다.
+func TestRefreshTokenRotationIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+
+	initial, err := loginSession(user)
+	assert.NoError(t, err)
+
+	// 1. 정상 갱신 시 새 토큰 쌍 발급
+	w := postRefresh(router, initial.RefreshToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var rotated models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+	assert.NotEmpty(t, rotated.Token)
+	assert.NotEqual(t, initial.RefreshToken, rotated.RefreshToken)
+
+	userPath := fmt.Sprintf("/user/%d", user.ID)
+	w = authRequest(router, "GET", userPath, rotated.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 2. 이미 사용된 토큰 재사용 시 거부
+	w = postRefresh(router, initial.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "이미 사용된 리프레시 토큰입니다")
+
+	// 3. 재사용 감지 후 같은 계열의 최신 토큰과 그 계열에서 발급된 액세스 토큰도 폐기됨
+	w = postRefresh(router, rotated.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = authRequest(router, "GET", userPath, rotated.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 4. 다른 계열의 토큰은 영향을 받지 않음
+	other, err := loginSession(user)
+	assert.NoError(t, err)
+	w = postRefresh(router, other.RefreshToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 5. 알 수 없는 토큰과 만료된 토큰 거부
+	w = postRefresh(router, "unknown-token")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	expired, err := loginSession(user)
+	assert.NoError(t, err)
+	database.DB.Model(&models.RefreshToken{}).
+		Where("token_hash = ?", auth.HashToken(expired.RefreshToken)).
+		Update("expires_at", time.Now().Add(-time.Minute))
+	w = postRefresh(router, expired.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "만료된 리프레시 토큰입니다")
+}
+
+// authRequest는 액세스 토큰을 담아 요청을 보냅니다.
+func authRequest(router *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Buffer
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(data)
+	} else {
+		reader = bytes.NewBuffer(nil)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestLogoutIntegration은 로그아웃과 토큰 폐기를 통합 테스트합니다.
+func TestLogoutIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	userPath := fmt.Sprintf("/user/%d", user.ID)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+	other, err := loginSession(user)
+	assert.NoError(t, err)
+
+	w := authRequest(router, "GET", userPath, session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 같은 세션에서 갱신으로 발급된 액세스 토큰
+	w = postRefresh(router, session.RefreshToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var refreshed models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+
+	// 로그아웃 후 같은 세션의 액세스 토큰과 리프레시 토큰은 사용할 수 없음
+	w = authRequest(router, "POST", "/logout", session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "GET", userPath, session.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "폐기된 토큰입니다")
+
+	w = authRequest(router, "GET", userPath, refreshed.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postRefresh(router, refreshed.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 다른 세션은 유지됨
+	w = authRequest(router, "GET", userPath, other.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestPasswordChangeRevokesTokensIntegration은 비밀번호 변경 시 모든 토큰이 폐기되는지 통합 테스트합니다.
+func TestPasswordChangeRevokesTokensIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	userPath := fmt.Sprintf("/user/%d", user.ID)
+
+	// 비밀번호 변경 이전에 발급된 토큰
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+	time.Sleep(time.Second)
+
+	w := authRequest(router, "PUT", userPath, session.Token, models.UpdateUserRequest{Password: "newpassword123"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "GET", userPath, session.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postRefresh(router, session.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 변경 이후 새로 발급된 토큰은 사용 가능
+	fresh, err := loginSession(user)
+	assert.NoError(t, err)
+	w = authRequest(router, "GET", userPath, fresh.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestRevokeUserSessionsIntegration은 관리자의 사용자 세션 전체 종료를 통합 테스트합니다.
+func TestRevokeUserSessionsIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+
+	admin := models.User{Username: "sessionadmin", Email: "sessionadmin@example.com", Password: "unused", Role: "ADMIN"}
+	database.DB.Create(&admin)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	time.Sleep(time.Second)
+
+	w := authRequest(router, "DELETE", fmt.Sprintf("/user/%d/sessions", user.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), session.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = postRefresh(router, session.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 관리자 자신의 세션은 유지됨
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", admin.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestTOTPLoginIntegration은 TOTP 등록, 2단계 로그인, 복구 코드, 해제 흐름을 통합 테스트합니다.
+func TestTOTPLoginIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.RecoveryCode{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM recovery_codes")
+	database.DB.Exec("DELETE FROM login_history")
+
+	router.POST("/login", Login)
+	router.POST("/login/mfa", LoginMFA)
+	mfaGroup := router.Group("/mfa/totp")
+	mfaGroup.Use(middleware.RequireAuth())
+	mfaGroup.POST("/enroll", EnrollTOTP)
+	mfaGroup.POST("/verify", VerifyTOTP)
+	mfaGroup.POST("/disable", DisableTOTP)
+
+	hash, err := password.Hash("mfa-password-1")
+	assert.NoError(t, err)
+	user := models.User{Username: "mfauser", Email: "mfa@example.com", Password: hash, Role: "USER"}
+	database.DB.Create(&user)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+
+	login := func() *httptest.ResponseRecorder {
+		return authRequest(router, "POST", "/login", "", models.LoginRequest{Username: "mfauser", Password: "mfa-password-1"})
+	}
+
+	// 1. 등록 시작 및 확인 전까지는 2단계 인증이 적용되지 않음
+	w := authRequest(router, "POST", "/mfa/totp/enroll", session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var enroll models.TOTPEnrollResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &enroll))
+	assert.NotEmpty(t, enroll.Secret)
+	assert.Contains(t, enroll.ProvisioningURI, "otpauth://totp/")
+
+	w = login()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "mfa_token")
+
+	// 2. 잘못된 코드로는 활성화할 수 없음
+	w = authRequest(router, "POST", "/mfa/totp/verify", session.Token, models.TOTPVerifyRequest{Code: "000000"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	now := time.Now()
+	code, _ := auth.TOTPCode(enroll.Secret, auth.TOTPStep(now))
+	w = authRequest(router, "POST", "/mfa/totp/verify", session.Token, models.TOTPVerifyRequest{Code: code})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var verified models.TOTPVerifyResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+	assert.Len(t, verified.RecoveryCodes, recoveryCodeCount)
+
+	// 3. 비밀번호만으로는 토큰이 발급되지 않고 챌린지가 반환됨
+	w = login()
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var challenge models.MFAChallengeResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+	assert.True(t, challenge.MFARequired)
+	assert.NotEmpty(t, challenge.MFAToken)
+
+	// 챌린지 토큰은 액세스 토큰으로 사용할 수 없음
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), challenge.MFAToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 4. 활성화에 사용한 코드는 재사용할 수 없음
+	w = authRequest(router, "POST", "/login/mfa", "", models.MFALoginRequest{MFAToken: challenge.MFAToken, Code: code})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 5. 다음 시간 단계의 코드로 로그인 완료
+	next, _ := auth.TOTPCode(enroll.Secret, auth.TOTPStep(now)+1)
+	w = authRequest(router, "POST", "/login/mfa", "", models.MFALoginRequest{MFAToken: challenge.MFAToken, Code: next})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var tokens models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	// 6. 복구 코드는 한 번만 사용 가능
+	recovery := strings.ToUpper(verified.RecoveryCodes[0])
+	w = authRequest(router, "POST", "/login/mfa", "", models.MFALoginRequest{MFAToken: challenge.MFAToken, Code: recovery})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "POST", "/login/mfa", "", models.MFALoginRequest{MFAToken: challenge.MFAToken, Code: recovery})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 7. 비밀번호와 복구 코드로 해제하면 다시 비밀번호만으로 로그인
+	w = authRequest(router, "POST", "/mfa/totp/disable", tokens.Token, models.TOTPDisableRequest{
+		Password: "wrong-password",
+		Code:     verified.RecoveryCodes[1],
+	})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = authRequest(router, "POST", "/mfa/totp/disable", tokens.Token, models.TOTPDisableRequest{
+		Password: "mfa-password-1",
+		Code:     verified.RecoveryCodes[1],
+	})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = login()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "mfa_token")
+}
+
+// captureTransport는 발송된 메일을 기록하는 테스트용 Transport입니다.
+type captureTransport struct {
+	messages []mail.Message
+}
+
+func (c *captureTransport) Send(msg mail.Message) error {
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+// TestPasswordResetIntegration은 비밀번호 재설정 메일 요청과 재설정 흐름을 통합 테스트합니다.
+func TestPasswordResetIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.PasswordResetToken{}, &models.OutboxMail{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM password_reset_tokens")
+	database.DB.Exec("DELETE FROM outbox_mails")
+
+	transport := &captureTransport{}
+	mail.SetTransport(transport)
+	t.Cleanup(func() { mail.SetTransport(nil) })
+
+	router.POST("/login", Login)
+	router.POST("/password/forgot", ForgotPassword)
+	router.POST("/password/reset", ResetPassword)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+
+	// 1. 존재하는 주소와 존재하지 않는 주소의 응답이 같음
+	known := authRequest(router, "POST", "/password/forgot", "", models.ForgotPasswordRequest{Email: user.Email})
+	unknown := authRequest(router, "POST", "/password/forgot", "", models.ForgotPasswordRequest{Email: "nobody@example.com"})
+	assert.Equal(t, http.StatusAccepted, known.Code)
+	assert.Equal(t, known.Code, unknown.Code)
+	assert.Equal(t, known.Body.String(), unknown.Body.String())
+
+	// 응답 시간으로 계정 존재 여부를 알 수 없도록 메일은 응답 후 대기열에 저장됨
+	pendingMails := func(n int64) func() bool {
+		return func() bool {
+			var count int64
+			database.DB.Model(&models.OutboxMail{}).Where("status = ?", models.MailStatusPending).Count(&count)
+			return count == n
+		}
+	}
+	assert.Eventually(t, pendingMails(1), time.Second, 10*time.Millisecond)
+
+	// 재발송 대기 시간 안에는 같은 주소로 다시 요청해도 새 토큰과 메일을 만들지 않음
+	sendPasswordResets(user.Email)
+	assert.True(t, pendingMails(1)())
+	var issued int64
+	database.DB.Model(&models.PasswordResetToken{}).Where("user_id = ?", user.ID).Count(&issued)
+	assert.Equal(t, int64(1), issued)
+
+	// 2. 메일은 대기열을 거쳐 발송되며 존재하는 계정에만 발송됨
+	sent, err := mail.DispatchPending(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, transport.messages, 1)
+	assert.Equal(t, user.Email, transport.messages[0].To)
+
+	_, rawLink, _ := strings.Cut(transport.messages[0].Body, appConfig.PasswordResetURL+"?token=")
+	token, _, _ := strings.Cut(rawLink, "\n")
+	assert.NotEmpty(t, token)
+
+	var stored models.PasswordResetToken
+	database.DB.Where("user_id = ?", user.ID).First(&stored)
+	assert.Equal(t, auth.HashToken(token), stored.TokenHash)
+
+	// 3. 정책 위반 시 토큰은 소모되지 않음
+	w := authRequest(router, "POST", "/password/reset", "", models.ResetPasswordRequest{Token: token, Password: "short"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "violations")
+
+	time.Sleep(time.Second)
+	w = authRequest(router, "POST", "/password/reset", "", models.ResetPasswordRequest{Token: token, Password: "brand-new-secret-9"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 4. 새 비밀번호로 로그인 가능하고 기존 세션은 폐기됨
+	w = authRequest(router, "POST", "/login", "", models.LoginRequest{Username: user.Username, Password: "brand-new-secret-9"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), session.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 5. 토큰은 한 번만 사용 가능
+	w = authRequest(router, "POST", "/password/reset", "", models.ResetPasswordRequest{Token: token, Password: "another-secret-42"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 6. 만료된 토큰과 알 수 없는 토큰 거부
+	authRequest(router, "POST", "/password/forgot", "", models.ForgotPasswordRequest{Email: user.Email})
+	assert.Eventually(t, pendingMails(1), time.Second, 10*time.Millisecond)
+	mail.DispatchPending(time.Now())
+	_, rawLink, _ = strings.Cut(transport.messages[len(transport.messages)-1].Body, "?token=")
+	expired, _, _ := strings.Cut(rawLink, "\n")
+	database.DB.Model(&models.PasswordResetToken{}).
+		Where("token_hash = ?", auth.HashToken(expired)).
+		Update("expires_at", time.Now().Add(-time.Minute))
+
+	w = authRequest(router, "POST", "/password/reset", "", models.ResetPasswordRequest{Token: expired, Password: "another-secret-42"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "POST", "/password/reset", "", models.ResetPasswordRequest{Token: "unknown", Password: "another-secret-42"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestMailQueueDropsWhenFull은 계정 메일 대기열이 가득 차면 요청을 버리는지 테스트합니다.
+func TestMailQueueDropsWhenFull(t *testing.T) {
+	q := newMailQueue(0, 1)
+	assert.True(t, q.enqueue(func() {}))
+	assert.False(t, q.enqueue(func() {}))
+
+	done := make(chan struct{})
+	q = newMailQueue(1, 1)
+	assert.True(t, q.enqueue(func() { close(done) }))
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("대기열 작업이 처리되지 않았습니다")
+	}
+}
+
+// TestRegisterIntegration은 회원 가입, 이메일 인증, 로그인 흐름을 통합 테스트합니다.
+func TestRegisterIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.EmailVerificationToken{}, &models.OutboxMail{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM email_verification_tokens")
+	database.DB.Exec("DELETE FROM outbox_mails")
+
+	transport := &captureTransport{}
+	mail.SetTransport(transport)
+	t.Cleanup(func() {
+		mail.SetTransport(nil)
+		signup.SetPolicy(&signup.Policy{Mode: signup.ModeDisabled})
+	})
+
+	router.POST("/login", Login)
+	router.POST("/register", Register)
+	router.POST("/register/verify", VerifyEmail)
+	router.POST("/register/resend", ResendVerification)
+
+	req := models.RegisterRequest{Username: "newcomer", Email: "newcomer@example.com", Password: "registered-secret-7"}
+
+	// 1. 기본 설정에서는 가입 불가
+	w := authRequest(router, "POST", "/register", "", req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 2. 허용 도메인과 일회용 도메인 검사
+	signup.SetPolicy(&signup.Policy{
+		Mode:           signup.ModeDomain,
+		AllowedDomains: signup.NewDomainList([]string{"example.com", "mailinator.com"}),
+		Disposable:     signup.NewDomainList([]string{"mailinator.com"}),
+	})
+	w = authRequest(router, "POST", "/register", "", models.RegisterRequest{Username: "outsider", Email: "outsider@example.org", Password: "registered-secret-7"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(router, "POST", "/register", "", models.RegisterRequest{Username: "throwaway", Email: "throwaway@mailinator.com", Password: "registered-secret-7"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 3. 가입하면 PENDING 상태로 생성되고 인증 전에는 로그인 불가
+	w = authRequest(router, "POST", "/register", "", req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "USER", created.Role)
+	assert.Equal(t, models.UserStatusPending, created.Status)
+
+	w = authRequest(router, "POST", "/register", "", req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	login := models.LoginRequest{Username: req.Username, Password: req.Password}
+	w = authRequest(router, "POST", "/login", "", login)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 4. 재발송 요청은 계정 존재 여부와 관계없이 같은 응답이며, 메일은 응답 후 대기열에 저장됨
+	pendingMails := func() int64 {
+		var count int64
+		database.DB.Model(&models.OutboxMail{}).Where("status = ?", models.MailStatusPending).Count(&count)
+		return count
+	}
+
+	// 재발송 대기 시간 안에는 새 인증 메일을 만들지 않음
+	resendEmailVerifications(req.Email)
+	assert.Equal(t, int64(1), pendingMails())
+
+	database.DB.Model(&models.EmailVerificationToken{}).Where("user_id = ?", created.ID).
+		Update("created_at", time.Now().Add(-appConfig.EmailVerificationCooldown-time.Minute))
+	known := authRequest(router, "POST", "/register/resend", "", models.ResendVerificationRequest{Email: req.Email})
+	unknown := authRequest(router, "POST", "/register/resend", "", models.ResendVerificationRequest{Email: "nobody@example.com"})
+	assert.Equal(t, http.StatusAccepted, known.Code)
+	assert.Equal(t, known.Body.String(), unknown.Body.String())
+	assert.Eventually(t, func() bool { return pendingMails() == 2 }, time.Second, 10*time.Millisecond)
+
+	sent, err := mail.DispatchPending(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+
+	tokenFrom := func(msg mail.Message) string {
+		_, rawLink, _ := strings.Cut(msg.Body, "?token=")
+		token, _, _ := strings.Cut(rawLink, "\n")
+		return token
+	}
+	first, latest := tokenFrom(transport.messages[0]), tokenFrom(transport.messages[1])
+
+	// 5. 인증 후 로그인 가능, 같은 사용자의 다른 인증 토큰은 무효화됨
+	w = authRequest(router, "POST", "/register/verify", "", models.VerifyEmailRequest{Token: latest})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "POST", "/register/verify", "", models.VerifyEmailRequest{Token: first})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "POST", "/register/verify", "", models.VerifyEmailRequest{Token: "unknown"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "POST", "/login", "", login)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 6. 초대 코드 가입 모드
+	signup.SetPolicy(&signup.Policy{Mode: signup.ModeInvite, InviteCodes: []string{"team-invite"}})
+	invited := models.RegisterRequest{Username: "invited", Email: "invited@example.net", Password: "registered-secret-7"}
+	w = authRequest(router, "POST", "/register", "", invited)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	invited.InviteCode = "team-invite"
+	w = authRequest(router, "POST", "/register", "", invited)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+// TestPersonalAccessTokenIntegration은 개인 액세스 토큰 생성, 사용, 폐기를 통합 테스트합니다.
+func TestPersonalAccessTokenIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.PersonalAccessToken{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM personal_access_tokens")
+
+	sessionGroup := router.Group("")
+	sessionGroup.Use(middleware.RequireAuth(), middleware.RequireSession())
+	sessionGroup.POST("/tokens", CreatePersonalAccessToken)
+	sessionGroup.GET("/tokens", ListPersonalAccessTokens)
+	sessionGroup.DELETE("/tokens/:id", RevokePersonalAccessToken)
+
+	scoped := router.Group("/scoped")
+	scoped.Use(middleware.RequireAuth())
+	scoped.GET("/user/:id", middleware.RequireScope(auth.ScopeUsersRead), GetUser)
+	scoped.PUT("/user/:id", middleware.RequireScope(auth.ScopeUsersWrite), UpdateUser)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+	userPath := fmt.Sprintf("/scoped/user/%d", user.ID)
+
+	// 1. 잘못된 권한 범위와 최대 기간 초과 거부
+	w := authRequest(router, "POST", "/tokens", session.Token, models.CreatePersonalAccessTokenRequest{
+		Name: "ci", Scopes: []string{"users:everything"}, ExpiresInDays: 30,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "POST", "/tokens", session.Token, models.CreatePersonalAccessTokenRequest{
+		Name: "ci", Scopes: []string{auth.ScopeUsersRead}, ExpiresInDays: 10000,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 2. 토큰 생성 시 원문은 한 번만 반환되고 해시만 저장됨
+	w = authRequest(router, "POST", "/tokens", session.Token, models.CreatePersonalAccessTokenRequest{
+		Name: "ci", Scopes: []string{auth.ScopeUsersRead, auth.ScopeUsersRead}, ExpiresInDays: 30,
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.CreatePersonalAccessTokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, strings.HasPrefix(created.Token, auth.PersonalAccessTokenPrefix))
+	assert.True(t, strings.HasPrefix(created.Token, created.TokenPrefix))
+	assert.Equal(t, []string{auth.ScopeUsersRead}, created.Scopes)
+
+	var stored models.PersonalAccessToken
+	database.DB.First(&stored, created.ID)
+	assert.Equal(t, auth.HashToken(created.Token), stored.TokenHash)
+
+	w = authRequest(router, "GET", "/tokens", session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Token)
+
+	// 3. 권한 범위에 따라 접근 제한
+	w = authRequest(router, "GET", userPath, created.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "PUT", userPath, created.Token, models.UpdateUserRequest{Email: "pat@example.com"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	database.DB.First(&stored, created.ID)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// 4. 개인 액세스 토큰으로 새 토큰을 만들 수 없음
+	w = authRequest(router, "POST", "/tokens", created.Token, models.CreatePersonalAccessTokenRequest{
+		Name: "nested", Scopes: []string{auth.ScopeUsersRead}, ExpiresInDays: 1,
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 5. 폐기 후 사용 불가, 다른 사용자의 토큰은 폐기할 수 없음
+	other := models.User{Username: "othertokenuser", Email: "other-token@example.com", Password: "unused", Role: "USER"}
+	database.DB.Create(&other)
+	otherSession, err := loginSession(other)
+	assert.NoError(t, err)
+
+	w = authRequest(router, "DELETE", fmt.Sprintf("/tokens/%d", created.ID), otherSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = authRequest(router, "DELETE", fmt.Sprintf("/tokens/%d", created.ID), session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "GET", userPath, created.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestRoleIntegration은 역할별 권한 검사와 역할 관리를 통합 테스트합니다.
+func TestRoleIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Group{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	if err := auth.InitRBAC(config.NewConfig()); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+
+	protected := router.Group("/rbac")
+	protected.Use(middleware.RequireAuth())
+	protected.DELETE("/user/:id", middleware.RequirePermission(auth.PermUsersDelete), DeleteUser)
+	protected.POST("/user/:id/unlock", middleware.RequirePermission(auth.PermUsersUnlock), UnlockUser)
+	protected.POST("/user", middleware.RequirePermission(auth.PermUsersCreate), CreateUser)
+
+	roleGroup := router.Group("")
+	roleGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.RequirePermission(auth.PermRolesManage))
+	roleGroup.GET("/roles", GetRoles)
+	roleGroup.POST("/roles", CreateRole)
+	roleGroup.PUT("/roles/:name/permissions", UpdateRolePermissions)
+	roleGroup.DELETE("/roles/:name", DeleteRole)
+	roleGroup.PUT("/user/:id/role", AssignUserRole)
+	roleGroup.PUT("/groups/:id/role", AssignGroupRole)
+
+	admin := models.User{Username: "rbacadmin", Email: "rbac-admin@example.com", Password: "unused", Role: auth.RoleSuperAdmin}
+	support := models.User{Username: "rbacsupport", Email: "rbac-support@example.com", Password: "unused", Role: "SUPPORT"}
+	database.DB.Create(&admin)
+	database.DB.Create(&support)
+
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	supportSession, err := loginSession(support)
+	assert.NoError(t, err)
+	userPath := fmt.Sprintf("/rbac/user/%d", user.ID)
+
+	// 1. SUPPORT 역할은 잠금 해제만 가능하고 삭제와 역할 관리는 불가
+	w := authRequest(router, "POST", userPath+"/unlock", supportSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "DELETE", userPath, supportSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "GET", "/roles", supportSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 2. 잘못된 권한 이름과 기본 역할 변경 거부
+	w = authRequest(router, "POST", "/roles", adminSession.Token, models.CreateRoleRequest{
+		Name: "operator", Permissions: []string{"users:everything"},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "PUT", "/roles/ADMIN/permissions", adminSession.Token, models.UpdateRolePermissionsRequest{
+		Permissions: []string{auth.PermUsersRead},
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "DELETE", "/roles/USER", adminSession.Token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 3. 새 역할 생성 후 사용자에게 지정하면 즉시 권한이 적용됨
+	w = authRequest(router, "POST", "/roles", adminSession.Token, models.CreateRoleRequest{
+		Name: "operator", Permissions: []string{auth.PermUsersDelete},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = authRequest(router, "POST", "/roles", adminSession.Token, models.CreateRoleRequest{Name: "OPERATOR"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", support.ID), adminSession.Token, models.AssignRoleRequest{Role: "UNKNOWN"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", support.ID), adminSession.Token, models.AssignRoleRequest{Role: "OPERATOR"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 4. 사용자가 지정된 역할은 삭제할 수 없음
+	w = authRequest(router, "DELETE", "/roles/OPERATOR", adminSession.Token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = authRequest(router, "DELETE", userPath, supportSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 5. 역할의 권한을 변경하면 즉시 반영됨
+	w = authRequest(router, "PUT", "/roles/operator/permissions", adminSession.Token, models.UpdateRolePermissionsRequest{
+		Permissions: []string{auth.PermUsersRead},
+	})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "DELETE", fmt.Sprintf("/rbac/user/%d", admin.ID), supportSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", support.ID), adminSession.Token, models.AssignRoleRequest{Role: "SUPPORT"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "DELETE", "/roles/OPERATOR", adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, auth.RoleExists("OPERATOR"))
+
+	// 6. 역할 관리 권한이 있어도 자신에게 없는 권한은 역할로 부여할 수 없음
+	w = authRequest(router, "POST", "/roles", adminSession.Token, models.CreateRoleRequest{
+		Name: "role_admin", Permissions: []string{auth.PermRolesManage, auth.PermUsersCreate, auth.PermUsersRead},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	roleAdmin := models.User{Username: "rbacroleadmin", Email: "rbac-roleadmin@example.com", Password: "unused", Role: "ROLE_ADMIN"}
+	database.DB.Create(&roleAdmin)
+	roleAdminSession, err := loginSession(roleAdmin)
+	assert.NoError(t, err)
+
+	w = authRequest(router, "POST", "/roles", roleAdminSession.Token, models.CreateRoleRequest{
+		Name: "deleter", Permissions: []string{auth.PermUsersDelete},
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.False(t, auth.RoleExists("DELETER"))
+
+	w = authRequest(router, "POST", "/roles", roleAdminSession.Token, models.CreateRoleRequest{
+		Name: "viewer", Permissions: []string{auth.PermUsersRead},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = authRequest(router, "PUT", "/roles/VIEWER/permissions", roleAdminSession.Token, models.UpdateRolePermissionsRequest{
+		Permissions: []string{auth.PermUsersRead, auth.PermUsersDelete},
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.False(t, auth.RoleHasPermission("VIEWER", auth.PermUsersDelete))
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", support.ID), roleAdminSession.Token, models.AssignRoleRequest{Role: auth.RoleSuperAdmin})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	group := models.Group{Name: "rbac-escalation"}
+	database.DB.Create(&group)
+	w = authRequest(router, "PUT", fmt.Sprintf("/groups/%d/role", group.ID), roleAdminSession.Token, models.GroupRoleRequest{Role: auth.RoleAdmin})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "POST", "/rbac/user", roleAdminSession.Token, models.CreateUserRequest{
+		Username: "rbacescalated", Email: "rbac-escalated@example.com", Password: "Escalated-Passw0rd!", Role: auth.RoleAdmin,
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", support.ID), roleAdminSession.Token, models.AssignRoleRequest{Role: "VIEWER"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 7. 전체 관리자 계정의 역할은 다른 권한의 사용자가 바꿀 수 없음
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/role", admin.ID), roleAdminSession.Token, models.AssignRoleRequest{Role: "VIEWER"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var found models.User
+	database.DB.First(&found, admin.ID)
+	assert.Equal(t, auth.RoleSuperAdmin, found.Role)
+}
+
+// TestOrganizationIntegration은 조직별 데이터 격리와 조직 관리를 통합 테스트합니다.
+func TestOrganizationIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM organizations")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	users := router.Group("/org")
+	users.Use(middleware.RequireAuth())
+	users.GET("/users", middleware.RequirePermission(auth.PermUsersRead), GetUsers)
+	users.POST("/user", middleware.RequirePermission(auth.PermUsersCreate), CreateUser)
+	users.DELETE("/user/:id", middleware.RequirePermission(auth.PermUsersDelete), DeleteUser)
+
+	orgGroup := router.Group("")
+	orgGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.RequirePermission(auth.PermOrgsManage))
+	orgGroup.POST("/organizations", CreateOrganization)
+	orgGroup.DELETE("/organizations/:id", DeleteOrganization)
+	orgGroup.PUT("/user/:id/organization", AssignUserOrganization)
+
+	superAdmin := models.User{Username: "superadmin", Email: "super@example.com", Password: "unused", Role: auth.RoleSuperAdmin}
+	database.DB.Create(&superAdmin)
+	superSession, err := loginSession(superAdmin)
+	assert.NoError(t, err)
+
+	// 1. 전체 관리자가 조직 생성
+	var acme, globex models.Organization
+	w := authRequest(router, "POST", "/organizations", superSession.Token, models.OrganizationRequest{Name: "acme"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &acme))
+	w = authRequest(router, "POST", "/organizations", superSession.Token, models.OrganizationRequest{Name: "globex"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &globex))
+	w = authRequest(router, "POST", "/organizations", superSession.Token, models.OrganizationRequest{Name: "acme"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	acmeAdmin := models.User{Username: "acmeadmin", Email: "admin@acme.example", Password: "unused", Role: auth.RoleAdmin, OrganizationID: acme.ID}
+	acmeUser := models.User{Username: "acmeuser", Email: "user@acme.example", Password: "unused", Role: auth.RoleUser, OrganizationID: acme.ID}
+	acmeSuper := models.User{Username: "acmesuper", Email: "super@acme.example", Password: "unused", Role: auth.RoleSuperAdmin, OrganizationID: acme.ID}
+	globexUser := models.User{Username: "globexuser", Email: "user@globex.example", Password: "unused", Role: auth.RoleUser, OrganizationID: globex.ID}
+	for _, u := range []*models.User{&acmeAdmin, &acmeUser, &acmeSuper, &globexUser} {
+		database.DB.Create(u)
+	}
+	adminSession, err := loginSession(acmeAdmin)
+	assert.NoError(t, err)
+
+	// 2. 조직 관리자는 자신의 조직 사용자만 조회 및 변경 가능
+	w = authRequest(router, "GET", "/org/users", adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listed []models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 3)
+	for _, u := range listed {
+		assert.Equal(t, acme.ID, u.OrganizationID)
+	}
+
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", globexUser.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", acmeUser.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d", globexUser.ID), adminSession.Token, models.UpdateUserRequest{Email: "taken@acme.example"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/org/user/%d", globexUser.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 3. 조직 관리자는 같은 조직의 전체 관리자 계정을 변경할 수 없음
+	w = authRequest(router, "DELETE", fmt.Sprintf("/org/user/%d", acmeSuper.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 4. 조직 관리자가 생성한 사용자는 자신의 조직에 속하며 다른 조직에는 생성할 수 없음
+	newUser := models.CreateUserRequest{Username: "acmenew", Email: "new@acme.example", Password: "Str0ng!Passw0rd", Role: auth.RoleUser}
+	newUser.OrganizationID = globex.ID
+	w = authRequest(router, "POST", "/org/user", adminSession.Token, newUser)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	newUser.OrganizationID = 0
+	w = authRequest(router, "POST", "/org/user", adminSession.Token, newUser)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, acme.ID, created.OrganizationID)
+
+	w = authRequest(router, "POST", "/organizations", adminSession.Token, models.OrganizationRequest{Name: "initech"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 5. 전체 관리자는 모든 조직의 사용자를 조회하고 조직을 지정해 조회 가능
+	w = authRequest(router, "GET", "/org/users", superSession.Token, nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 7)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/org/users?organization_id=%d", globex.ID), superSession.Token, nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+
+	// 6. 사용자가 있는 조직과 기본 조직은 삭제할 수 없음
+	w = authRequest(router, "DELETE", fmt.Sprintf("/organizations/%d", globex.ID), superSession.Token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/organizations/%d", tenant.DefaultOrganizationID()), superSession.Token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/user/%d/organization", globexUser.ID), superSession.Token, models.AssignOrganizationRequest{OrganizationID: acme.ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/organizations/%d", globex.ID), superSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestGroupIntegration은 그룹 관리와 중첩 그룹, 그룹 역할을 통합 테스트합니다.
+func TestGroupIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Group{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM group_users")
+	database.DB.Exec("DELETE FROM group_subgroups")
+	database.DB.Exec("DELETE FROM groups")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	groups := router.Group("")
+	groups.Use(middleware.RequireAuth())
+	groups.GET("/groups/:id", middleware.RequirePermission(auth.PermUsersRead), GetGroup)
+	groups.POST("/groups", middleware.RequirePermission(auth.PermGroupsManage), CreateGroup)
+	groups.DELETE("/groups/:id", middleware.RequirePermission(auth.PermGroupsManage), DeleteGroup)
+	groups.POST("/groups/:id/members", middleware.RequirePermission(auth.PermGroupsManage), AddGroupMember)
+	groups.DELETE("/groups/:id/members/groups/:groupId", middleware.RequirePermission(auth.PermGroupsManage), RemoveSubgroup)
+	groups.POST("/group-test/user/:id/unlock", middleware.RequirePermission(auth.PermUsersUnlock), UnlockUser)
+
+	roleGroup := router.Group("")
+	roleGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.RequirePermission(auth.PermRolesManage))
+	roleGroup.PUT("/groups/:id/role", AssignGroupRole)
+	roleGroup.DELETE("/roles/:name", DeleteRole)
+
+	other := models.Organization{Name: "group-other"}
+	database.DB.Create(&other)
+	admin := models.User{Username: "groupadmin", Email: "group-admin@example.com", Password: "unused", Role: auth.RoleSuperAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	member := models.User{Username: "groupmember", Email: "group-member@example.com", Password: "unused", Role: auth.RoleUser, OrganizationID: tenant.DefaultOrganizationID()}
+	locked := models.User{Username: "grouplocked", Email: "group-locked@example.com", Password: "unused", Role: auth.RoleUser, OrganizationID: tenant.DefaultOrganizationID()}
+	outsider := models.User{Username: "groupoutsider", Email: "group-outsider@example.com", Password: "unused", Role: auth.RoleAdmin, OrganizationID: other.ID}
+	for _, u := range []*models.User{&admin, &member, &locked, &outsider} {
+		database.DB.Create(u)
+	}
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	memberSession, err := loginSession(member)
+	assert.NoError(t, err)
+	outsiderSession, err := loginSession(outsider)
+	assert.NoError(t, err)
+
+	// 1. 그룹 생성과 이름 중복 확인
+	var engineering, backend models.Group
+	w := authRequest(router, "POST", "/groups", adminSession.Token, models.CreateGroupRequest{Name: "engineering"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &engineering))
+	w = authRequest(router, "POST", "/groups", adminSession.Token, models.CreateGroupRequest{Name: "backend"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &backend))
+	w = authRequest(router, "POST", "/groups", adminSession.Token, models.CreateGroupRequest{Name: "engineering"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// 2. 구성원과 하위 그룹 추가, 순환 구조와 다른 조직 구성원은 거부
+	w = authRequest(router, "POST", fmt.Sprintf("/groups/%d/members", backend.ID), adminSession.Token, models.AddGroupMemberRequest{UserID: member.ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "POST", fmt.Sprintf("/groups/%d/members", engineering.ID), adminSession.Token, models.AddGroupMemberRequest{GroupID: backend.ID})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var loaded models.Group
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &loaded))
+	assert.Len(t, loaded.Subgroups, 1)
+
+	w = authRequest(router, "POST", fmt.Sprintf("/groups/%d/members", backend.ID), adminSession.Token, models.AddGroupMemberRequest{GroupID: engineering.ID})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "POST", fmt.Sprintf("/groups/%d/members", backend.ID), adminSession.Token, models.AddGroupMemberRequest{GroupID: backend.ID})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "POST", fmt.Sprintf("/groups/%d/members", backend.ID), adminSession.Token, models.AddGroupMemberRequest{UserID: outsider.ID})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 3. 사용자 정보에 상위 그룹을 포함한 그룹 목록이 표시됨
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", member.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var fetched models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+	assert.Len(t, fetched.Groups, 2)
+
+	// 4. 상위 그룹에 지정한 역할의 권한을 하위 그룹 구성원이 가짐
+	w = authRequest(router, "POST", fmt.Sprintf("/group-test/user/%d/unlock", locked.ID), memberSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "PUT", fmt.Sprintf("/groups/%d/role", engineering.ID), adminSession.Token, models.GroupRoleRequest{Role: "NOPE"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "PUT", fmt.Sprintf("/groups/%d/role", engineering.ID), adminSession.Token, models.GroupRoleRequest{Role: "SUPPORT"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "POST", fmt.Sprintf("/group-test/user/%d/unlock", locked.ID), memberSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = authRequest(router, "DELETE", "/roles/SUPPORT", adminSession.Token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// 5. 하위 그룹에서 제외하면 권한이 사라짐
+	w = authRequest(router, "DELETE", fmt.Sprintf("/groups/%d/members/groups/%d", engineering.ID, backend.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "POST", fmt.Sprintf("/group-test/user/%d/unlock", locked.ID), memberSession.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 6. 다른 조직의 관리자는 그룹을 조회하거나 삭제할 수 없음
+	w = authRequest(router, "GET", fmt.Sprintf("/groups/%d", engineering.ID), outsiderSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/groups/%d", engineering.ID), outsiderSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = authRequest(router, "DELETE", fmt.Sprintf("/groups/%d", engineering.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/groups/%d", engineering.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// lastMailToken은 마지막으로 발송된 메일의 링크에서 토큰을 꺼냅니다.
+func lastMailToken(transport *captureTransport) string {
+	_, rawLink, _ := strings.Cut(transport.messages[len(transport.messages)-1].Body, "?token=")
+	token, _, _ := strings.Cut(rawLink, "\n")
+	return token
+}
+
+// TestInvitationIntegration은 초대 발송, 재발송, 취소와 수락 흐름을 통합 테스트합니다.
+func TestInvitationIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Invitation{}, &models.OutboxMail{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM invitations")
+	database.DB.Exec("DELETE FROM outbox_mails")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	transport := &captureTransport{}
+	mail.SetTransport(transport)
+	t.Cleanup(func() { mail.SetTransport(nil) })
+
+	router.POST("/login", Login)
+	router.POST("/invitations/accept", AcceptInvitation)
+	invitations := router.Group("")
+	invitations.Use(middleware.RequireAuth(), middleware.RequirePermission(auth.PermUsersCreate))
+	invitations.GET("/invitations", GetInvitations)
+	invitations.POST("/invitations", CreateInvitation)
+	invitations.POST("/invitations/:id/resend", ResendInvitation)
+	invitations.DELETE("/invitations/:id", RevokeInvitation)
+
+	other := models.Organization{Name: "invite-other"}
+	database.DB.Create(&other)
+	admin := models.User{Username: "inviteadmin", Email: "invite-admin@example.com", Password: "unused", Role: auth.RoleAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	outsider := models.User{Username: "inviteoutsider", Email: "invite-outsider@example.com", Password: "unused", Role: auth.RoleAdmin, OrganizationID: other.ID}
+	database.DB.Create(&admin)
+	database.DB.Create(&outsider)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	outsiderSession, err := loginSession(outsider)
+	assert.NoError(t, err)
+
+	// 1. roles:manage 권한이 없으면 USER 외의 역할로 초대할 수 없음
+	w := authRequest(router, "POST", "/invitations", adminSession.Token, models.CreateInvitationRequest{Email: "new@example.com", Role: "SUPPORT"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 2. 초대 생성과 중복 초대 거부
+	w = authRequest(router, "POST", "/invitations", adminSession.Token, models.CreateInvitationRequest{Email: "new@example.com"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var invitation models.Invitation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &invitation))
+	assert.Equal(t, auth.RoleUser, invitation.Role)
+	assert.Equal(t, tenant.DefaultOrganizationID(), invitation.OrganizationID)
+	assert.NotContains(t, w.Body.String(), "token")
+
+	w = authRequest(router, "POST", "/invitations", adminSession.Token, models.CreateInvitationRequest{Email: "new@example.com"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	sent, err := mail.DispatchPending(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, "new@example.com", transport.messages[0].To)
+	firstToken := lastMailToken(transport)
+	assert.NotEmpty(t, firstToken)
+
+	// 3. 대기 중인 초대 목록은 같은 조직에서만 조회됨
+	var listed []models.Invitation
+	w = authRequest(router, "GET", "/invitations", adminSession.Token, nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+	w = authRequest(router, "GET", "/invitations", outsiderSession.Token, nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 0)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/invitations/%d", invitation.ID), outsiderSession.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 4. 재발송하면 이전 링크는 사용할 수 없음
+	w = authRequest(router, "POST", fmt.Sprintf("/invitations/%d/resend", invitation.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	mail.DispatchPending(time.Now())
+	token := lastMailToken(transport)
+	assert.NotEqual(t, firstToken, token)
+
+	accept := models.AcceptInvitationRequest{Token: firstToken, Username: "invitee", Password: "welcome-aboard-77"}
+	w = authRequest(router, "POST", "/invitations/accept", "", accept)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 5. 정책 위반 시 초대는 소모되지 않음
+	accept.Token = token
+	accept.Password = "short"
+	w = authRequest(router, "POST", "/invitations/accept", "", accept)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "violations")
+
+	// 6. 수락하면 초대된 조직과 역할로 활성 계정이 생성되고 바로 로그인 가능
+	accept.Password = "welcome-aboard-77"
+	w = authRequest(router, "POST", "/invitations/accept", "", accept)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "new@example.com", created.Email)
+	assert.Equal(t, auth.RoleUser, created.Role)
+	assert.Equal(t, tenant.DefaultOrganizationID(), created.OrganizationID)
+
+	w = authRequest(router, "POST", "/login", "", models.LoginRequest{Username: "invitee", Password: "welcome-aboard-77"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// 7. 초대 링크는 한 번만 사용할 수 있으며 수락된 초대는 목록에서 제외됨
+	accept.Username = "invitee2"
+	w = authRequest(router, "POST", "/invitations/accept", "", accept)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "GET", "/invitations", adminSession.Token, nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 0)
+	w = authRequest(router, "POST", fmt.Sprintf("/invitations/%d/resend", invitation.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// 8. 취소된 초대와 만료된 초대로는 가입할 수 없음
+	w = authRequest(router, "POST", "/invitations", adminSession.Token, models.CreateInvitationRequest{Email: "revoked@example.com"})
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &invitation))
+	mail.DispatchPending(time.Now())
+	revokedToken := lastMailToken(transport)
+	w = authRequest(router, "DELETE", fmt.Sprintf("/invitations/%d", invitation.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "POST", "/invitations/accept", "", models.AcceptInvitationRequest{Token: revokedToken, Username: "revokeduser", Password: "welcome-aboard-77"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "POST", "/invitations", adminSession.Token, models.CreateInvitationRequest{Email: "expired@example.com"})
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &invitation))
+	mail.DispatchPending(time.Now())
+	expiredToken := lastMailToken(transport)
+	database.DB.Model(&models.Invitation{}).Where("id = ?", invitation.ID).Update("expires_at", time.Now().Add(-time.Minute))
+	w = authRequest(router, "POST", "/invitations/accept", "", models.AcceptInvitationRequest{Token: expiredToken, Username: "expireduser", Password: "welcome-aboard-77"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// oidcLogin은 외부 로그인을 시작하고 모의 공급자를 거쳐 돌아온 콜백 주소를 반환합니다.
+func oidcLogin(t *testing.T, router *gin.Engine, server *oidctest.Server, provider string) string {
+	w := authRequest(router, "GET", "/oidc/"+provider+"/login", "", nil)
+	if w.Code != http.StatusFound {
+		t.Fatalf("외부 로그인 시작 실패: %d %s", w.Code, w.Body.String())
+	}
+	callback, err := server.Authorize(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("모의 공급자 인가 실패: %v", err)
+	}
+	return callback.RequestURI()
+}
+
+// TestOIDCIntegration은 외부 공급자 로그인과 계정 자동 생성, 역할 매핑을 통합 테스트합니다.
+func TestOIDCIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Identity{}, &models.OIDCLoginState{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM identities")
+	database.DB.Exec("DELETE FROM oidc_login_states")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	server := oidctest.NewServer("quickstart", "client-secret")
+	t.Cleanup(server.Close)
+	providerConfig := config.OIDCProviderConfig{
+		Name:          "corp",
+		Issuer:        server.Issuer(),
+		ClientID:      "quickstart",
+		ClientSecret:  "client-secret",
+		RedirectURL:   "http://localhost:8080/oidc/corp/callback",
+		Scopes:        []string{"openid", "profile", "email"},
+		AutoProvision: true,
+		RoleClaim:     "groups",
+		RoleMapping:   map[string]string{"helpdesk": "SUPPORT", "contractors": "CONTRACTOR"},
+		DefaultRole:   auth.RoleUser,
+	}
+	manual := providerConfig
+	manual.Name = "partner"
+	manual.RedirectURL = "http://localhost:8080/oidc/partner/callback"
+	manual.AutoProvision = false
+	oidc.SetProviders(oidc.NewProvider(providerConfig), oidc.NewProvider(manual))
+	t.Cleanup(func() { oidc.SetProviders() })
+
+	router.GET("/oidc/:provider/login", OIDCLogin)
+	router.GET("/oidc/:provider/callback", OIDCCallback)
+
+	// 1. 설정되지 않은 공급자
+	w := authRequest(router, "GET", "/oidc/unknown/login", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 2. 처음 로그인하면 클레임으로 계정이 생성되고 역할이 매핑됨
+	server.SetUser(map[string]interface{}{
+		"sub":                "employee-1",
+		"email":              "jdoe@corp.example",
+		"email_verified":     true,
+		"preferred_username": "jdoe",
+		"groups":             []string{"staff", "helpdesk"},
+	})
+	callback := oidcLogin(t, router, server, "corp")
+	w = authRequest(router, "GET", callback, "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var first models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.Equal(t, "jdoe", first.Username)
+	assert.Equal(t, "SUPPORT", first.Role)
+	assert.NotEmpty(t, first.Token)
+
+	var created models.User
+	database.DB.First(&created, first.ID)
+	assert.Equal(t, tenant.DefaultOrganizationID(), created.OrganizationID)
+	assert.True(t, created.IsActive())
+
+	// 3. 같은 콜백 주소는 다시 사용할 수 없음
+	w = authRequest(router, "GET", callback, "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 4. 다시 로그인하면 연결된 같은 계정을 사용
+	w = authRequest(router, "GET", oidcLogin(t, router, server, "corp"), "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var second models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	assert.Equal(t, first.ID, second.ID)
+
+	var identity models.Identity
+	database.DB.Where("provider = ? AND subject = ?", "corp", "employee-1").First(&identity)
+	assert.Equal(t, first.ID, identity.UserID)
+	assert.NotNil(t, identity.LastLoginAt)
+
+	// 역할 클레임이 바뀌면 다음 로그인에서 역할을 다시 매핑하고, 존재하지 않는 역할이면 USER를 사용
+	roleTests := []struct {
+		groups       []string
+		expectedRole string
+	}{
+		{[]string{"staff"}, auth.RoleUser},
+		{[]string{"helpdesk"}, "SUPPORT"},
+		{[]string{"contractors"}, auth.RoleUser},
+	}
+	for _, tt := range roleTests {
+		server.SetUser(map[string]interface{}{
+			"sub":                "employee-1",
+			"email":              "jdoe@corp.example",
+			"email_verified":     true,
+			"preferred_username": "jdoe",
+			"groups":             tt.groups,
+		})
+		w = authRequest(router, "GET", oidcLogin(t, router, server, "corp"), "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var relogin models.LoginResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &relogin))
+		assert.Equal(t, first.ID, relogin.ID)
+		assert.Equal(t, tt.expectedRole, relogin.Role)
+
+		var stored models.User
+		database.DB.First(&stored, first.ID)
+		assert.Equal(t, tt.expectedRole, stored.Role)
+	}
+
+	// 5. 사용자명이 이미 사용 중이면 접미사를 붙여 생성
+	server.SetUser(map[string]interface{}{
+		"sub":                "employee-2",
+		"email":              "jdoe2@corp.example",
+		"email_verified":     true,
+		"preferred_username": "jdoe",
+	})
+	w = authRequest(router, "GET", oidcLogin(t, router, server, "corp"), "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var renamed models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &renamed))
+	assert.NotEqual(t, first.ID, renamed.ID)
+	assert.True(t, strings.HasPrefix(renamed.Username, "jdoe-"))
+	assert.Equal(t, auth.RoleUser, renamed.Role)
+
+	// 6. 확인되지 않은 이메일과 자동 생성이 꺼진 공급자는 계정을 만들지 않음
+	server.SetUser(map[string]interface{}{"sub": "employee-3", "email": "unverified@corp.example"})
+	w = authRequest(router, "GET", oidcLogin(t, router, server, "corp"), "", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	server.SetUser(map[string]interface{}{"sub": "partner-1", "email": "p@partner.example", "email_verified": true})
+	w = authRequest(router, "GET", oidcLogin(t, router, server, "partner"), "", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 7. nonce가 다른 ID 토큰과 다른 공급자의 state는 거부
+	server.SetUser(map[string]interface{}{"sub": "employee-1"})
+	server.Tamper(func(claims jwt.MapClaims) { claims["nonce"] = "replayed" })
+	w = authRequest(router, "GET", oidcLogin(t, router, server, "corp"), "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	server.Tamper(nil)
+
+	callback = oidcLogin(t, router, server, "corp")
+	w = authRequest(router, "GET", strings.Replace(callback, "/oidc/corp/", "/oidc/partner/", 1), "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 8. 공급자가 오류를 돌려보내면 로그인 실패
+	w = authRequest(router, "GET", "/oidc/corp/callback?error=access_denied", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// formRequest는 폼 요청을 보냅니다. clientID가 있으면 HTTP Basic 인증으로 클라이언트를 인증합니다.
+func formRequest(router *gin.Engine, path string, form url.Values, clientID, clientSecret string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientID != "" {
+		req.SetBasicAuth(clientID, clientSecret)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// approveAuthorization은 인가 요청 주소의 항목으로 사용자가 인가 코드를 발급받고 클라이언트로 돌아갈 주소를 반환합니다.
+func approveAuthorization(t *testing.T, router *gin.Engine, authURL, token string) *url.URL {
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("인가 요청 주소 파싱 실패: %v", err)
+	}
+	query := parsed.Query()
+	w := authRequest(router, "POST", "/oauth/authorize", token, models.AuthorizeRequest{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("인가 코드 발급 실패: %d %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		RedirectURI string `json:"redirect_uri"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	redirect, err := url.Parse(response.RedirectURI)
+	if err != nil {
+		t.Fatalf("리다이렉트 주소 파싱 실패: %v", err)
+	}
+	return redirect
+}
+
+// TestOAuthServerIntegration은 다른 애플리케이션이 이 서비스로 로그인하는 인가 서버 흐름을 통합 테스트합니다.
+// 외부 로그인에 사용하는 OIDC 클라이언트로 디스커버리, 토큰 교환, ID 토큰 서명 검증까지 확인합니다.
+func TestOAuthServerIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.OAuthClient{}, &models.OAuthAuthorizationCode{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM oauth_authorization_codes")
+	database.DB.Exec("DELETE FROM oauth_clients")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	router.GET("/.well-known/openid-configuration", OpenIDConfiguration)
+	router.GET("/oauth/jwks", JWKS)
+	router.GET("/oauth/authorize", Authorize)
+	router.POST("/oauth/token", OAuthToken)
+	router.GET("/oauth/userinfo", UserInfo)
+	sessionGroup := router.Group("")
+	sessionGroup.Use(middleware.RequireAuth(), middleware.RequireSession())
+	sessionGroup.POST("/oauth/authorize", ApproveAuthorization)
+	clientGroup := router.Group("")
+	clientGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.RequirePermission(auth.PermClientsManage))
+	clientGroup.POST("/oauth/clients", CreateOAuthClient)
+	clientGroup.DELETE("/oauth/clients/:id", DeleteOAuthClient)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	cfg.OAuthIssuer = server.URL
+	if err := oauth.Init(cfg); err != nil {
+		t.Fatalf("OAuth 초기화 실패: %v", err)
+	}
+
+	superAdmin := models.User{Username: "oauthsuper", Email: "oauth-super@example.com", Password: "unused", Role: auth.RoleSuperAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	admin := models.User{Username: "oauthadmin", Email: "oauth-admin@example.com", Password: "unused", Role: auth.RoleAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	member := models.User{Username: "oauthmember", Email: "oauth-member@example.com", Password: "unused", Role: auth.RoleUser, OrganizationID: tenant.DefaultOrganizationID()}
+	for _, u := range []*models.User{&superAdmin, &admin, &member} {
+		database.DB.Create(u)
+	}
+	superSession, err := loginSession(superAdmin)
+	assert.NoError(t, err)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	memberSession, err := loginSession(member)
+	assert.NoError(t, err)
+
+	// 1. 조직 관리자는 클라이언트를 등록할 수 없음
+	webApp := models.CreateOAuthClientRequest{
+		Name:         "사내 위키",
+		RedirectURIs: []string{"http://wiki.example/callback"},
+		GrantTypes:   []string{models.GrantTypeAuthorizationCode},
+	}
+	w := authRequest(router, "POST", "/oauth/clients", adminSession.Token, webApp)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 2. 전체 관리자가 클라이언트를 등록하면 비밀 키는 등록 응답에서만 제공
+	w = authRequest(router, "POST", "/oauth/clients", superSession.Token, webApp)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var wiki models.CreateOAuthClientResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &wiki))
+	assert.NotEmpty(t, wiki.ClientID)
+	assert.NotEmpty(t, wiki.ClientSecret)
+	assert.Equal(t, oauth.DefaultScopes, wiki.Scopes)
+
+	invalid := webApp
+	invalid.RedirectURIs = []string{"/relative/callback"}
+	w = authRequest(router, "POST", "/oauth/clients", superSession.Token, invalid)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 3. 인가 요청은 로그인 화면으로 이동하고, 로그인한 사용자가 인가 코드를 발급
+	provider := oidc.NewProvider(config.OIDCProviderConfig{
+		Name:         "quickstart",
+		Issuer:       server.URL,
+		ClientID:     wiki.ClientID,
+		ClientSecret: wiki.ClientSecret,
+		RedirectURL:  "http://wiki.example/callback",
+		Scopes:       []string{"openid", "profile", "email"},
+	})
+	verifier, challenge, err := oidc.NewCodeVerifier()
+	assert.NoError(t, err)
+	authURL, err := provider.AuthCodeURL("state-1", "nonce-1", challenge)
+	if err != nil {
+		t.Fatalf("인가 요청 주소 생성 실패: %v", err)
+	}
+	assert.True(t, strings.HasPrefix(authURL, server.URL+"/oauth/authorize?"))
+
+	w = authRequest(router, "GET", strings.TrimPrefix(authURL, server.URL), "", nil)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Location"), appConfig.OAuthLoginURL+"?"))
+	assert.Contains(t, w.Header().Get("Location"), "client_id="+wiki.ClientID)
+
+	callback := approveAuthorization(t, router, authURL, memberSession.Token)
+	assert.Equal(t, "wiki.example", callback.Host)
+	assert.Equal(t, "state-1", callback.Query().Get("state"))
+	code := callback.Query().Get("code")
+
+	// 4. 클라이언트가 인가 코드를 교환하고 JWKS의 공개 키로 ID 토큰을 검증
+	idToken, err := provider.Exchange(code, verifier)
+	if err != nil {
+		t.Fatalf("토큰 교환 실패: %v", err)
+	}
+	claims, err := provider.VerifyIDToken(idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("ID 토큰 검증 실패: %v", err)
+	}
+	assert.Equal(t, fmt.Sprint(member.ID), claims.Subject())
+	assert.Equal(t, "oauth-member@example.com", claims.Email())
+	assert.Equal(t, "oauthmember", claims.PreferredUsername())
+	assert.Equal(t, auth.RoleUser, claims.String("role"))
+
+	// 5. 인가 코드는 한 번만 교환할 수 있고, 코드 검증값이 다르면 거부
+	_, err = provider.Exchange(code, verifier)
+	assert.Error(t, err)
+
+	callback = approveAuthorization(t, router, authURL, memberSession.Token)
+	otherVerifier, _, err := oidc.NewCodeVerifier()
+	assert.NoError(t, err)
+	_, err = provider.Exchange(callback.Query().Get("code"), otherVerifier)
+	assert.Error(t, err)
+
+	// 6. 액세스 토큰으로 userinfo 조회, ID 토큰은 액세스 토큰으로 사용할 수 없음
+	callback = approveAuthorization(t, router, authURL, memberSession.Token)
+	w = formRequest(router, "/oauth/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {callback.Query().Get("code")},
+		"redirect_uri":  {"http://wiki.example/callback"},
+		"code_verifier": {verifier},
+		"client_id":     {wiki.ClientID},
+		"client_secret": {wiki.ClientSecret},
+	}, "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	var tokens models.OAuthTokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.NotEmpty(t, tokens.IDToken)
+
+	w = authRequest(router, "GET", "/oauth/userinfo", tokens.AccessToken, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var userInfo map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &userInfo))
+	assert.Equal(t, fmt.Sprint(member.ID), userInfo["sub"])
+	assert.Equal(t, "oauth-member@example.com", userInfo["email"])
+
+	w = authRequest(router, "GET", "/oauth/userinfo", tokens.IDToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 클라이언트에 발급한 토큰으로는 이 서비스의 API를 호출할 수 없음
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", member.ID), tokens.AccessToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 7. 등록되지 않은 리다이렉트 URI는 돌려보내지 않고, 확인된 URI에는 오류를 돌려보냄
+	w = authRequest(router, "GET", "/oauth/authorize?response_type=code&client_id="+wiki.ClientID+"&redirect_uri=http://evil.example/callback&scope=openid", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "GET", "/oauth/authorize?response_type=code&client_id="+wiki.ClientID+"&redirect_uri=http://wiki.example/callback&scope=openid&state=s2", "", nil)
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	assert.NoError(t, err)
+	assert.Equal(t, "wiki.example", location.Host)
+	assert.Equal(t, "invalid_request", location.Query().Get("error"))
+	assert.Equal(t, "s2", location.Query().Get("state"))
+
+	// 8. client_credentials 그랜트는 사용자 없이 클라이언트에 토큰을 발급
+	w = authRequest(router, "POST", "/oauth/clients", superSession.Token, models.CreateOAuthClientRequest{
+		Name:       "리포트 배치",
+		GrantTypes: []string{models.GrantTypeClientCredentials},
+		Scopes:     []string{"reports:read", "reports:write"},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var batch models.CreateOAuthClientResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+
+	w = formRequest(router, "/oauth/token", url.Values{"grant_type": {"client_credentials"}, "scope": {"reports:read"}}, batch.ClientID, batch.ClientSecret)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var clientTokens models.OAuthTokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &clientTokens))
+	assert.Equal(t, "reports:read", clientTokens.Scope)
+	assert.Empty(t, clientTokens.IDToken)
+
+	w = authRequest(router, "GET", "/oauth/userinfo", clientTokens.AccessToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = formRequest(router, "/oauth/token", url.Values{"grant_type": {"client_credentials"}}, batch.ClientID, "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_client")
+
+	w = formRequest(router, "/oauth/token", url.Values{"grant_type": {"client_credentials"}, "scope": {"openid"}}, batch.ClientID, batch.ClientSecret)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_scope")
+
+	w = formRequest(router, "/oauth/token", url.Values{"grant_type": {"client_credentials"}}, wiki.ClientID, wiki.ClientSecret)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unauthorized_client")
+
+	// 9. 공개 클라이언트는 비밀 키 없이 PKCE만으로 인가 코드를 교환
+	w = authRequest(router, "POST", "/oauth/clients", superSession.Token, models.CreateOAuthClientRequest{
+		Name:         "모바일 앱",
+		RedirectURIs: []string{"com.example.app://callback"},
+		GrantTypes:   []string{models.GrantTypeAuthorizationCode},
+		Public:       true,
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var mobile models.CreateOAuthClientResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &mobile))
+	assert.Empty(t, mobile.ClientSecret)
+
+	mobileProvider := oidc.NewProvider(config.OIDCProviderConfig{
+		Name:        "mobile",
+		Issuer:      server.URL,
+		ClientID:    mobile.ClientID,
+		RedirectURL: "com.example.app://callback",
+		Scopes:      []string{"openid"},
+	})
+	verifier, challenge, err = oidc.NewCodeVerifier()
+	assert.NoError(t, err)
+	authURL, err = mobileProvider.AuthCodeURL("state-3", "nonce-3", challenge)
+	assert.NoError(t, err)
+	callback = approveAuthorization(t, router, authURL, memberSession.Token)
+	idToken, err = mobileProvider.Exchange(callback.Query().Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("공개 클라이언트 토큰 교환 실패: %v", err)
+	}
+	_, err = mobileProvider.VerifyIDToken(idToken, "nonce-3")
+	assert.NoError(t, err)
+
+	// 10. 삭제된 클라이언트는 토큰을 발급받을 수 없음
+	w = authRequest(router, "DELETE", fmt.Sprintf("/oauth/clients/%d", batch.ID), superSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = formRequest(router, "/oauth/token", url.Values{"grant_type": {"client_credentials"}}, batch.ClientID, batch.ClientSecret)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestLDAPLoginIntegration은 LDAP 바인드 로그인과 로컬 사용자 자동 생성, 갱신을 테스트합니다.
+func TestLDAPLoginIntegration(t *testing.T) {
+	router, localUser := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Identity{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM identities")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	const base = "dc=example,dc=com"
+	alice := ldaptest.Entry{
+		DN:       "uid=alice,ou=people," + base,
+		Password: "alice-secret",
+		Attributes: map[string][]string{
+			"uid":      {"alice"},
+			"mail":     {"alice@example.com"},
+			"memberOf": {"cn=staff,ou=groups," + base, "cn=admins,ou=groups," + base},
+		},
+	}
+	service := ldaptest.Entry{DN: "cn=svc," + base, Password: "svc-secret"}
+	// 로컬 사용자와 이름이 같은 디렉터리 사용자
+	impostor := ldaptest.Entry{
+		DN:         "uid=refreshuser,ou=people," + base,
+		Password:   "impostor-secret",
+		Attributes: map[string][]string{"uid": {"refreshuser"}, "mail": {"other@example.com"}},
+	}
+	server := ldaptest.NewServer(service, alice, impostor)
+	t.Cleanup(server.Close)
+
+	cfg.LDAPURL = server.URL
+	cfg.LDAPBindDN = service.DN
+	cfg.LDAPBindPassword = service.Password
+	cfg.LDAPBaseDN = base
+	cfg.LDAPRoleMapping = map[string]string{"admins": auth.RoleAdmin}
+	cfg.AuthBackends = []string{"local", "ldap"}
+	if err := authn.Init(cfg); err != nil {
+		t.Fatalf("인증 방식 초기화 실패: %v", err)
+	}
+	t.Cleanup(func() { authn.SetAuthenticators(authn.Local{}) })
+
+	router.POST("/login", Login)
+	login := func(username, secret string) *httptest.ResponseRecorder {
+		return authRequest(router, "POST", "/login", "", models.LoginRequest{Username: username, Password: secret})
+	}
+
+	// 1. 비밀번호가 틀리면 로그인 실패, 계정도 생성되지 않음
+	w := login("alice", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var count int64
+	database.DB.Model(&models.User{}).Where("username = ?", "alice").Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	// 2. 처음 로그인하면 디렉터리 정보로 로컬 사용자가 생성되고 그룹으로 역할이 매핑됨
+	w = login("alice", "alice-secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var first models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.Equal(t, "alice", first.Username)
+	assert.Equal(t, auth.RoleAdmin, first.Role)
+	assert.NotEmpty(t, first.Token)
+
+	var created models.User
+	database.DB.First(&created, first.ID)
+	assert.Equal(t, "alice@example.com", created.Email)
+	assert.Equal(t, tenant.DefaultOrganizationID(), created.OrganizationID)
+	var identity models.Identity
+	database.DB.Where("user_id = ?", first.ID).First(&identity)
+	assert.Equal(t, authn.LDAPProvider, identity.Provider)
+	assert.Equal(t, strings.ToLower(alice.DN), identity.Subject)
+
+	// 3. 디렉터리 정보가 바뀌면 다음 로그인에서 같은 계정에 반영됨
+	alice.Attributes = map[string][]string{
+		"uid":      {"alice"},
+		"mail":     {"alice@corp.example.com"},
+		"memberOf": {"cn=staff,ou=groups," + base},
+	}
+	server.SetEntries(service, alice, impostor)
+	w = login("alice", "alice-secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var second models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, auth.RoleUser, second.Role)
+	database.DB.First(&created, first.ID)
+	assert.Equal(t, "alice@corp.example.com", created.Email)
+
+	// 4. 같은 이름의 로컬 계정은 LDAP 계정으로 넘겨받지 않음
+	w = login("refreshuser", "impostor-secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	database.DB.Model(&models.Identity{}).Where("user_id = ?", localUser.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	// 5. 필터 특수 문자는 이스케이프되어 다른 사용자와 일치하지 않음
+	w = login("*", "alice-secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = login("alice)(uid=*", "alice-secret")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 6. LDAP 서버에 연결할 수 없으면 로그인 실패로 처리하지 않고 503 응답
+	server.Close()
+	w = login("alice", "alice-secret")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+// TestSCIMIntegration은 SCIM 토큰 발급과 사용자, 그룹 프로비저닝을 통합 테스트합니다.
+func TestSCIMIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Group{}, &models.SCIMToken{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM scim_tokens")
+	database.DB.Exec("DELETE FROM group_users")
+	database.DB.Exec("DELETE FROM group_subgroups")
+	database.DB.Exec("DELETE FROM groups")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	scimGroup := router.Group("/scim/v2")
+	scimGroup.GET("/ServiceProviderConfig", SCIMServiceProviderConfig)
+	provisioning := scimGroup.Group("")
+	provisioning.Use(middleware.RequireSCIMToken())
+	provisioning.GET("/Users", SCIMGetUsers)
+	provisioning.POST("/Users", SCIMCreateUser)
+	provisioning.GET("/Users/:id", SCIMGetUser)
+	provisioning.PATCH("/Users/:id", SCIMPatchUser)
+	provisioning.DELETE("/Users/:id", SCIMDeleteUser)
+	provisioning.POST("/Groups", SCIMCreateGroup)
+	provisioning.PATCH("/Groups/:id", SCIMPatchGroup)
+
+	tokenGroup := router.Group("")
+	tokenGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.RequirePermission(auth.PermSCIMManage))
+	tokenGroup.POST("/scim/tokens", CreateSCIMToken)
+	tokenGroup.DELETE("/scim/tokens/:id", RevokeSCIMToken)
+
+	other := models.Organization{Name: "scim-other"}
+	database.DB.Create(&other)
+	superAdmin := models.User{Username: "scimsuper", Email: "scim-super@example.com", Password: "unused", Role: auth.RoleSuperAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	admin := models.User{Username: "scimadmin", Email: "scim-admin@example.com", Password: "unused", Role: auth.RoleAdmin, OrganizationID: tenant.DefaultOrganizationID()}
+	outsider := models.User{Username: "scimoutsider", Email: "scim-outsider@example.com", Password: "unused", Role: auth.RoleUser, OrganizationID: other.ID}
+	for _, u := range []*models.User{&superAdmin, &admin, &outsider} {
+		database.DB.Create(u)
+	}
+	superSession, err := loginSession(superAdmin)
+	assert.NoError(t, err)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+
+	// 1. SCIM 토큰은 SCIM 관리 권한이 있어야 발급할 수 있음
+	w := authRequest(router, "POST", "/scim/tokens", adminSession.Token, models.CreateSCIMTokenRequest{Name: "idp"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(router, "POST", "/scim/tokens", superSession.Token, models.CreateSCIMTokenRequest{Name: "idp"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var issued models.CreateSCIMTokenResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.True(t, strings.HasPrefix(issued.Token, auth.SCIMTokenPrefix))
+	token := issued.Token
+
+	// 2. 스키마 조회 외에는 SCIM 토큰이 필요하고 로그인 토큰으로는 접근할 수 없음
+	w = authRequest(router, "GET", "/scim/v2/ServiceProviderConfig", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", "/scim/v2/Users", superSession.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), scim.ContentType)
+
+	// 3. 사용자 생성과 userName 중복 확인
+	newUser := map[string]interface{}{
+		"schemas":    []string{scim.SchemaUser},
+		"userName":   "scimalice",
+		"externalId": "idp-alice",
+		"emails":     []map[string]interface{}{{"value": "scim-alice@example.com", "primary": true}},
+		"active":     true,
+	}
+	w = authRequest(router, "POST", "/scim/v2/Users", token, newUser)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var alice scim.User
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &alice))
+	assert.Equal(t, "scimalice", alice.UserName)
+	assert.True(t, strings.HasSuffix(w.Header().Get("Location"), "/Users/"+alice.ID))
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	w = authRequest(router, "POST", "/scim/v2/Users", token, newUser)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), scim.ErrTypeUniqueness)
+
+	// 4. 필터와 페이지 조회, 다른 조직의 사용자는 보이지 않음
+	w = authRequest(router, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "SCIMALICE"`), token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list scim.ListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, int64(1), list.TotalResults)
+
+	w = authRequest(router, "GET", "/scim/v2/Users?startIndex=2&count=1", token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 2, list.StartIndex)
+	assert.Len(t, list.Resources, 1)
+
+	w = authRequest(router, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq`), token, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), scim.ErrTypeInvalidFilter)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/scim/v2/Users/%d", outsider.ID), token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 5. ETag로 변경 여부 확인과 동시 수정 방지
+	req, _ := http.NewRequest("GET", "/scim/v2/Users/"+alice.ID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	deactivate := scim.PatchRequest{
+		Schemas:    []string{scim.SchemaPatchOp},
+		Operations: []scim.PatchOperation{{Op: "replace", Path: "active", Value: "False"}},
+	}
+	data, _ := json.Marshal(deactivate)
+	req, _ = http.NewRequest("PATCH", "/scim/v2/Users/"+alice.ID, bytes.NewBuffer(data))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", `W/"0000000000000000"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// 6. 비활성화하면 상태가 바뀌고 발급된 토큰이 폐기됨
+	var aliceUser models.User
+	database.DB.Where("username = ?", "scimalice").First(&aliceUser)
+	aliceSession, err := loginSession(aliceUser)
+	assert.NoError(t, err)
+	w = authRequest(router, "PATCH", "/scim/v2/Users/"+alice.ID, token, deactivate)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &alice))
+	if assert.NotNil(t, alice.Active) {
+		assert.False(t, *alice.Active)
+	}
+	database.DB.First(&aliceUser, aliceUser.ID)
+	assert.Equal(t, models.UserStatusDisabled, aliceUser.Status)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", aliceUser.ID), aliceSession.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 7. 그룹 생성과 구성원 추가, 제외. 다른 조직 사용자는 구성원이 될 수 없음
+	w = authRequest(router, "POST", "/scim/v2/Groups", token, map[string]interface{}{
+		"schemas":     []string{scim.SchemaGroup},
+		"displayName": "scim-staff",
+		"members":     []map[string]interface{}{{"value": alice.ID}},
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var staff scim.Group
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &staff))
+	assert.Len(t, staff.Members, 1)
+
+	addAdmin := scim.PatchRequest{
+		Schemas:    []string{scim.SchemaPatchOp},
+		Operations: []scim.PatchOperation{{Op: "add", Path: "members", Value: []map[string]interface{}{{"value": strconv.FormatInt(admin.ID, 10)}}}},
+	}
+	w = authRequest(router, "PATCH", "/scim/v2/Groups/"+staff.ID, token, addAdmin)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &staff))
+	assert.Len(t, staff.Members, 2)
+
+	removeAlice := scim.PatchRequest{
+		Schemas:    []string{scim.SchemaPatchOp},
+		Operations: []scim.PatchOperation{{Op: "remove", Path: fmt.Sprintf(`members[value eq "%s"]`, alice.ID)}},
+	}
+	w = authRequest(router, "PATCH", "/scim/v2/Groups/"+staff.ID, token, removeAlice)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &staff))
+	if assert.Len(t, staff.Members, 1) {
+		assert.Equal(t, strconv.FormatInt(admin.ID, 10), staff.Members[0].Value)
+	}
+
+	addOutsider := scim.PatchRequest{
+		Schemas:    []string{scim.SchemaPatchOp},
+		Operations: []scim.PatchOperation{{Op: "add", Path: "members", Value: []map[string]interface{}{{"value": strconv.FormatInt(outsider.ID, 10)}}}},
+	}
+	w = authRequest(router, "PATCH", "/scim/v2/Groups/"+staff.ID, token, addOutsider)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 8. 여러 조직을 관리하는 계정은 SCIM으로 변경할 수 없음
+	w = authRequest(router, "PATCH", fmt.Sprintf("/scim/v2/Users/%d", superAdmin.ID), token, deactivate)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 9. 사용자 삭제
+	w = authRequest(router, "DELETE", "/scim/v2/Users/"+alice.ID, token, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = authRequest(router, "GET", "/scim/v2/Users/"+alice.ID, token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 10. 폐기된 토큰으로는 프로비저닝할 수 없음
+	w = authRequest(router, "DELETE", fmt.Sprintf("/scim/tokens/%d", issued.ID), superSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", "/scim/v2/Users", token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestSAMLIntegration은 모의 IdP가 서명한 응답으로 SAML 로그인 흐름을 통합 테스트합니다.
+func TestSAMLIntegration(t *testing.T) {
+	router, _ := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Identity{}, &models.SAMLLoginRequest{}, &models.SAMLAssertion{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM identities")
+	database.DB.Exec("DELETE FROM saml_login_requests")
+	database.DB.Exec("DELETE FROM saml_assertions")
+	cfg := config.NewConfig()
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+
+	idp := samltest.NewIdP("https://idp.corp.example")
+	certFile := filepath.Join(t.TempDir(), "idp.pem")
+	if err := idp.WriteCertificate(certFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	providerConfig := config.SAMLProviderConfig{
+		Name:               "corp",
+		EntityID:           "http://localhost:8080/saml/corp/metadata",
+		ACSURL:             "http://localhost:8080/saml/corp/acs",
+		IDPEntityID:        idp.EntityID,
+		IDPSSOURL:          idp.SSOURL,
+		IDPCertificateFile: certFile,
+		AllowIDPInitiated:  true,
+		AutoProvision:      true,
+		UsernameAttribute:  "uid",
+		EmailAttribute:     "email",
+		RoleAttribute:      "groups",
+		RoleMapping:        map[string]string{"helpdesk": "SUPPORT"},
+		DefaultRole:        auth.RoleUser,
+	}
+	strict := providerConfig
+	strict.Name = "strict"
+	strict.EntityID = "http://localhost:8080/saml/strict/metadata"
+	strict.ACSURL = "http://localhost:8080/saml/strict/acs"
+	strict.AllowIDPInitiated = false
+	corp, err := saml.NewProvider(providerConfig)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	strictProvider, err := saml.NewProvider(strict)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	saml.SetProviders(corp, strictProvider)
+	t.Cleanup(func() { saml.SetProviders() })
+
+	router.GET("/saml/:provider/metadata", SAMLMetadata)
+	router.GET("/saml/:provider/login", SAMLLogin)
+	router.POST("/saml/:provider/acs", SAMLACS)
+
+	// samlLogin은 로그인을 시작하고 IdP가 받은 인증 요청 ID를 반환합니다.
+	samlLogin := func(provider string) string {
+		w := authRequest(router, "GET", "/saml/"+provider+"/login", "", nil)
+		if w.Code != http.StatusFound {
+			t.Fatalf("SAML 로그인 시작 실패: %d %s", w.Code, w.Body.String())
+		}
+		request, err := samltest.ParseAuthnRequest(w.Header().Get("Location"))
+		if err != nil {
+			t.Fatalf("인증 요청 파싱 실패: %v", err)
+		}
+		return request.ID
+	}
+	employee := samltest.Response{
+		ACSURL:   providerConfig.ACSURL,
+		Audience: providerConfig.EntityID,
+		NameID:   "employee-1",
+		Attributes: map[string][]string{
+			"uid":    {"jdoe"},
+			"email":  {"jdoe@corp.example"},
+			"groups": {"staff", "helpdesk"},
+		},
+	}
+
+	// 1. 메타데이터와 설정되지 않은 IdP
+	w := authRequest(router, "GET", "/saml/corp/metadata", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `entityID="http://localhost:8080/saml/corp/metadata"`)
+	w = authRequest(router, "GET", "/saml/unknown/login", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 2. SP에서 시작한 로그인: 처음 로그인하면 속성으로 계정이 생성되고 역할이 매핑됨
+	response := employee
+	response.InResponseTo = samlLogin("corp")
+	encoded := idp.Response(response)
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {encoded}}, "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var first models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.Equal(t, "jdoe", first.Username)
+	assert.Equal(t, "SUPPORT", first.Role)
+	assert.NotEmpty(t, first.Token)
+
+	var identity models.Identity
+	database.DB.Where("provider = ? AND subject = ?", "saml:corp", "employee-1").First(&identity)
+	assert.Equal(t, first.ID, identity.UserID)
+	assert.NotNil(t, identity.LastLoginAt)
+
+	// 3. 같은 응답은 다시 사용할 수 없음
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {encoded}}, "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 4. IdP에서 시작한 로그인은 연결된 같은 계정을 사용하고, 역할은 IdP 속성에 맞춰 변경됨
+	response = employee
+	response.Attributes = map[string][]string{"email": {"jdoe@corp.example"}, "groups": {"staff"}}
+	encoded = idp.Response(response)
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {encoded}}, "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var second models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, auth.RoleUser, second.Role)
+
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {encoded}}, "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 5. 허용하지 않은 IdP의 IdP에서 시작한 로그인과 알 수 없는 요청에 대한 응답은 거부
+	response = employee
+	response.ACSURL, response.Audience = strict.ACSURL, strict.EntityID
+	w = formRequest(router, "/saml/strict/acs", url.Values{"SAMLResponse": {idp.Response(response)}}, "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	response = employee
+	response.InResponseTo = "_unknown"
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {idp.Response(response)}}, "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 6. 다른 IdP에 보낸 요청에 대한 응답은 거부
+	response = employee
+	response.InResponseTo = samlLogin("strict")
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {idp.Response(response)}}, "", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 7. 다른 키로 서명한 응답은 거부
+	response = employee
+	response.InResponseTo = samlLogin("corp")
+	w = formRequest(router, "/saml/corp/acs", url.Values{"SAMLResponse": {samltest.NewIdP(idp.EntityID).Response(response)}}, "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestMTLSIntegration은 mTLS 리스너에서 클라이언트 인증서로 서비스 계정이 API를 호출하는 흐름을 통합 테스트합니다.
+func TestMTLSIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Organization{}, &models.Group{}, &models.PersonalAccessToken{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	ca := mtlstest.NewCA("internal-ca")
+	dir := t.TempDir()
+	cfg := config.NewConfig()
+	cfg.MTLSCertFile = filepath.Join(dir, "server.pem")
+	cfg.MTLSKeyFile = filepath.Join(dir, "server-key.pem")
+	cfg.MTLSClientCAFile = filepath.Join(dir, "ca.pem")
+	cfg.MTLSServiceAccounts = []config.ServiceAccountConfig{
+		{Name: "billing", Subjects: []string{"DNS:billing.internal"}, Permissions: []string{auth.PermUsersRead}},
+		{Name: "audit", Subjects: []string{"URI:spiffe://corp.example/audit"}},
+	}
+	if err := auth.InitRBAC(cfg); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+	if err := tenant.Init(cfg); err != nil {
+		t.Fatalf("기본 조직 초기화 실패: %v", err)
+	}
+	if err := mtls.Init(cfg); err != nil {
+		t.Fatalf("서비스 계정 설정 실패: %v", err)
+	}
+	t.Cleanup(func() { mtls.SetAccounts() })
+
+	server := ca.IssueServer(mtlstest.Subject{CommonName: "localhost", IPs: []net.IP{net.ParseIP("127.0.0.1")}})
+	if err := mtlstest.WriteKeyPair(server, cfg.MTLSCertFile, cfg.MTLSKeyFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if err := ca.WriteCertificate(cfg.MTLSClientCAFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	tlsConfig, err := mtls.ServerTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	serviceGroup := router.Group("")
+	serviceGroup.Use(middleware.RequireAuth())
+	serviceGroup.GET("/users", middleware.RequirePermission(auth.PermUsersRead), GetUsers)
+	serviceGroup.POST("/tokens", middleware.RequireSession(), CreatePersonalAccessToken)
+
+	listener := httptest.NewUnstartedServer(router)
+	listener.TLS = tlsConfig
+	listener.StartTLS()
+	t.Cleanup(listener.Close)
+
+	// call은 subject로 발급한 클라이언트 인증서로 mTLS 리스너에 요청을 보냅니다.
+	call := func(subject mtlstest.Subject, method, path string) int {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      ca.Pool(),
+			Certificates: []tls.Certificate{ca.IssueClient(subject)},
+		}}}
+		req, _ := http.NewRequest(method, listener.URL+path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("mTLS 요청 실패: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	billing := mtlstest.Subject{CommonName: "billing", DNSNames: []string{"billing.internal"}}
+	audit := mtlstest.Subject{CommonName: "audit", URIs: []string{"spiffe://corp.example/audit"}}
+
+	// 1. 설정한 권한으로 같은 조직의 사용자 조회
+	assert.Equal(t, http.StatusOK, call(billing, "GET", "/users"))
+	assert.Equal(t, http.StatusOK, call(billing, "GET", fmt.Sprintf("/user/%d", user.ID)))
+
+	// 2. 설정하지 않은 권한과 세션 전용 기능은 거부
+	assert.Equal(t, http.StatusForbidden, call(audit, "GET", "/users"))
+	assert.Equal(t, http.StatusForbidden, call(billing, "PUT", fmt.Sprintf("/user/%d", user.ID)))
+	assert.Equal(t, http.StatusForbidden, call(billing, "POST", "/tokens"))
+
+	// 3. CA가 발급했지만 서비스 계정에 연결되지 않은 인증서
+	assert.Equal(t, http.StatusUnauthorized, call(mtlstest.Subject{CommonName: "billing"}, "GET", "/users"))
+
+	// 4. 다른 CA가 발급한 인증서는 연결 단계에서 거부
+	other := mtlstest.NewCA("other-ca")
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      ca.Pool(),
+		Certificates: []tls.Certificate{other.IssueClient(billing)},
+	}}}
+	_, err = client.Get(listener.URL + "/users")
+	assert.Error(t, err)
+}
+
+// TestAPIKeySigningIntegration은 API 키 발급부터 서명 요청과 폐기까지의 흐름을 통합 테스트합니다.
+func TestAPIKeySigningIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.APIKey{}, &models.APINonce{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM api_nonces")
+	database.DB.Exec("DELETE FROM api_keys")
+
+	sessionGroup := router.Group("")
+	sessionGroup.Use(middleware.RequireAuth(), middleware.RequireSession())
+	sessionGroup.POST("/api-keys", CreateAPIKey)
+	sessionGroup.GET("/api-keys", ListAPIKeys)
+	sessionGroup.DELETE("/api-keys/:id", RevokeAPIKey)
+
+	scoped := router.Group("/scoped")
+	scoped.Use(middleware.RequireAuth())
+	scoped.GET("/user/:id", middleware.RequireScope(auth.ScopeUsersRead), GetUser)
+	scoped.PUT("/user/:id", middleware.RequireScope(auth.ScopeUsersWrite), UpdateUser)
+
+	session, err := loginSession(user)
+	assert.NoError(t, err)
+	userPath := fmt.Sprintf("/scoped/user/%d", user.ID)
+
+	// signedRequest는 API 키로 서명한 요청을 보냅니다.
+	signedRequest := func(signer signing.Signer, method, path string, body interface{}) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		if err := signer.Sign(req); err != nil {
+			t.Fatalf("요청 서명 실패: %v", err)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 1. 잘못된 권한 범위와 최대 기간 초과 거부
+	w := authRequest(router, "POST", "/api-keys", session.Token, models.CreateAPIKeyRequest{
+		Name: "partner", Scopes: []string{"users:everything"}, ExpiresInDays: 30,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = authRequest(router, "POST", "/api-keys", session.Token, models.CreateAPIKeyRequest{
+		Name: "partner", Scopes: []string{auth.ScopeUsersRead}, ExpiresInDays: 10000,
+	})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 2. 비밀 값은 생성 응답에서만 반환
+	w = authRequest(router, "POST", "/api-keys", session.Token, models.CreateAPIKeyRequest{
+		Name: "partner", Scopes: []string{auth.ScopeUsersRead}, ExpiresInDays: 30,
+	})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.CreateAPIKeyResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, strings.HasPrefix(created.KeyID, auth.APIKeyPrefix))
+	assert.NotEmpty(t, created.Secret)
+
+	w = authRequest(router, "GET", "/api-keys", session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), created.KeyID)
+	assert.NotContains(t, w.Body.String(), created.Secret)
+
+	// 3. 서명한 요청은 권한 범위 안에서 사용자로 인증
+	signer := signing.Signer{KeyID: created.KeyID, Secret: created.Secret}
+	w = signedRequest(signer, "GET", userPath, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = signedRequest(signer, "PUT", userPath, models.UpdateUserRequest{Email: "signed@example.com"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var stored models.APIKey
+	database.DB.First(&stored, created.ID)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	// 사용한 nonce는 데이터베이스에 기록되어 다른 인스턴스에서도 다시 사용할 수 없음
+	var used []models.APINonce
+	database.DB.Where("key_id = ?", created.KeyID).Find(&used)
+	assert.Len(t, used, 2)
+	first, err := repository.RecordAPINonce(created.KeyID, used[0].Nonce, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, first)
+
+	// 기한이 지난 기록은 다시 사용할 수 있고 정리 대상이 됨
+	database.DB.Model(&models.APINonce{}).Where("id = ?", used[0].ID).Update("expires_at", time.Now().Add(-time.Second))
+	first, err = repository.RecordAPINonce(created.KeyID, used[0].Nonce, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, first)
+	database.DB.Model(&models.APINonce{}).Where("id = ?", used[1].ID).Update("expires_at", time.Now().Add(-time.Second))
+	assert.NoError(t, repository.DeleteExpiredAPINonces(time.Now()))
+	var remaining int64
+	database.DB.Model(&models.APINonce{}).Where("key_id = ?", created.KeyID).Count(&remaining)
+	assert.Equal(t, int64(1), remaining)
+
+	// 4. API 키로는 키를 관리할 수 없음
+	w = signedRequest(signer, "GET", "/api-keys", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 5. 폐기한 키로 서명한 요청은 거부
+	w = authRequest(router, "DELETE", fmt.Sprintf("/api-keys/%d", created.ID), session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = signedRequest(signer, "GET", userPath, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// TestImpersonationIntegration은 관리자의 사용자 가장과 가장 상태의 제한을 통합 테스트합니다.
+func TestImpersonationIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Group{}, &models.ImpersonationAudit{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM impersonation_audits")
+	if err := auth.InitRBAC(config.NewConfig()); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+
+	impersonateGroup := router.Group("")
+	impersonateGroup.Use(middleware.RequireAuth(), middleware.RequireSession(), middleware.DenyImpersonation(), middleware.RequirePermission(auth.PermUsersImpersonate))
+	impersonateGroup.POST("/user/:id/impersonate", ImpersonateUser)
+
+	admin := models.User{Username: "impadmin", Email: "imp-admin@example.com", Password: "unused", Role: auth.RoleAdmin}
+	support := models.User{Username: "impsupport", Email: "imp-support@example.com", Password: "unused", Role: "SUPPORT"}
+	database.DB.Create(&admin)
+	database.DB.Create(&support)
+
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+	supportSession, err := loginSession(support)
+	assert.NoError(t, err)
+	userSession, err := loginSession(user)
+	assert.NoError(t, err)
+	userPath := fmt.Sprintf("/user/%d", user.ID)
+	reason := models.ImpersonateRequest{Reason: "문의 #1234 재현"}
+
+	// 1. 가장 권한이 없거나 자기 자신, 자신보다 권한이 많은 사용자로는 가장 불가
+	w := authRequest(router, "POST", fmt.Sprintf("/user/%d/impersonate", support.ID), userSession.Token, reason)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "POST", fmt.Sprintf("/user/%d/impersonate", admin.ID), adminSession.Token, reason)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = authRequest(router, "POST", fmt.Sprintf("/user/%d/impersonate", admin.ID), supportSession.Token, reason)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 이유 없이는 가장할 수 없음
+	w = authRequest(router, "POST", userPath+"/impersonate", adminSession.Token, models.ImpersonateRequest{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// 2. 관리자가 사용자로 가장하면 리프레시 토큰 없는 단기 토큰 발급
+	w = authRequest(router, "POST", userPath+"/impersonate", adminSession.Token, reason)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var impersonation models.ImpersonationResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &impersonation))
+	assert.Equal(t, user.ID, impersonation.ID)
+	assert.Equal(t, admin.ID, impersonation.ActorID)
+	assert.NotEmpty(t, impersonation.Token)
+	assert.Empty(t, impersonation.RefreshToken)
+
+	// 가장한 관리자, 대상 사용자, 이유, 만료 시각, 세션이 감사 기록으로 남음
+	var audits []models.ImpersonationAudit
+	database.DB.Where("target_id = ?", user.ID).Find(&audits)
+	if assert.Len(t, audits, 1) {
+		assert.Equal(t, admin.ID, audits[0].ActorID)
+		assert.Equal(t, reason.Reason, audits[0].Reason)
+		assert.WithinDuration(t, *impersonation.ExpiresAt, audits[0].ExpiresAt, time.Second)
+
+		var session models.Session
+		database.DB.Where("family_id = ?", audits[0].SessionID).First(&session)
+		assert.Equal(t, user.ID, session.UserID)
+		assert.Equal(t, admin.ID, session.ImpersonatorID)
+	}
+
+	// 3. 가장 토큰은 대상 사용자의 권한으로 동작
+	w = authRequest(router, "GET", userPath, impersonation.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", admin.ID), impersonation.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 4. 가장 상태에서는 비밀번호와 역할 변경 불가
+	w = authRequest(router, "PUT", userPath, impersonation.Token, models.UpdateUserRequest{Email: "impersonated@example.com"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "PUT", userPath, impersonation.Token, models.UpdateUserRequest{Password: "NewPassw0rd!"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(router, "PUT", userPath, impersonation.Token, models.UpdateUserRequest{Role: auth.RoleUser})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 5. 가장 권한이 있는 사용자로 가장해도 다시 가장할 수 없음
+	w = authRequest(router, "POST", fmt.Sprintf("/user/%d/impersonate", support.ID), adminSession.Token, reason)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var chained models.ImpersonationResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &chained))
+	w = authRequest(router, "POST", userPath+"/impersonate", chained.Token, reason)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// 6. 로그아웃하면 가장 토큰 폐기
+	w = authRequest(router, "POST", "/logout", impersonation.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", userPath, impersonation.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 관리자의 세션은 그대로 유지
+	w = authRequest(router, "GET", userPath, adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestSessionIntegration은 세션 목록 조회, 개별 세션 종료, 동시 세션 수 제한을 통합 테스트합니다.
+func TestSessionIntegration(t *testing.T) {
+	router, user := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Group{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	if err := auth.InitRBAC(config.NewConfig()); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+
+	sessionGroup := router.Group("")
+	sessionGroup.Use(middleware.RequireAuth(), middleware.RequireSession())
+	sessionGroup.GET("/sessions", ListSessions)
+	sessionGroup.DELETE("/sessions/:id", RevokeSession)
+
+	adminGroup := router.Group("")
+	adminGroup.Use(middleware.RequireAuth())
+	adminGroup.GET("/user/:id/sessions", middleware.RequirePermission(auth.PermUsersRead), GetUserSessions)
+	adminGroup.DELETE("/user/:id/sessions/:sessionId", middleware.RequirePermission(auth.PermSessionsRevoke), RevokeUserSession)
+
+	admin := models.User{Username: "sessadmin", Email: "sess-admin@example.com", Password: "unused", Role: auth.RoleAdmin}
+	database.DB.Create(&admin)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+
+	// 기기별로 로그인
+	loginFrom := func(userAgent string) models.LoginResponse {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("POST", "/login", nil)
+		c.Request.Header.Set("User-Agent", userAgent)
+		response, err := startSession(c, user)
+		if err != nil {
+			t.Fatalf("예상하지 못한 오류: %v", err)
+		}
+		return response
+	}
+	desktop := loginFrom("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	phone := loginFrom("Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1")
+
+	listSessions := func(token string) []models.SessionResponse {
+		w := authRequest(router, "GET", "/sessions", token, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var sessions []models.SessionResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &sessions))
+		return sessions
+	}
+
+	// 1. 최근 세션부터 기기, IP, 사용 시각과 현재 세션 여부를 표시
+	sessions := listSessions(desktop.Token)
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, "Safari", sessions[0].Device.Browser)
+		assert.Equal(t, "mobile", sessions[0].Device.Type)
+		assert.False(t, sessions[0].Current)
+		assert.Equal(t, "Chrome", sessions[1].Device.Browser)
+		assert.Equal(t, "Windows", sessions[1].Device.OS)
+		assert.True(t, sessions[1].Current)
+		assert.Equal(t, "192.0.2.1", sessions[1].IPAddress)
+		assert.NotNil(t, sessions[1].CreatedAt)
+		assert.False(t, sessions[1].LastUsedAt.IsZero())
+	}
+	phoneSessionID := sessions[0].ID
+	desktopSessionID := sessions[1].ID
+
+	// 2. 리프레시 토큰으로 갱신해도 같은 세션이 유지됨
+	w := postRefresh(router, desktop.RefreshToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &desktop))
+	sessions = listSessions(desktop.Token)
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, desktopSessionID, sessions[1].ID)
+		assert.True(t, sessions[1].Current)
+	}
+
+	// 3. 세션 하나를 종료하면 해당 세션의 액세스 토큰과 리프레시 토큰만 폐기
+	w = authRequest(router, "DELETE", fmt.Sprintf("/sessions/%d", phoneSessionID), desktop.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), phone.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = postRefresh(router, phone.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, listSessions(desktop.Token), 1)
+
+	// 이미 종료된 세션이나 다른 사용자의 세션은 찾을 수 없음
+	w = authRequest(router, "DELETE", fmt.Sprintf("/sessions/%d", phoneSessionID), desktop.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	adminSessions := listSessions(adminSession.Token)
+	if assert.Len(t, adminSessions, 1) {
+		w = authRequest(router, "DELETE", fmt.Sprintf("/sessions/%d", adminSessions[0].ID), desktop.Token, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+
+	// 4. 관리자는 사용자의 세션을 조회하고 종료할 수 있음
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d/sessions", admin.ID), desktop.Token, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d/sessions", user.ID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var userSessions []models.SessionResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &userSessions))
+	if assert.Len(t, userSessions, 1) {
+		assert.Equal(t, desktopSessionID, userSessions[0].ID)
+		assert.False(t, userSessions[0].Current)
+	}
+
+	w = authRequest(router, "DELETE", fmt.Sprintf("/user/%d/sessions/%d", user.ID, desktopSessionID), adminSession.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), desktop.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 5. 동시 세션 수 제한을 넘으면 가장 오래된 세션부터 종료
+	originalLimit := appConfig.MaxSessionsPerUser
+	appConfig.MaxSessionsPerUser = 2
+	t.Cleanup(func() { appConfig.MaxSessionsPerUser = originalLimit })
+
+	first, err := loginSession(user)
+	assert.NoError(t, err)
+	second, err := loginSession(user)
+	assert.NoError(t, err)
+	third, err := loginSession(user)
+	assert.NoError(t, err)
+
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), first.Token, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = postRefresh(router, first.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = authRequest(router, "GET", fmt.Sprintf("/user/%d", user.ID), second.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, listSessions(third.Token), 2)
+}
+
+// TestLoginHistoryIntegration은 로그인 기록 조회의 접근 제어, 조건, 페이지 나누기를 통합 테스트합니다.
+func TestLoginHistoryIntegration(t *testing.T) {
+	router, other := setupTokenIntegrationTest(t)
+	if err := database.DB.AutoMigrate(&models.Role{}, &models.Permission{}, &models.Group{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM login_history")
+	if err := auth.InitRBAC(config.NewConfig()); err != nil {
+		t.Fatalf("역할 권한 초기화 실패: %v", err)
+	}
+
+	router.POST("/login", Login)
+	loginsGroup := router.Group("")
+	loginsGroup.Use(middleware.RequireAuth())
+	loginsGroup.GET("/user/:id/logins", GetUserLogins)
+
+	hash, err := password.Hash("history-password-1")
+	assert.NoError(t, err)
+	user := models.User{Username: "historyuser", Email: "history@example.com", Password: hash, Role: auth.RoleUser}
+	admin := models.User{Username: "historyadmin", Email: "history-admin@example.com", Password: "unused", Role: auth.RoleAdmin}
+	database.DB.Create(&user)
+	database.DB.Create(&admin)
+
+	// 1. 로그인 시도가 애플리케이션이 만든 테이블에 기록됨
+	w := authRequest(router, "POST", "/login", "", models.LoginRequest{Username: "historyuser", Password: "wrong-password"})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = authRequest(router, "POST", "/login", "", models.LoginRequest{Username: "historyuser", Password: "history-password-1"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var session models.LoginResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+
+	// 로그인 기록은 비동기로 저장됨
+	assert.Eventually(t, func() bool {
+		var count int64
+		database.DB.Model(&models.LoginHistory{}).Where("user_id = ?", user.ID).Count(&count)
+		return count == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// 존재하지 않는 사용자명으로 시도한 로그인도 사용자 없이 기록됨
+	w = authRequest(router, "POST", "/login", "", models.LoginRequest{Username: "no-such-user", Password: "guess-password-1"})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Eventually(t, func() bool {
+		var count int64
+		database.DB.Model(&models.LoginHistory{}).Where("user_id IS NULL AND success = ?", false).Count(&count)
+		return count == 1
+	}, time.Second, 10*time.Millisecond)
+
+	logins := func(token, query string) (int, models.LoginHistoryPage) {
+		w := authRequest(router, "GET", fmt.Sprintf("/user/%d/logins%s", user.ID, query), token, nil)
+		var page models.LoginHistoryPage
+		if w.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		}
+		return w.Code, page
+	}
+
+	code, page := logins(session.Token, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, defaultLoginPageSize, page.PageSize)
+	if assert.Len(t, page.Items, 2) {
+		assert.True(t, page.Items[0].Success)
+		assert.False(t, page.Items[1].Success)
+	}
+
+	// 2. 본인 또는 logins:read 권한이 있는 관리자만 조회 가능
+	otherSession, err := loginSession(other)
+	assert.NoError(t, err)
+	adminSession, err := loginSession(admin)
+	assert.NoError(t, err)
+
+	code, _ = logins(otherSession.Token, "")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, page = logins(adminSession.Token, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), page.Total)
+
+	// 3. 성공 여부, 기간, IP로 조회하고 페이지를 나눔
+	base := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		at := base.Add(time.Duration(i) * time.Hour)
+		database.DB.Create(&models.LoginHistory{UserID: &user.ID, LoginTime: &at, Success: true, IPAddress: "198.51.100.7"})
+	}
+
+	code, page = logins(session.Token, "?success=false")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(1), page.Total)
+
+	code, page = logins(session.Token, "?ip=198.51.100.7")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(3), page.Total)
+
+	code, page = logins(session.Token, "?from="+base.Add(time.Hour).Format(time.RFC3339)+"&to="+base.Add(2*time.Hour).Format(time.RFC3339))
+	assert.Equal(t, http.StatusOK, code)
+	if assert.Len(t, page.Items, 1) {
+		assert.WithinDuration(t, base.Add(time.Hour), *page.Items[0].LoginTime, time.Second)
+	}
+
+	code, page = logins(session.Token, "?page=3&page_size=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(5), page.Total)
+	assert.Equal(t, 3, page.Page)
+	if assert.Len(t, page.Items, 1) {
+		assert.WithinDuration(t, base, *page.Items[0].LoginTime, time.Second)
+	}
+
+	code, page = logins(session.Token, "?page_size=1000")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, maxLoginPageSize, page.PageSize)
+
+	// 4. 잘못된 조건은 거부
+	for _, query := range []string{"?page=0", "?page_size=abc", "?success=maybe", "?from=yesterday", "?ip=not-an-ip",
+		"?from=" + base.Format(time.RFC3339) + "&to=" + base.Format(time.RFC3339)} {
+		code, _ = logins(session.Token, query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
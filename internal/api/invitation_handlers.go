@@ -0,0 +1,296 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/mail"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CreateInvitation은 이메일 주소로 가입 초대를 보냅니다.
+// 초대받은 사람은 메일의 링크에서 사용자명과 비밀번호를 정해 지정된 역할과 조직으로 가입합니다.
+func CreateInvitation(c *gin.Context) {
+	var req models.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+	if req.Role == "" {
+		req.Role = auth.RoleUser
+	}
+
+	// 역할 확인: USER 외의 역할로 초대하려면 roles:manage 권한 필요
+	authUser, _ := middleware.GetAuthUser(c)
+	if !checkRoleAssignment(c, authUser, req.Role, auth.RoleUser) {
+		return
+	}
+
+	organizationID, ok := resolveOrganization(c, authUser, req.OrganizationID)
+	if !ok {
+		return
+	}
+
+	// 같은 이메일 주소로 대기 중인 초대 확인
+	_, err := repository.GetPendingInvitation(organizationID, req.Email)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 초대한 이메일 주소입니다",
+		})
+		return
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	token, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	invitation := models.Invitation{
+		OrganizationID: organizationID,
+		Email:          req.Email,
+		Role:           req.Role,
+		InvitedBy:      authUser.ID,
+		TokenHash:      hash,
+		ExpiresAt:      time.Now().Add(appConfig.InvitationTTL),
+	}
+	if err := repository.CreateInvitation(&invitation); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 메일 생성에 실패해도 초대는 유지되며 재발송으로 다시 보낼 수 있음
+	if err := sendInvitation(invitation, token, authUser); err != nil {
+		log.Printf("초대 메일 생성 실패 (초대 %d): %v", invitation.ID, err)
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// GetInvitations는 같은 조직에서 수락이나 취소되지 않은 초대 목록을 반환합니다.
+func GetInvitations(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	invitations, err := repository.GetPendingInvitations(tenant.Of(authUser))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// ResendInvitation은 새 링크로 초대 메일을 다시 보냅니다.
+// 이전에 보낸 링크는 더 이상 사용할 수 없으며, 만료 시각도 새로 정해집니다.
+func ResendInvitation(c *gin.Context) {
+	invitation, ok := findInvitation(c)
+	if !ok {
+		return
+	}
+
+	token, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 재발송 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	expiresAt := time.Now().Add(appConfig.InvitationTTL)
+	renewed, err := repository.RenewInvitation(invitation.ID, hash, expiresAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 재발송 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !renewed {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 수락되었거나 취소된 초대입니다",
+		})
+		return
+	}
+	invitation.TokenHash = hash
+	invitation.ExpiresAt = expiresAt
+
+	authUser, _ := middleware.GetAuthUser(c)
+	if err := sendInvitation(invitation, token, authUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 메일 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeInvitation은 대기 중인 초대를 취소합니다. 취소된 초대의 링크로는 가입할 수 없습니다.
+func RevokeInvitation(c *gin.Context) {
+	invitation, ok := findInvitation(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := repository.RevokeInvitation(invitation.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 취소 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !revoked {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 수락되었거나 취소된 초대입니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "초대가 취소되었습니다",
+	})
+}
+
+// AcceptInvitation은 초대 토큰을 확인하고 초대받은 사람이 정한 사용자명과 비밀번호로 계정을 생성합니다.
+// 초대 메일을 받은 것으로 이메일 주소가 확인되었으므로 계정은 바로 활성화됩니다.
+func AcceptInvitation(c *gin.Context) {
+	var req models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	invitation, err := repository.GetInvitationByHash(auth.HashToken(req.Token))
+	if err != nil || invitation.AcceptedAt != nil || invitation.RevokedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 초대입니다",
+		})
+		return
+	}
+
+	// 사용자명 중복 확인
+	_, err = repository.GetUserByUsername(req.Username)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 사용 중인 사용자명입니다",
+		})
+		return
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 정책 위반 시에는 초대를 소모하지 않고 다시 시도할 수 있도록 함
+	if !checkPasswordPolicy(c, req.Password, req.Username, invitation.Email) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 초대 이후 역할이 삭제되었으면 기본 역할로 가입
+	role := invitation.Role
+	if !auth.RoleExists(role) {
+		role = auth.RoleUser
+	}
+
+	user := models.User{
+		Username: req.Username,
+		Email:    invitation.Email,
+		Password: hashedPassword,
+		Role:     role,
+		Status:   models.UserStatusActive,
+
+		OrganizationID: invitation.OrganizationID,
+	}
+
+	accepted, err := repository.CreateUserFromInvitation(invitation, &user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !accepted {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 초대입니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// findInvitation은 경로의 초대 ID로 같은 조직의 초대를 조회하고, 없으면 오류를 응답합니다.
+func findInvitation(c *gin.Context) (models.Invitation, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 초대 ID 형식입니다",
+		})
+		return models.Invitation{}, false
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	invitation, err := repository.GetTenantInvitationByID(tenant.Of(authUser), id)
+	if err == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "초대를 찾을 수 없습니다",
+		})
+		return invitation, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "초대 조회 중 오류가 발생했습니다",
+		})
+		return invitation, false
+	}
+	return invitation, true
+}
+
+// sendInvitation은 초대 링크가 담긴 안내 메일을 발송 대기열에 저장합니다.
+func sendInvitation(invitation models.Invitation, token string, inviter models.User) error {
+	link, err := tokenLink(appConfig.InvitationURL, token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("안녕하세요.\n\n"+
+		"%s님이 회원님을 초대했습니다. 아래 링크에서 사용자명과 비밀번호를 정하면 가입이 완료됩니다.\n\n"+
+		"%s\n\n"+
+		"이 링크는 %s까지 한 번만 사용할 수 있습니다.\n"+
+		"초대받을 이유가 없다면 이 메일을 무시해 주세요.\n",
+		inviter.Username, link, invitation.ExpiresAt.Format("2006-01-02 15:04 MST"))
+
+	return mail.Enqueue(invitation.Email, "가입 초대 안내", body)
+}
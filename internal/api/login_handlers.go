@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// 로그인 기록 페이지 크기
+const (
+	defaultLoginPageSize = 20
+	maxLoginPageSize     = 100
+)
+
+// GetUserLogins는 사용자의 로그인 기록을 최근 순서로 한 페이지 반환합니다.
+// 접근 여부는 권한 정책으로 결정되며, 기본 정책에서는 logins:read 권한이 있으면 같은 조직의 사용자, 없으면 자신의 기록만 볼 수 있습니다.
+// page, page_size 쿼리 파라미터로 페이지를, success, from, to, ip로 조회 조건을 지정합니다.
+func GetUserLogins(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+
+	// 권한 확인: 존재하지 않거나 다른 조직의 사용자도 권한이 없으면 같은 응답으로 거부
+	if !authorize(c, auth.PermLoginsRead, userResource(id, user)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "다른 사용자의 로그인 기록에 접근할 권한이 없습니다",
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+
+	page, pageSize, ok := loginPagination(c)
+	if !ok {
+		return
+	}
+	filter, ok := loginHistoryFilter(c)
+	if !ok {
+		return
+	}
+
+	histories, total, err := repository.GetLoginHistories(user.ID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 기록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LoginHistoryPage{
+		Items:    histories,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// loginPagination은 page, page_size 쿼리 파라미터를 읽습니다. 페이지 크기는 최대값을 넘지 않도록 줄입니다.
+func loginPagination(c *gin.Context) (int, int, bool) {
+	page, pageSize := 1, defaultLoginPageSize
+	if raw := c.Query("page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "page는 1 이상의 정수여야 합니다",
+			})
+			return 0, 0, false
+		}
+		page = n
+	}
+	if raw := c.Query("page_size"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "page_size는 1 이상의 정수여야 합니다",
+			})
+			return 0, 0, false
+		}
+		pageSize = n
+	}
+	if pageSize > maxLoginPageSize {
+		pageSize = maxLoginPageSize
+	}
+	return page, pageSize, true
+}
+
+// loginHistoryFilter는 success, from, to, ip 쿼리 파라미터로 조회 조건을 만듭니다.
+// 시각은 RFC 3339 형식이며 from은 포함, to는 포함하지 않습니다.
+func loginHistoryFilter(c *gin.Context) (models.LoginHistoryFilter, bool) {
+	var filter models.LoginHistoryFilter
+	if raw := c.Query("success"); raw != "" {
+		success, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "success는 true 또는 false여야 합니다",
+			})
+			return filter, false
+		}
+		filter.Success = &success
+	}
+	var ok bool
+	if filter.From, ok = timeQuery(c, "from"); !ok {
+		return filter, false
+	}
+	if filter.To, ok = timeQuery(c, "to"); !ok {
+		return filter, false
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "from은 to보다 이전 시각이어야 합니다",
+		})
+		return filter, false
+	}
+	if raw := c.Query("ip"); raw != "" {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "잘못된 IP 주소 형식입니다",
+			})
+			return filter, false
+		}
+		filter.IPAddress = ip.String()
+	}
+	return filter, true
+}
+
+// timeQuery는 RFC 3339 형식의 시각 쿼리 파라미터를 읽습니다. 값이 없으면 nil을 반환합니다.
+func timeQuery(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	at, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": name + "는 RFC 3339 형식의 시각이어야 합니다",
+		})
+		return nil, false
+	}
+	return &at, true
+}
@@ -0,0 +1,42 @@
+package api
+
+import "log"
+
+// 계정 메일 작업자 수와 대기할 수 있는 최대 요청 수
+const (
+	accountMailWorkers   = 2
+	accountMailQueueSize = 256
+)
+
+// accountMails는 비밀번호 재설정, 인증 메일 재발송처럼 계정 존재 여부를 드러내지 않아야 하는 요청의
+// 계정 조회와 메일 생성을 응답과 분리하여 처리하는 대기열입니다.
+var accountMails = newMailQueue(accountMailWorkers, accountMailQueueSize)
+
+// mailQueue는 정해진 수의 작업자가 순서대로 처리하는 작업 대기열입니다.
+type mailQueue struct {
+	jobs chan func()
+}
+
+// newMailQueue는 작업자를 시작하고 대기열을 반환합니다.
+func newMailQueue(workers, size int) *mailQueue {
+	q := &mailQueue{jobs: make(chan func(), size)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range q.jobs {
+				job()
+			}
+		}()
+	}
+	return q
+}
+
+// enqueue는 작업을 대기열에 넣습니다. 대기열이 가득 차면 요청이 몰린 것이므로 작업을 버리고 false를 반환합니다.
+func (q *mailQueue) enqueue(job func()) bool {
+	select {
+	case q.jobs <- job:
+		return true
+	default:
+		log.Println("계정 메일 대기열이 가득 차 요청을 처리하지 않았습니다")
+		return false
+	}
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// mfaPurpose는 2단계 인증 챌린지 토큰의 용도입니다.
+const mfaPurpose = "mfa"
+
+// recoveryCodeCount는 TOTP 활성화 시 발급하는 복구 코드 수입니다.
+const recoveryCodeCount = 10
+
+// EnrollTOTP는 새 TOTP 비밀 키를 생성하고 인증 앱 등록 정보를 반환합니다.
+// VerifyTOTP로 인증 앱의 코드를 확인해야 2단계 인증이 활성화됩니다.
+func EnrollTOTP(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+	if authUser.MFAEnabled {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 2단계 인증이 활성화되어 있습니다",
+		})
+		return
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 등록 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	if err := repository.UpdateUserTOTP(authUser.ID, secret, false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 등록 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TOTPEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(appConfig.TOTPIssuer, authUser.Username, secret),
+	})
+}
+
+// VerifyTOTP는 인증 앱의 코드를 확인하여 2단계 인증을 활성화하고 복구 코드를 발급합니다.
+// 복구 코드는 이 응답에서만 확인할 수 있습니다.
+func VerifyTOTP(c *gin.Context) {
+	var req models.TOTPVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	if authUser.MFAEnabled {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 2단계 인증이 활성화되어 있습니다",
+		})
+		return
+	}
+	if authUser.TOTPSecret == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "먼저 2단계 인증 등록을 시작해야 합니다",
+		})
+		return
+	}
+
+	step, ok := auth.ValidateTOTP(authUser.TOTPSecret, req.Code, time.Now())
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "인증 코드가 올바르지 않습니다",
+		})
+		return
+	}
+
+	codes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 활성화 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	stored := make([]models.RecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		stored = append(stored, models.RecoveryCode{UserID: authUser.ID, CodeHash: auth.HashToken(code)})
+	}
+
+	if err := repository.ReplaceRecoveryCodes(authUser.ID, stored); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 활성화 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if err := repository.UpdateUserTOTP(authUser.ID, authUser.TOTPSecret, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 활성화 중 오류가 발생했습니다",
+		})
+		return
+	}
+	// 활성화에 사용한 코드는 로그인에 다시 사용할 수 없음
+	if _, err := repository.AdvanceTOTPStep(authUser.ID, step); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 활성화 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.TOTPVerifyResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP는 비밀번호와 TOTP 코드(또는 복구 코드)를 확인한 뒤 2단계 인증을 해제합니다.
+func DisableTOTP(c *gin.Context) {
+	var req models.TOTPDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	if !authUser.MFAEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "2단계 인증이 활성화되어 있지 않습니다",
+		})
+		return
+	}
+
+	matched, err := password.Verify(authUser.Password, req.Password)
+	if err != nil || !matched {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "비밀번호가 올바르지 않습니다",
+		})
+		return
+	}
+
+	ok, err := verifySecondFactor(authUser, req.Code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 해제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "인증 코드가 올바르지 않습니다",
+		})
+		return
+	}
+
+	if err := repository.UpdateUserTOTP(authUser.ID, "", false); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 해제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if err := repository.ReplaceRecoveryCodes(authUser.ID, nil); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 해제 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "2단계 인증이 해제되었습니다",
+	})
+}
+
+// LoginMFA는 로그인 시 발급된 챌린지 토큰과 TOTP 코드(또는 복구 코드)로 로그인을 완료합니다.
+func LoginMFA(c *gin.Context) {
+	var req models.MFALoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	claims, err := auth.ParseChallengeToken(req.MFAToken, mfaPurpose)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "유효하지 않거나 만료된 2단계 인증 요청입니다",
+		})
+		return
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "유효하지 않거나 만료된 2단계 인증 요청입니다",
+		})
+		return
+	}
+
+	user, err := repository.GetUserByID(userID)
+	if err != nil || !user.MFAEnabled {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "유효하지 않거나 만료된 2단계 인증 요청입니다",
+		})
+		return
+	}
+
+	// 코드 추측 시도도 로그인 실패로 기록되어 계정 잠금에 반영됨
+	lockedUntil, locked, err := auth.CheckLockout(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if locked {
+		respondLocked(c, lockedUntil)
+		return
+	}
+
+	ok, err := verifySecondFactor(user, req.Code)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !ok {
+		recordLoginAttempt(c, user.ID, false)
+
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "인증 코드가 올바르지 않습니다",
+		})
+		return
+	}
+
+	// 로그인 성공 기록
+	recordLoginAttempt(c, user.ID, true)
+
+	response, err := startSession(c, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// issueMFAChallenge는 비밀번호 확인을 마친 2단계 인증 사용자에게 챌린지 토큰을 발급합니다.
+func issueMFAChallenge(c *gin.Context, user models.User) {
+	token, expiresAt, err := auth.GenerateChallengeToken(user.ID, mfaPurpose, appConfig.MFAChallengeTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresAt:   expiresAt,
+	})
+}
+
+// verifySecondFactor는 TOTP 코드 또는 복구 코드를 검증합니다.
+// TOTP 코드는 같은 시간 단계에서 한 번만, 복구 코드는 한 번만 사용할 수 있습니다.
+func verifySecondFactor(user models.User, code string) (bool, error) {
+	if step, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		return repository.AdvanceTOTPStep(user.ID, step)
+	}
+	return repository.UseRecoveryCode(user.ID, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+}
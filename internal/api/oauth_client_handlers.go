@@ -0,0 +1,161 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/oauth"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOAuthClients는 등록된 OAuth2 클라이언트 목록을 반환합니다.
+func GetOAuthClients(c *gin.Context) {
+	clients, err := repository.GetOAuthClients()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "클라이언트 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, clients)
+}
+
+// CreateOAuthClient는 이 서비스로 로그인할 애플리케이션을 OAuth2 클라이언트로 등록합니다.
+// 클라이언트 비밀 키는 이 응답에서만 확인할 수 있습니다.
+func CreateOAuthClient(c *gin.Context) {
+	var req models.CreateOAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	grantTypes := uniqueStrings(req.GrantTypes)
+	for _, grantType := range grantTypes {
+		switch grantType {
+		case models.GrantTypeAuthorizationCode:
+			if len(req.RedirectURIs) == 0 {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "인가 코드 그랜트를 사용하려면 리다이렉트 URI가 필요합니다",
+				})
+				return
+			}
+		case models.GrantTypeClientCredentials:
+			if req.Public {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "공개 클라이언트는 client_credentials 그랜트를 사용할 수 없습니다",
+				})
+				return
+			}
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "지원하지 않는 그랜트 유형입니다: " + grantType,
+			})
+			return
+		}
+	}
+
+	for _, uri := range req.RedirectURIs {
+		parsed, err := url.Parse(uri)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Fragment != "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "리다이렉트 URI는 프래그먼트가 없는 절대 주소여야 합니다: " + uri,
+			})
+			return
+		}
+	}
+
+	scopes := uniqueStrings(req.Scopes)
+	if len(scopes) == 0 {
+		scopes = oauth.DefaultScopes
+	}
+	for _, scope := range scopes {
+		if strings.ContainsAny(scope, " \t\"\\") {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "권한 범위에 공백이나 따옴표를 사용할 수 없습니다: " + scope,
+			})
+			return
+		}
+	}
+
+	clientID, err := auth.RandomHex(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "클라이언트 등록 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	client := models.OAuthClient{
+		ClientID:     clientID,
+		Name:         req.Name,
+		RedirectURIs: uniqueStrings(req.RedirectURIs),
+		GrantTypes:   grantTypes,
+		Scopes:       scopes,
+		Public:       req.Public,
+		CreatedBy:    authUser.ID,
+	}
+
+	var secret string
+	if !req.Public {
+		var hash string
+		if secret, hash, err = auth.GenerateOpaqueToken(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "클라이언트 등록 중 오류가 발생했습니다",
+			})
+			return
+		}
+		client.SecretHash = hash
+	}
+
+	if err := repository.CreateOAuthClient(&client); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "클라이언트 등록 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreateOAuthClientResponse{
+		OAuthClient:  client,
+		ClientSecret: secret,
+	})
+}
+
+// DeleteOAuthClient는 OAuth2 클라이언트를 삭제합니다.
+// 이미 발급된 액세스 토큰과 ID 토큰은 만료될 때까지 유효합니다.
+func DeleteOAuthClient(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 클라이언트 ID 형식입니다",
+		})
+		return
+	}
+
+	deleted, err := repository.DeleteOAuthClient(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "클라이언트 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "클라이언트를 찾을 수 없습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "클라이언트가 삭제되었습니다",
+	})
+}
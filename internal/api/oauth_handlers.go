@@ -0,0 +1,343 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/oauth"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeError는 인가 요청 검증 실패 정보입니다.
+// redirect가 true이면 클라이언트의 리다이렉트 URI가 확인된 상태이므로 오류를 클라이언트에 돌려보낼 수 있습니다.
+type authorizeError struct {
+	code        string
+	description string
+	redirect    bool
+}
+
+// OpenIDConfiguration은 OpenID Connect 디스커버리 문서를 반환합니다.
+func OpenIDConfiguration(c *gin.Context) {
+	c.JSON(http.StatusOK, oauth.Discovery())
+}
+
+// JWKS는 ID 토큰과 액세스 토큰을 검증할 수 있는 공개 키 목록을 반환합니다.
+func JWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, oauth.JWKS())
+}
+
+// Authorize는 클라이언트가 보낸 인가 요청을 확인하고 사용자를 로그인 화면으로 보냅니다.
+// 로그인 화면은 /login으로 로그인한 뒤 같은 요청 항목으로 POST /oauth/authorize를 호출해 인가 코드를 발급받습니다.
+func Authorize(c *gin.Context) {
+	var req models.AuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 인가 요청입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if _, _, authErr := validateAuthorizeRequest(req); authErr != nil {
+		if !authErr.redirect {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": authErr.description,
+			})
+			return
+		}
+		c.Redirect(http.StatusFound, redirectURL(req.RedirectURI, map[string]string{
+			"error":             authErr.code,
+			"error_description": authErr.description,
+			"state":             req.State,
+		}))
+		return
+	}
+
+	separator := "?"
+	if strings.Contains(appConfig.OAuthLoginURL, "?") {
+		separator = "&"
+	}
+	c.Redirect(http.StatusFound, appConfig.OAuthLoginURL+separator+c.Request.URL.RawQuery)
+}
+
+// ApproveAuthorization은 로그인한 사용자를 대신해 클라이언트에 인가 코드를 발급합니다.
+// 응답의 redirect_uri로 브라우저를 보내면 클라이언트가 인가 코드를 받습니다.
+func ApproveAuthorization(c *gin.Context) {
+	var req models.AuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	client, scopes, authErr := validateAuthorizeRequest(req)
+	if authErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": authErr.description,
+		})
+		return
+	}
+
+	code, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "인가 코드 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	if err := repository.CreateOAuthAuthorizationCode(&models.OAuthAuthorizationCode{
+		CodeHash:      hash,
+		ClientID:      client.ClientID,
+		UserID:        authUser.ID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         strings.Join(scopes, " "),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(appConfig.OAuthCodeTTL),
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "인가 코드 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"redirect_uri": redirectURL(req.RedirectURI, map[string]string{
+			"code":  code,
+			"state": req.State,
+		}),
+	})
+}
+
+// OAuthToken은 인가 코드 또는 클라이언트 자격 증명으로 토큰을 발급하는 토큰 엔드포인트입니다.
+// 요청과 오류 응답은 OAuth2 표준 형식(RFC 6749)을 따릅니다.
+func OAuthToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	client, ok := authenticateOAuthClient(c)
+	if !ok {
+		return
+	}
+
+	grantType := c.PostForm("grant_type")
+	if grantType != models.GrantTypeAuthorizationCode && grantType != models.GrantTypeClientCredentials {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "지원하지 않는 그랜트 유형입니다")
+		return
+	}
+	if !client.AllowsGrant(grantType) {
+		oauthError(c, http.StatusBadRequest, "unauthorized_client", "클라이언트에 허용되지 않은 그랜트 유형입니다")
+		return
+	}
+
+	if grantType == models.GrantTypeClientCredentials {
+		exchangeClientCredentials(c, client)
+		return
+	}
+	exchangeAuthorizationCode(c, client)
+}
+
+// exchangeAuthorizationCode는 인가 코드를 사용자 액세스 토큰과 ID 토큰으로 교환합니다.
+func exchangeAuthorizationCode(c *gin.Context, client models.OAuthClient) {
+	code, err := repository.ConsumeOAuthAuthorizationCode(auth.HashToken(c.PostForm("code")))
+	if err != nil || code.ClientID != client.ClientID || time.Now().After(code.ExpiresAt) ||
+		code.RedirectURI != c.PostForm("redirect_uri") {
+		oauthError(c, http.StatusBadRequest, "invalid_grant", "유효하지 않거나 만료된 인가 코드입니다")
+		return
+	}
+	if !oauth.VerifyCodeChallenge(c.PostForm("code_verifier"), code.CodeChallenge) {
+		oauthError(c, http.StatusBadRequest, "invalid_grant", "코드 검증값이 일치하지 않습니다")
+		return
+	}
+
+	user, err := repository.GetUserByID(code.UserID)
+	if err != nil || !user.IsActive() {
+		oauthError(c, http.StatusBadRequest, "invalid_grant", "사용할 수 없는 계정입니다")
+		return
+	}
+
+	scopes := oauth.ParseScope(code.Scope)
+	accessToken, expiresAt, err := oauth.IssueAccessToken(strconv.FormatInt(user.ID, 10), client.ClientID, scopes)
+	if err != nil {
+		oauthError(c, http.StatusInternalServerError, "server_error", "토큰 생성 중 오류가 발생했습니다")
+		return
+	}
+
+	response := models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(expiresAt).Seconds()),
+		Scope:       code.Scope,
+	}
+	if oauth.HasScope(scopes, oauth.ScopeOpenID) {
+		if response.IDToken, err = oauth.IssueIDToken(user, client.ClientID, code.Nonce, scopes); err != nil {
+			oauthError(c, http.StatusInternalServerError, "server_error", "토큰 생성 중 오류가 발생했습니다")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// exchangeClientCredentials는 사용자 없이 클라이언트 자신에게 액세스 토큰을 발급합니다.
+// 권한 범위를 지정하지 않으면 클라이언트에 허용된 범위 중 사용자 정보 범위를 제외한 모든 범위를 부여합니다.
+func exchangeClientCredentials(c *gin.Context, client models.OAuthClient) {
+	scopes := oauth.ParseScope(c.PostForm("scope"))
+	if len(scopes) == 0 {
+		for _, scope := range client.Scopes {
+			if !oauth.HasScope(oauth.DefaultScopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	for _, scope := range scopes {
+		if oauth.HasScope(oauth.DefaultScopes, scope) {
+			oauthError(c, http.StatusBadRequest, "invalid_scope", "사용자 없이 요청할 수 없는 권한 범위입니다: "+scope)
+			return
+		}
+		if !client.AllowsScope(scope) {
+			oauthError(c, http.StatusBadRequest, "invalid_scope", "클라이언트에 허용되지 않은 권한 범위입니다: "+scope)
+			return
+		}
+	}
+
+	accessToken, expiresAt, err := oauth.IssueAccessToken(client.ClientID, client.ClientID, scopes)
+	if err != nil {
+		oauthError(c, http.StatusInternalServerError, "server_error", "토큰 생성 중 오류가 발생했습니다")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(expiresAt).Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	})
+}
+
+// UserInfo는 openid 범위의 액세스 토큰으로 사용자 클레임을 반환합니다.
+func UserInfo(c *gin.Context) {
+	raw, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found {
+		bearerError(c, http.StatusUnauthorized, "invalid_request", "액세스 토큰이 필요합니다")
+		return
+	}
+
+	claims, err := oauth.ParseAccessToken(raw)
+	if err != nil {
+		bearerError(c, http.StatusUnauthorized, "invalid_token", "유효하지 않은 액세스 토큰입니다")
+		return
+	}
+	userID, ok := claims.UserID()
+	if !ok || !oauth.HasScope(claims.Scopes(), oauth.ScopeOpenID) {
+		bearerError(c, http.StatusForbidden, "insufficient_scope", "openid 범위로 발급된 사용자 토큰이 필요합니다")
+		return
+	}
+
+	user, err := repository.GetUserByID(userID)
+	if err != nil || !user.IsActive() {
+		bearerError(c, http.StatusUnauthorized, "invalid_token", "유효하지 않은 액세스 토큰입니다")
+		return
+	}
+
+	c.JSON(http.StatusOK, oauth.UserClaims(user, claims.Scopes()))
+}
+
+// validateAuthorizeRequest는 인가 요청의 클라이언트, 리다이렉트 URI, PKCE와 권한 범위를 확인합니다.
+func validateAuthorizeRequest(req models.AuthorizeRequest) (models.OAuthClient, []string, *authorizeError) {
+	client, err := repository.GetOAuthClientByClientID(req.ClientID)
+	if err != nil {
+		return client, nil, &authorizeError{code: "invalid_client", description: "등록되지 않은 클라이언트입니다"}
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return client, nil, &authorizeError{code: "invalid_request", description: "등록되지 않은 리다이렉트 URI입니다"}
+	}
+
+	if !client.AllowsGrant(models.GrantTypeAuthorizationCode) {
+		return client, nil, &authorizeError{code: "unauthorized_client", description: "클라이언트에 허용되지 않은 그랜트 유형입니다", redirect: true}
+	}
+	if req.ResponseType != "code" {
+		return client, nil, &authorizeError{code: "unsupported_response_type", description: "response_type은 code만 지원합니다", redirect: true}
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != "S256" {
+		return client, nil, &authorizeError{code: "invalid_request", description: "S256 방식의 PKCE 코드 챌린지가 필요합니다", redirect: true}
+	}
+
+	scopes := oauth.ParseScope(req.Scope)
+	if len(scopes) == 0 {
+		return client, nil, &authorizeError{code: "invalid_scope", description: "권한 범위가 필요합니다", redirect: true}
+	}
+	for _, scope := range scopes {
+		if !client.AllowsScope(scope) {
+			return client, nil, &authorizeError{code: "invalid_scope", description: "클라이언트에 허용되지 않은 권한 범위입니다: " + scope, redirect: true}
+		}
+	}
+	return client, scopes, nil
+}
+
+// authenticateOAuthClient는 토큰 요청의 클라이언트를 인증합니다.
+// 기밀 클라이언트는 HTTP Basic 인증 또는 client_secret 파라미터로 비밀 키를 보내야 하고, 공개 클라이언트는 client_id만 보냅니다.
+func authenticateOAuthClient(c *gin.Context) (models.OAuthClient, bool) {
+	clientID, secret, basic := c.Request.BasicAuth()
+	if basic {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID = c.PostForm("client_id")
+		secret = c.PostForm("client_secret")
+	}
+
+	client, err := repository.GetOAuthClientByClientID(clientID)
+	authenticated := err == nil
+	if authenticated && !client.Public {
+		authenticated = subtle.ConstantTimeCompare([]byte(auth.HashToken(secret)), []byte(client.SecretHash)) == 1
+	}
+	if !authenticated {
+		if basic {
+			c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		oauthError(c, http.StatusUnauthorized, "invalid_client", "클라이언트 인증에 실패했습니다")
+		return client, false
+	}
+	return client, true
+}
+
+// redirectURL은 리다이렉트 URI에 쿼리 파라미터를 추가합니다. 값이 비어 있는 파라미터는 제외됩니다.
+func redirectURL(uri string, params map[string]string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	query := parsed.Query()
+	for k, v := range params {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// oauthError는 OAuth2 표준 형식의 오류를 응답합니다.
+func oauthError(c *gin.Context, status int, code, description string) {
+	c.JSON(status, gin.H{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// bearerError는 WWW-Authenticate 헤더와 함께 보호된 리소스 접근 오류를 응답합니다 (RFC 6750).
+func bearerError(c *gin.Context, status int, code, description string) {
+	c.Header("WWW-Authenticate", `Bearer error="`+code+`"`)
+	oauthError(c, status, code, description)
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 자동 생성하는 사용자명에 사용할 수 없는 문자
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// OIDCLogin은 외부 공급자의 로그인 화면으로 이동시킵니다.
+// 콜백에서 확인할 state, nonce와 PKCE 코드 검증값을 저장한 뒤 인가 요청 주소로 리다이렉트합니다.
+func OIDCLogin(c *gin.Context) {
+	provider, ok := oidc.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 공급자입니다",
+		})
+		return
+	}
+
+	state, stateHash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+	nonce, err := auth.RandomHex(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+	verifier, challenge, err := oidc.NewCodeVerifier()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	authURL, err := provider.AuthCodeURL(state, nonce, challenge)
+	if err != nil {
+		log.Printf("외부 로그인 요청 생성 실패 (%s): %v", provider.Name(), err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error": "로그인 공급자에 연결할 수 없습니다",
+		})
+		return
+	}
+
+	if err := repository.CreateOIDCLoginState(&models.OIDCLoginState{
+		Provider:     provider.Name(),
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(appConfig.OIDCStateTTL),
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback은 외부 공급자가 돌려보낸 인가 코드로 로그인을 완료합니다.
+// 연결된 계정이 없으면 공급자 설정에 따라 계정을 자동으로 생성하며, 로그인 응답은 /login과 같습니다.
+func OIDCCallback(c *gin.Context) {
+	provider, ok := oidc.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 공급자입니다",
+		})
+		return
+	}
+
+	if errCode := c.Query("error"); errCode != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "외부 로그인이 취소되었거나 실패했습니다: " + errCode,
+		})
+		return
+	}
+
+	state, err := repository.ConsumeOIDCLoginState(auth.HashToken(c.Query("state")))
+	if err != nil || state.Provider != provider.Name() || time.Now().After(state.ExpiresAt) || c.Query("code") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 로그인 요청입니다",
+		})
+		return
+	}
+
+	idToken, err := provider.Exchange(c.Query("code"), state.CodeVerifier)
+	if err != nil {
+		log.Printf("외부 로그인 토큰 교환 실패 (%s): %v", provider.Name(), err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "외부 로그인에 실패했습니다",
+		})
+		return
+	}
+	claims, err := provider.VerifyIDToken(idToken, state.Nonce)
+	if err != nil {
+		log.Printf("외부 로그인 ID 토큰 검증 실패 (%s): %v", provider.Name(), err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "외부 로그인에 실패했습니다",
+		})
+		return
+	}
+
+	user, identity, ok := resolveIdentityUser(c, provider, claims)
+	if !ok {
+		return
+	}
+
+	if !user.IsActive() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": inactiveMessage(user),
+		})
+		return
+	}
+
+	if err := repository.RecordIdentityLogin(identity.ID, claims.Email()); err != nil {
+		log.Printf("외부 계정 로그인 기록 실패 (연결 %d): %v", identity.ID, err)
+	}
+
+	// 2단계 인증이 활성화된 사용자는 외부 로그인 후에도 /login/mfa에서 로그인을 완료
+	if user.MFAEnabled {
+		issueMFAChallenge(c, user)
+		return
+	}
+
+	recordLoginAttempt(c, user.ID, true)
+
+	response, err := startSession(c, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// resolveIdentityUser는 외부 계정에 연결된 사용자를 찾고, 없으면 공급자 설정에 따라 새 사용자를 생성합니다.
+// 역할 매핑이 설정되어 있으면 로그인할 때마다 공급자의 클레임으로 역할을 맞춥니다.
+func resolveIdentityUser(c *gin.Context, provider *oidc.Provider, claims oidc.Claims) (models.User, models.Identity, bool) {
+	cfg := provider.Config()
+
+	identity, err := repository.GetIdentity(provider.Name(), claims.Subject())
+	if err == nil {
+		user, err := repository.GetUserByID(identity.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "사용자 조회 중 오류가 발생했습니다",
+			})
+			return user, identity, false
+		}
+		if len(cfg.RoleMapping) > 0 {
+			if role := oidcRole(provider, claims); role != user.Role {
+				user.Role = role
+				if err := repository.UpdateUser(&user); err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{
+						"error": "사용자 정보 업데이트 중 오류가 발생했습니다",
+					})
+					return user, identity, false
+				}
+			}
+		}
+		return user, identity, true
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 조회 중 오류가 발생했습니다",
+		})
+		return models.User{}, identity, false
+	}
+
+	if !cfg.AutoProvision {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "외부 계정과 연결된 사용자가 없습니다. 관리자에게 문의하세요",
+		})
+		return models.User{}, identity, false
+	}
+	if claims.Email() == "" || !claims.EmailVerified() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "로그인 공급자가 확인한 이메일 주소가 없어 계정을 만들 수 없습니다",
+		})
+		return models.User{}, identity, false
+	}
+
+	user, ok := provisionUser(c, provider, claims)
+	if !ok {
+		return user, identity, false
+	}
+
+	identity = models.Identity{
+		Provider: provider.Name(),
+		Subject:  claims.Subject(),
+		Email:    claims.Email(),
+	}
+	if err := repository.CreateUserWithIdentity(&user, &identity); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return user, identity, false
+	}
+	return user, identity, true
+}
+
+// provisionUser는 외부 계정의 클레임으로 새 사용자 정보를 만듭니다.
+// 역할은 공급자의 역할 매핑으로 정하고, 비밀번호는 알 수 없는 임의 값으로 설정하여 비밀번호 로그인은 할 수 없습니다.
+func provisionUser(c *gin.Context, provider *oidc.Provider, claims oidc.Claims) (models.User, bool) {
+	return newExternalUser(c, provider.Name(), provider.Config().Organization, claims.PreferredUsername(), claims.Email(), oidcRole(provider, claims))
+}
+
+// oidcRole은 공급자의 클레임을 역할로 매핑합니다. 매핑 결과가 존재하지 않는 역할이면 USER를 사용합니다.
+func oidcRole(provider *oidc.Provider, claims oidc.Claims) string {
+	role := provider.MapRole(claims)
+	if !auth.RoleExists(role) {
+		log.Printf("외부 로그인 역할 매핑 결과가 존재하지 않는 역할입니다 (%s): %s", provider.Name(), role)
+		return auth.RoleUser
+	}
+	return role
+}
+
+// newExternalUser는 외부 계정으로 처음 로그인한 사용자의 새 사용자 정보를 만듭니다.
+// 조직 이름이 비어 있으면 기본 조직에 속하며, 비밀번호는 알 수 없는 임의 값으로 설정합니다.
+func newExternalUser(c *gin.Context, providerName, organization, preferredUsername, email, role string) (models.User, bool) {
+	organizationID := tenant.DefaultOrganizationID()
+	if organization != "" {
+		org, err := repository.GetOrganizationByName(organization)
+		if err != nil {
+			log.Printf("외부 로그인 공급자의 조직을 찾을 수 없습니다 (%s): %v", providerName, err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "사용자 생성 중 오류가 발생했습니다",
+			})
+			return models.User{}, false
+		}
+		organizationID = org.ID
+	}
+
+	username, err := availableUsername(preferredUsername, email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return models.User{}, false
+	}
+
+	secret, err := auth.RandomHex(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return models.User{}, false
+	}
+	hashedPassword, err := password.Hash(secret)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다",
+		})
+		return models.User{}, false
+	}
+
+	return models.User{
+		Username: username,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     role,
+		Status:   models.UserStatusActive,
+
+		OrganizationID: organizationID,
+	}, true
+}
+
+// availableUsername은 공급자가 알려준 사용자명 또는 이메일 주소의 앞부분으로 사용 중이지 않은 사용자명을 만듭니다.
+// 이미 사용 중이면 임의의 접미사를 붙입니다.
+func availableUsername(preferred, email string) (string, error) {
+	base := preferred
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	base = usernameInvalidChars.ReplaceAllString(base, "")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	for len(base) < 3 {
+		base += "_"
+	}
+
+	candidate := base
+	for {
+		_, err := repository.GetUserByUsername(candidate)
+		if err == gorm.ErrRecordNotFound {
+			return candidate, nil
+		} else if err != nil {
+			return "", err
+		}
+
+		suffix, err := auth.RandomHex(3)
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "-" + suffix
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetMyOrganization은 인증된 사용자가 속한 조직 정보를 반환합니다.
+func GetMyOrganization(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	org, err := repository.GetOrganizationByID(authUser.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "조직을 찾을 수 없습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// GetOrganizations는 모든 조직 목록을 반환합니다.
+func GetOrganizations(c *gin.Context) {
+	orgs, err := repository.GetOrganizations()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, orgs)
+}
+
+// CreateOrganization은 새 조직을 생성합니다.
+func CreateOrganization(c *gin.Context) {
+	var req models.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if !checkOrganizationName(c, req.Name) {
+		return
+	}
+
+	org := models.Organization{Name: req.Name}
+	if err := repository.CreateOrganization(&org); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, org)
+}
+
+// UpdateOrganization은 조직 이름을 변경합니다.
+func UpdateOrganization(c *gin.Context) {
+	org, ok := findOrganization(c)
+	if !ok {
+		return
+	}
+
+	var req models.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if req.Name != org.Name && !checkOrganizationName(c, req.Name) {
+		return
+	}
+
+	org.Name = req.Name
+	if err := repository.UpdateOrganization(&org); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 수정 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// DeleteOrganization은 조직을 삭제합니다. 기본 조직이나 사용자가 있는 조직은 삭제할 수 없습니다.
+func DeleteOrganization(c *gin.Context) {
+	org, ok := findOrganization(c)
+	if !ok {
+		return
+	}
+
+	if org.ID == tenant.DefaultOrganizationID() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "기본 조직은 삭제할 수 없습니다",
+		})
+		return
+	}
+
+	count, err := repository.CountUsersInOrganization(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "사용자가 있는 조직은 삭제할 수 없습니다",
+		})
+		return
+	}
+
+	if err := repository.DeleteOrganization(org.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "조직이 삭제되었습니다",
+	})
+}
+
+// AssignUserOrganization은 사용자를 다른 조직으로 옮깁니다.
+func AssignUserOrganization(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	var req models.AssignOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if _, err := repository.GetOrganizationByID(req.OrganizationID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "존재하지 않는 조직입니다",
+		})
+		return
+	}
+
+	user, err := repository.GetTenantUserByID(repository.AllTenants, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+
+	if err := repository.UpdateUserOrganization(user.ID, req.OrganizationID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 변경 중 오류가 발생했습니다",
+		})
+		return
+	}
+	user.OrganizationID = req.OrganizationID
+
+	c.JSON(http.StatusOK, user)
+}
+
+// findOrganization은 경로의 조직 ID로 조직을 조회하고, 없으면 오류를 응답합니다.
+func findOrganization(c *gin.Context) (models.Organization, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 조직 ID 형식입니다",
+		})
+		return models.Organization{}, false
+	}
+
+	org, err := repository.GetOrganizationByID(id)
+	if err == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "조직을 찾을 수 없습니다",
+		})
+		return org, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 조회 중 오류가 발생했습니다",
+		})
+		return org, false
+	}
+	return org, true
+}
+
+// checkOrganizationName은 조직 이름이 이미 사용 중인지 확인합니다.
+func checkOrganizationName(c *gin.Context, name string) bool {
+	_, err := repository.GetOrganizationByName(name)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 사용 중인 조직 이름입니다",
+		})
+		return false
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "조직 확인 중 오류가 발생했습니다",
+		})
+		return false
+	}
+	return true
+}
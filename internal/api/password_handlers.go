@@ -0,0 +1,182 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/mail"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// ForgotPassword는 이메일 주소로 가입된 계정에 비밀번호 재설정 메일을 보냅니다.
+// 계정 존재 여부를 알 수 없도록 항상 같은 응답을 반환하며, 응답 시간으로도 알 수 없도록 계정 조회와 메일 생성은 대기열에서 처리합니다.
+func ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	// 응답과 별도로 재설정 메일 생성
+	email := req.Email
+	accountMails.enqueue(func() { sendPasswordResets(email) })
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "입력한 이메일 주소로 가입된 계정이 있으면 비밀번호 재설정 안내 메일을 보냈습니다",
+	})
+}
+
+// ResetPassword는 재설정 토큰을 확인하고 새 비밀번호를 설정합니다.
+// 재설정이 완료되면 사용자의 다른 재설정 토큰과 기존에 발급된 모든 로그인 토큰이 폐기됩니다.
+func ResetPassword(c *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	token, err := repository.GetPasswordResetTokenByHash(auth.HashToken(req.Token))
+	if err != nil || token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 비밀번호 재설정 토큰입니다",
+		})
+		return
+	}
+
+	user, err := repository.GetUserByID(token.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 비밀번호 재설정 토큰입니다",
+		})
+		return
+	}
+
+	// 정책 위반 시에는 토큰을 소모하지 않고 다시 시도할 수 있도록 함
+	if !checkPasswordPolicy(c, req.Password, user.Username, user.Email) {
+		return
+	}
+
+	marked, err := repository.MarkPasswordResetTokenUsed(token.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 재설정 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !marked {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 비밀번호 재설정 토큰입니다",
+		})
+		return
+	}
+
+	hash, err := password.Hash(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	if err := repository.UpdateUserPassword(user.ID, hash); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 재설정 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if err := repository.InvalidatePasswordResetTokens(user.ID); err != nil {
+		log.Printf("비밀번호 재설정 토큰 무효화 실패 (사용자 %d): %v", user.ID, err)
+	}
+	// 메일 수신으로 계정 소유가 확인되었으므로 로그인 실패로 인한 잠금도 해제
+	if err := repository.ResetUserLockout(user.ID); err != nil {
+		log.Printf("계정 잠금 초기화 실패 (사용자 %d): %v", user.ID, err)
+	}
+
+	if err := auth.RevokeAllUserTokens(user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "기존 세션 종료 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "비밀번호가 재설정되었습니다",
+	})
+}
+
+// sendPasswordResets는 이메일 주소로 가입된 모든 계정에 재설정 메일을 만듭니다.
+// 재발송 대기 시간 안에 보낸 토큰이 아직 유효한 계정에는 다시 보내지 않습니다.
+func sendPasswordResets(email string) {
+	users, err := repository.GetUsersByEmail(email)
+	if err != nil {
+		log.Printf("비밀번호 재설정 대상 조회 실패: %v", err)
+	}
+	now := time.Now()
+	for _, user := range users {
+		recent, err := repository.HasRecentPasswordResetToken(user.ID, now.Add(-appConfig.PasswordResetCooldown), now)
+		if err != nil {
+			log.Printf("비밀번호 재설정 토큰 확인 실패 (사용자 %d): %v", user.ID, err)
+			continue
+		}
+		if recent {
+			continue
+		}
+		if err := sendPasswordReset(user); err != nil {
+			log.Printf("비밀번호 재설정 메일 생성 실패 (사용자 %d): %v", user.ID, err)
+		}
+	}
+}
+
+// sendPasswordReset은 재설정 토큰을 발급하고 안내 메일을 발송 대기열에 저장합니다.
+func sendPasswordReset(user models.User) error {
+	token, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(appConfig.PasswordResetTTL)
+	if err := repository.CreatePasswordResetToken(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	link, err := tokenLink(appConfig.PasswordResetURL, token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("%s님, 안녕하세요.\n\n"+
+		"비밀번호 재설정이 요청되었습니다. 아래 링크에서 새 비밀번호를 설정해 주세요.\n\n"+
+		"%s\n\n"+
+		"이 링크는 %s까지 한 번만 사용할 수 있습니다.\n"+
+		"직접 요청하지 않았다면 이 메일을 무시해 주세요. 비밀번호는 변경되지 않습니다.\n",
+		user.Username, link, expiresAt.Format("2006-01-02 15:04 MST"))
+
+	return mail.Enqueue(user.Email, "비밀번호 재설정 안내", body)
+}
+
+// tokenLink는 메일에 포함할 링크 주소에 token 쿼리 파라미터를 추가합니다.
+func tokenLink(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/mail"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/signup"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Register는 회원 가입을 처리합니다.
+// 가입한 계정은 PENDING 상태로 생성되며, 메일로 받은 토큰으로 이메일을 인증해야 활성화됩니다.
+func Register(c *gin.Context) {
+	if !signup.Enabled() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": signup.ErrSignupDisabled.Error(),
+		})
+		return
+	}
+
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	// 캡차 확인
+	if err := signup.VerifyCaptcha(req.CaptchaToken, c.ClientIP()); err != nil {
+		if errors.Is(err, signup.ErrCaptchaFailed) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		log.Printf("캡차 확인 실패: %v", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "캡차 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 가입 모드, 초대 코드, 이메일 도메인 확인
+	if err := signup.Check(req.Email, req.InviteCode); err != nil {
+		status := http.StatusForbidden
+		if errors.Is(err, signup.ErrDisposableEmail) || errors.Is(err, signup.ErrInvalidEmail) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// 사용자명 중복 확인
+	_, err := repository.GetUserByUsername(req.Username)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 사용 중인 사용자명입니다",
+		})
+		return
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 비밀번호 정책 검사
+	if !checkPasswordPolicy(c, req.Password, req.Username, req.Email) {
+		return
+	}
+
+	hashedPassword, err := password.Hash(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	user := models.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: hashedPassword,
+		Role:     auth.RoleUser,
+		Status:   models.UserStatusPending,
+
+		OrganizationID: tenant.DefaultOrganizationID(),
+	}
+
+	if err := repository.CreateUser(&user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	// 메일 생성에 실패해도 가입은 유지되며 인증 메일 재발송으로 다시 받을 수 있음
+	if err := sendEmailVerification(user); err != nil {
+		log.Printf("이메일 인증 메일 생성 실패 (사용자 %d): %v", user.ID, err)
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// VerifyEmail은 이메일 인증 토큰을 확인하고 계정을 활성화합니다.
+func VerifyEmail(c *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	token, err := repository.GetEmailVerificationTokenByHash(auth.HashToken(req.Token))
+	if err != nil || token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 이메일 인증 토큰입니다",
+		})
+		return
+	}
+
+	activated, err := repository.ActivateUserByVerificationToken(token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "이메일 인증 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !activated {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "유효하지 않거나 만료된 이메일 인증 토큰입니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "이메일 인증이 완료되었습니다",
+	})
+}
+
+// ResendVerification은 이메일 인증을 기다리는 계정에 인증 메일을 다시 보냅니다.
+// 계정 존재 여부를 알 수 없도록 항상 같은 응답을 반환하며, 응답 시간으로도 알 수 없도록 계정 조회와 메일 생성은 대기열에서 처리합니다.
+func ResendVerification(c *gin.Context) {
+	var req models.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	// 응답과 별도로 인증 메일 생성
+	email := req.Email
+	accountMails.enqueue(func() { resendEmailVerifications(email) })
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "입력한 이메일 주소로 인증을 기다리는 계정이 있으면 인증 메일을 보냈습니다",
+	})
+}
+
+// resendEmailVerifications는 이메일 주소로 인증을 기다리는 모든 계정에 인증 메일을 다시 만듭니다.
+// 재발송 대기 시간 안에 보낸 토큰이 아직 유효한 계정에는 다시 보내지 않습니다.
+func resendEmailVerifications(email string) {
+	users, err := repository.GetPendingUsersByEmail(email)
+	if err != nil {
+		log.Printf("이메일 인증 대상 조회 실패: %v", err)
+	}
+	now := time.Now()
+	for _, user := range users {
+		recent, err := repository.HasRecentVerificationToken(user.ID, now.Add(-appConfig.EmailVerificationCooldown), now)
+		if err != nil {
+			log.Printf("이메일 인증 토큰 확인 실패 (사용자 %d): %v", user.ID, err)
+			continue
+		}
+		if recent {
+			continue
+		}
+		if err := sendEmailVerification(user); err != nil {
+			log.Printf("이메일 인증 메일 생성 실패 (사용자 %d): %v", user.ID, err)
+		}
+	}
+}
+
+// sendEmailVerification은 이메일 인증 토큰을 발급하고 안내 메일을 발송 대기열에 저장합니다.
+func sendEmailVerification(user models.User) error {
+	token, hash, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(appConfig.EmailVerificationTTL)
+	if err := repository.CreateEmailVerificationToken(&models.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	link, err := tokenLink(appConfig.EmailVerificationURL, token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("%s님, 가입해 주셔서 감사합니다.\n\n"+
+		"아래 링크에서 이메일 주소를 인증하면 계정이 활성화됩니다.\n\n"+
+		"%s\n\n"+
+		"이 링크는 %s까지 사용할 수 있습니다.\n"+
+		"직접 가입하지 않았다면 이 메일을 무시해 주세요.\n",
+		user.Username, link, expiresAt.Format("2006-01-02 15:04 MST"))
+
+	return mail.Enqueue(user.Email, "이메일 주소 인증 안내", body)
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetPermissions는 등록된 모든 권한 목록을 반환합니다.
+func GetPermissions(c *gin.Context) {
+	permissions, err := repository.GetPermissions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "권한 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// GetRoles는 모든 역할과 역할별 권한 목록을 반환합니다.
+func GetRoles(c *gin.Context) {
+	roles, err := repository.GetRoles()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// CreateRole은 새 역할을 생성합니다.
+func CreateRole(c *gin.Context) {
+	var req models.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	name := strings.ToUpper(req.Name)
+	if _, err := repository.GetRoleByName(name); err == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "이미 존재하는 역할입니다",
+		})
+		return
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 확인 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	if !checkPermissionNames(c, req.Permissions) {
+		return
+	}
+	authUser, _ := middleware.GetAuthUser(c)
+	if !checkGrantablePermissions(c, authUser, req.Permissions) {
+		return
+	}
+
+	role := models.Role{Name: name, Description: req.Description}
+	if err := repository.CreateRole(&role, uniqueStrings(req.Permissions)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+	reloadRoles()
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRolePermissions는 역할의 권한 목록을 교체합니다.
+// 기본 역할(SUPER_ADMIN, ADMIN, USER)의 권한은 코드에서 정의하므로 변경할 수 없습니다.
+func UpdateRolePermissions(c *gin.Context) {
+	var req models.UpdateRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	role, ok := findRole(c)
+	if !ok {
+		return
+	}
+	if role.Builtin {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "기본 역할의 권한은 변경할 수 없습니다",
+		})
+		return
+	}
+
+	if !checkPermissionNames(c, req.Permissions) {
+		return
+	}
+	authUser, _ := middleware.GetAuthUser(c)
+	if !checkGrantablePermissions(c, authUser, req.Permissions) {
+		return
+	}
+
+	if err := repository.ReplaceRolePermissions(&role, uniqueStrings(req.Permissions)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 수정 중 오류가 발생했습니다",
+		})
+		return
+	}
+	reloadRoles()
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole은 역할을 삭제합니다. 기본 역할이나 사용자 또는 그룹에 지정된 역할은 삭제할 수 없습니다.
+func DeleteRole(c *gin.Context) {
+	role, ok := findRole(c)
+	if !ok {
+		return
+	}
+	if role.Builtin {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "기본 역할은 삭제할 수 없습니다",
+		})
+		return
+	}
+
+	users, err := repository.CountUsersWithRole(role.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	groups, err := repository.CountGroupsWithRole(role.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if users > 0 || groups > 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "사용자나 그룹에 지정된 역할은 삭제할 수 없습니다",
+		})
+		return
+	}
+
+	if err := repository.DeleteRole(&role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 삭제 중 오류가 발생했습니다",
+		})
+		return
+	}
+	reloadRoles()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "역할이 삭제되었습니다",
+	})
+}
+
+// AssignUserRole은 사용자의 역할을 지정합니다.
+func AssignUserRole(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	var req models.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	if !auth.RoleExists(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "존재하지 않는 역할입니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+	if !checkManageable(c, authUser, user) {
+		return
+	}
+	if !checkGrantableRole(c, authUser, req.Role) {
+		return
+	}
+
+	if err := repository.UpdateUserRole(user.ID, req.Role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 지정 중 오류가 발생했습니다",
+		})
+		return
+	}
+	user.Role = req.Role
+
+	c.JSON(http.StatusOK, user)
+}
+
+// findRole은 경로의 역할 이름으로 역할을 조회하고, 없으면 오류를 응답합니다.
+func findRole(c *gin.Context) (models.Role, bool) {
+	role, err := repository.GetRoleByName(strings.ToUpper(c.Param("name")))
+	if err == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "역할을 찾을 수 없습니다",
+		})
+		return role, false
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "역할 조회 중 오류가 발생했습니다",
+		})
+		return role, false
+	}
+	return role, true
+}
+
+// checkPermissionNames는 권한 이름이 모두 정의된 권한인지 확인합니다.
+func checkPermissionNames(c *gin.Context, names []string) bool {
+	for _, name := range names {
+		if !auth.ValidPermission(name) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "존재하지 않는 권한입니다: " + name,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// checkGrantablePermissions는 역할에 부여하려는 권한이 모두 요청한 사용자에게 있는지 확인합니다.
+// 역할 관리 권한만으로 자신보다 많은 권한을 가진 역할을 만들 수 없게 합니다.
+func checkGrantablePermissions(c *gin.Context, authUser models.User, names []string) bool {
+	for _, name := range names {
+		if !auth.HasPermission(authUser, name) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "자신에게 없는 권한은 역할에 부여할 수 없습니다: " + name,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// checkGrantableRole은 지정하려는 역할의 권한이 모두 요청한 사용자에게 있는지 확인합니다.
+func checkGrantableRole(c *gin.Context, authUser models.User, role string) bool {
+	for _, p := range auth.Permissions {
+		if auth.RoleHasPermission(role, p.Name) && !auth.HasPermission(authUser, p.Name) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "자신에게 없는 권한을 가진 역할은 지정할 수 없습니다: " + p.Name,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// reloadRoles는 역할 변경을 이 인스턴스의 권한 캐시에 즉시 반영합니다.
+func reloadRoles() {
+	if err := auth.ReloadRoles(); err != nil {
+		log.Printf("역할 권한 목록 갱신 실패: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SAMLMetadata는 IdP에 등록할 SP 메타데이터를 반환합니다.
+func SAMLMetadata(c *gin.Context) {
+	provider, ok := saml.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 공급자입니다",
+		})
+		return
+	}
+
+	metadata, err := provider.Metadata()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "메타데이터 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// SAMLLogin은 인증 요청을 저장한 뒤 IdP의 로그인 화면으로 이동시킵니다(SP에서 시작한 로그인).
+func SAMLLogin(c *gin.Context) {
+	provider, ok := saml.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 공급자입니다",
+		})
+		return
+	}
+
+	requestID, err := saml.NewRequestID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+	location, err := provider.AuthnRequestURL(requestID)
+	if err != nil {
+		log.Printf("SAML 인증 요청 생성 실패 (%s): %v", provider.Name(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	if err := repository.CreateSAMLLoginRequest(&models.SAMLLoginRequest{
+		Provider:  provider.Name(),
+		RequestID: requestID,
+		ExpiresAt: time.Now().Add(appConfig.SAMLRequestTTL),
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 요청 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, location)
+}
+
+// SAMLACS는 IdP가 HTTP-POST 바인딩으로 보낸 응답으로 로그인을 완료합니다.
+// 이 서비스가 보낸 요청에 대한 응답과 IdP에서 시작한 로그인(설정에서 허용한 경우)을 모두 받으며,
+// 연결된 계정이 없으면 IdP 설정에 따라 계정을 자동으로 생성합니다. 로그인 응답은 /login과 같습니다.
+func SAMLACS(c *gin.Context) {
+	provider, ok := saml.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "지원하지 않는 로그인 공급자입니다",
+		})
+		return
+	}
+
+	encoded := c.PostForm("SAMLResponse")
+	if encoded == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "SAML 응답이 없습니다",
+		})
+		return
+	}
+
+	assertion, err := provider.ParseResponse(encoded)
+	if err != nil {
+		log.Printf("SAML 응답 검증 실패 (%s): %v", provider.Name(), err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "외부 로그인에 실패했습니다",
+		})
+		return
+	}
+
+	if assertion.InResponseTo != "" {
+		request, err := repository.ConsumeSAMLLoginRequest(assertion.InResponseTo)
+		if err != nil || request.Provider != provider.Name() || time.Now().After(request.ExpiresAt) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "유효하지 않거나 만료된 로그인 요청입니다",
+			})
+			return
+		}
+	} else if !provider.Config().AllowIDPInitiated {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "IdP에서 시작한 로그인은 허용되지 않습니다",
+		})
+		return
+	}
+
+	first, err := repository.RecordSAMLAssertion(provider.Name(), assertion.ID, assertion.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !first {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "이미 사용된 SAML 응답입니다",
+		})
+		return
+	}
+
+	user, identity, ok := resolveSAMLUser(c, provider, assertion)
+	if !ok {
+		return
+	}
+
+	if !user.IsActive() {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": inactiveMessage(user),
+		})
+		return
+	}
+
+	email := assertion.Email(provider.Config().EmailAttribute)
+	if err := repository.RecordIdentityLogin(identity.ID, email); err != nil {
+		log.Printf("외부 계정 로그인 기록 실패 (연결 %d): %v", identity.ID, err)
+	}
+
+	// 2단계 인증이 활성화된 사용자는 외부 로그인 후에도 /login/mfa에서 로그인을 완료
+	if user.MFAEnabled {
+		issueMFAChallenge(c, user)
+		return
+	}
+
+	recordLoginAttempt(c, user.ID, true)
+
+	response, err := startSession(c, user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// resolveSAMLUser는 NameID에 연결된 사용자를 찾고, 없으면 IdP 설정에 따라 새 사용자를 생성합니다.
+// 역할 매핑이 설정되어 있으면 로그인할 때마다 IdP 속성으로 역할을 맞춥니다.
+func resolveSAMLUser(c *gin.Context, provider *saml.Provider, assertion saml.Assertion) (models.User, models.Identity, bool) {
+	cfg := provider.Config()
+
+	// 임시 NameID는 로그인마다 바뀌므로 계정을 연결할 수 없음
+	if assertion.NameIDFormat == saml.NameIDTransient {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "IdP가 임시 NameID를 보내 계정을 연결할 수 없습니다. IdP에서 persistent 형식을 사용하세요",
+		})
+		return models.User{}, models.Identity{}, false
+	}
+
+	identity, err := repository.GetIdentity(provider.IdentityProvider(), assertion.NameID)
+	if err == nil {
+		user, err := repository.GetUserByID(identity.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "사용자 조회 중 오류가 발생했습니다",
+			})
+			return user, identity, false
+		}
+		if len(cfg.RoleMapping) > 0 {
+			if role := samlRole(provider, assertion); role != user.Role {
+				user.Role = role
+				if err := repository.UpdateUser(&user); err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{
+						"error": "사용자 정보 업데이트 중 오류가 발생했습니다",
+					})
+					return user, identity, false
+				}
+			}
+		}
+		return user, identity, true
+	} else if err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 조회 중 오류가 발생했습니다",
+		})
+		return models.User{}, identity, false
+	}
+
+	if !cfg.AutoProvision {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "외부 계정과 연결된 사용자가 없습니다. 관리자에게 문의하세요",
+		})
+		return models.User{}, identity, false
+	}
+	email := assertion.Email(cfg.EmailAttribute)
+	if email == "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "IdP가 이메일 주소를 보내지 않아 계정을 만들 수 없습니다",
+		})
+		return models.User{}, identity, false
+	}
+
+	user, ok := newExternalUser(c, provider.IdentityProvider(), cfg.Organization,
+		assertion.Value(cfg.UsernameAttribute), email, samlRole(provider, assertion))
+	if !ok {
+		return user, identity, false
+	}
+
+	identity = models.Identity{
+		Provider: provider.IdentityProvider(),
+		Subject:  assertion.NameID,
+		Email:    email,
+	}
+	if err := repository.CreateUserWithIdentity(&user, &identity); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 생성 중 오류가 발생했습니다",
+		})
+		return user, identity, false
+	}
+	return user, identity, true
+}
+
+// samlRole은 IdP 속성을 역할로 매핑합니다. 매핑 결과가 존재하지 않는 역할이면 USER를 사용합니다.
+func samlRole(provider *saml.Provider, assertion saml.Assertion) string {
+	role := provider.MapRole(assertion)
+	if !auth.RoleExists(role) {
+		log.Printf("SAML 역할 매핑 결과가 존재하지 않는 역할입니다 (%s): %s", provider.Name(), role)
+		return auth.RoleUser
+	}
+	return role
+}
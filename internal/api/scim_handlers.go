@@ -0,0 +1,905 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/scim"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 사용자 필터에 사용할 수 있는 속성과 컬럼
+var scimUserColumns = map[string]scim.Column{
+	"id":                {Name: "id", Type: scim.TypeInteger},
+	"username":          {Name: "username", Type: scim.TypeString},
+	"externalid":        {Name: "external_id", Type: scim.TypeString, CaseExact: true},
+	"emails":            {Name: "email", Type: scim.TypeString},
+	"emails.value":      {Name: "email", Type: scim.TypeString},
+	"active":            {Name: fmt.Sprintf("status <> '%s'", models.UserStatusDisabled), Type: scim.TypeBoolean},
+	"meta.created":      {Name: "created_at", Type: scim.TypeDateTime},
+	"meta.lastmodified": {Name: "updated_at", Type: scim.TypeDateTime},
+}
+
+// 그룹 필터에 사용할 수 있는 속성과 컬럼
+var scimGroupColumns = map[string]scim.Column{
+	"id":                {Name: "id", Type: scim.TypeInteger},
+	"displayname":       {Name: "name", Type: scim.TypeString},
+	"externalid":        {Name: "external_id", Type: scim.TypeString, CaseExact: true},
+	"meta.created":      {Name: "created_at", Type: scim.TypeDateTime},
+	"meta.lastmodified": {Name: "updated_at", Type: scim.TypeDateTime},
+}
+
+// SCIMServiceProviderConfig는 지원하는 SCIM 기능을 반환합니다.
+func SCIMServiceProviderConfig(c *gin.Context) {
+	scimJSON(c, http.StatusOK, scim.ServiceProviderConfig(appConfig.SCIMBaseURL))
+}
+
+// SCIMResourceTypes는 지원하는 리소스 유형 목록을 반환합니다.
+func SCIMResourceTypes(c *gin.Context) {
+	resourceTypes := scim.ResourceTypes(appConfig.SCIMBaseURL)
+	scimJSON(c, http.StatusOK, scim.NewListResponse(resourceTypes, int64(len(resourceTypes)), 1))
+}
+
+// SCIMResourceType은 이름으로 리소스 유형을 반환합니다.
+func SCIMResourceType(c *gin.Context) {
+	for _, resourceType := range scim.ResourceTypes(appConfig.SCIMBaseURL) {
+		if resourceType.(map[string]interface{})["id"] == c.Param("id") {
+			scimJSON(c, http.StatusOK, resourceType)
+			return
+		}
+	}
+	scimError(c, scim.ErrNotFound("ResourceType", c.Param("id")))
+}
+
+// SCIMSchemas는 리소스 스키마 목록을 반환합니다.
+func SCIMSchemas(c *gin.Context) {
+	var schemas []interface{}
+	for _, schema := range scim.Schemas {
+		schemas = append(schemas, schema.Resource(appConfig.SCIMBaseURL))
+	}
+	scimJSON(c, http.StatusOK, scim.NewListResponse(schemas, int64(len(schemas)), 1))
+}
+
+// SCIMSchema는 URN으로 리소스 스키마를 반환합니다.
+func SCIMSchema(c *gin.Context) {
+	for _, schema := range scim.Schemas {
+		if schema.ID == c.Param("id") {
+			scimJSON(c, http.StatusOK, schema.Resource(appConfig.SCIMBaseURL))
+			return
+		}
+	}
+	scimError(c, scim.ErrNotFound("Schema", c.Param("id")))
+}
+
+// SCIMGetUsers는 SCIM 토큰의 조직에서 필터와 일치하는 사용자를 startIndex, count에 따라 한 페이지 반환합니다.
+func SCIMGetUsers(c *gin.Context) {
+	where, args, ok := scimFilter(c, scimUserColumns)
+	if !ok {
+		return
+	}
+	start, offset, limit := scim.Pagination(c.Query("startIndex"), c.Query("count"))
+
+	users, total, err := repository.SearchUsers(scimTenant(c), where, args, offset, limit)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resource, err := scimUserResource(user)
+		if err != nil {
+			scimError(c, err)
+			return
+		}
+		projected, err := scimProject(c, resource)
+		if err != nil {
+			scimError(c, err)
+			return
+		}
+		resources = append(resources, projected)
+	}
+	scimJSON(c, http.StatusOK, scim.NewListResponse(resources, total, start))
+}
+
+// SCIMGetUser는 사용자 하나를 반환합니다. If-None-Match가 현재 ETag와 같으면 304를 응답합니다.
+func SCIMGetUser(c *gin.Context) {
+	user, ok := findSCIMUser(c)
+	if !ok {
+		return
+	}
+	resource, err := scimUserResource(user)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	respondSCIMResource(c, http.StatusOK, resource)
+}
+
+// SCIMCreateUser는 ID 공급자가 프로비저닝한 사용자를 SCIM 토큰의 조직에 생성합니다.
+// 비밀번호가 없으면 로컬 로그인할 수 없는 임의 비밀번호를 설정하며, 역할은 항상 USER입니다.
+func SCIMCreateUser(c *gin.Context) {
+	var input scim.User
+	if !bindSCIM(c, scim.UserSchema, &input) {
+		return
+	}
+
+	token, _ := middleware.GetSCIMToken(c)
+	user := models.User{
+		Role:           auth.RoleUser,
+		Status:         models.UserStatusActive,
+		OrganizationID: token.OrganizationID,
+	}
+	if !applySCIMUser(c, &user, input) {
+		return
+	}
+	if user.Password == "" {
+		secret, err := auth.RandomHex(32)
+		if err == nil {
+			user.Password, err = password.Hash(secret)
+		}
+		if err != nil {
+			scimError(c, err)
+			return
+		}
+	}
+
+	if err := repository.CreateUser(&user); err != nil {
+		scimError(c, err)
+		return
+	}
+
+	resource, err := scimUserResource(user)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	c.Header("Location", resource.Meta.Location)
+	respondSCIMResource(c, http.StatusCreated, resource)
+}
+
+// SCIMReplaceUser는 사용자 속성을 요청한 표현으로 교체합니다.
+func SCIMReplaceUser(c *gin.Context) {
+	user, _, ok := findSCIMUserForUpdate(c)
+	if !ok {
+		return
+	}
+
+	var input scim.User
+	if !bindSCIM(c, scim.UserSchema, &input) {
+		return
+	}
+	updateSCIMUser(c, user, input)
+}
+
+// SCIMPatchUser는 PATCH 연산으로 사용자 속성을 변경합니다.
+func SCIMPatchUser(c *gin.Context) {
+	user, current, ok := findSCIMUserForUpdate(c)
+	if !ok {
+		return
+	}
+
+	doc, ok := patchSCIMResource(c, scim.UserSchema, current)
+	if !ok {
+		return
+	}
+	var input scim.User
+	if err := scim.FromMap(scim.UserSchema, doc, &input); err != nil {
+		scimError(c, err)
+		return
+	}
+	updateSCIMUser(c, user, input)
+}
+
+// SCIMDeleteUser는 사용자를 삭제합니다.
+func SCIMDeleteUser(c *gin.Context) {
+	user, _, ok := findSCIMUserForUpdate(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteUser(user.ID); err != nil {
+		scimError(c, err)
+		return
+	}
+	if err := auth.RevokeAllUserTokens(user.ID); err != nil {
+		log.Printf("삭제된 사용자의 토큰 폐기 실패 (사용자 %d): %v", user.ID, err)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// updateSCIMUser는 요청한 표현을 사용자에 반영하여 저장하고 변경된 사용자를 응답합니다.
+// 비밀번호가 바뀌거나 계정이 비활성화되면 발급된 모든 토큰을 폐기합니다.
+func updateSCIMUser(c *gin.Context, user models.User, input scim.User) {
+	previousStatus := user.Status
+	if !applySCIMUser(c, &user, input) {
+		return
+	}
+
+	if err := repository.UpdateUser(&user); err != nil {
+		scimError(c, err)
+		return
+	}
+
+	disabled := user.Status == models.UserStatusDisabled && previousStatus != models.UserStatusDisabled
+	if input.Password != "" || disabled {
+		if err := auth.RevokeAllUserTokens(user.ID); err != nil {
+			scimError(c, err)
+			return
+		}
+	}
+
+	resource, err := scimUserResource(user)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	respondSCIMResource(c, http.StatusOK, resource)
+}
+
+// applySCIMUser는 SCIM 사용자 표현을 검증하고 사용자 모델에 반영합니다.
+// active가 지정되지 않으면 계정 상태를 바꾸지 않으며, 이메일 인증 대기 중인 계정은 활성화하지 않습니다.
+func applySCIMUser(c *gin.Context, user *models.User, input scim.User) bool {
+	if n := utf8.RuneCountInString(input.UserName); n < 3 || n > 50 {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "userName은 3~50자여야 합니다"))
+		return false
+	}
+	email := input.PrimaryEmail()
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email || len(email) > 100 {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "올바른 이메일 주소가 필요합니다"))
+		return false
+	}
+	if len(input.ExternalID) > 255 {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "externalId는 255자 이하여야 합니다"))
+		return false
+	}
+
+	if input.UserName != user.Username {
+		if _, err := repository.GetUserByUsername(input.UserName); err == nil {
+			scimError(c, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "이미 사용 중인 사용자명입니다"))
+			return false
+		} else if err != gorm.ErrRecordNotFound {
+			scimError(c, err)
+			return false
+		}
+	}
+
+	if input.Password != "" {
+		if violations := password.CheckPolicy(input.Password, input.UserName, email); len(violations) > 0 {
+			messages := make([]string, len(violations))
+			for i, violation := range violations {
+				messages[i] = violation.Message
+			}
+			scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue,
+				"비밀번호가 정책을 만족하지 않습니다: %s", strings.Join(messages, ", ")))
+			return false
+		}
+		hashed, err := password.Hash(input.Password)
+		if err != nil {
+			scimError(c, err)
+			return false
+		}
+		user.Password = hashed
+	}
+
+	if input.Active != nil {
+		switch {
+		case !*input.Active:
+			user.Status = models.UserStatusDisabled
+		case user.Status == models.UserStatusDisabled:
+			user.Status = models.UserStatusActive
+		}
+	}
+
+	user.Username = input.UserName
+	user.Email = email
+	user.ExternalID = input.ExternalID
+	return true
+}
+
+// findSCIMUser는 경로의 ID로 SCIM 토큰의 조직에 속한 사용자를 조회하고, 없으면 오류를 응답합니다.
+func findSCIMUser(c *gin.Context) (models.User, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		scimError(c, scim.ErrNotFound("User", c.Param("id")))
+		return models.User{}, false
+	}
+
+	user, err := repository.GetTenantUserByID(scimTenant(c), id)
+	if err == gorm.ErrRecordNotFound {
+		scimError(c, scim.ErrNotFound("User", c.Param("id")))
+		return user, false
+	} else if err != nil {
+		scimError(c, err)
+		return user, false
+	}
+	return user, true
+}
+
+// findSCIMUserForUpdate는 변경할 사용자를 조회하고 현재 표현을 함께 반환합니다.
+// 전체 관리자 계정은 SCIM으로 변경할 수 없으며, If-Match가 현재 ETag와 다르면 412를 응답합니다.
+func findSCIMUserForUpdate(c *gin.Context) (models.User, scim.User, bool) {
+	user, ok := findSCIMUser(c)
+	if !ok {
+		return user, scim.User{}, false
+	}
+
+	resource, err := scimUserResource(user)
+	if err != nil {
+		scimError(c, err)
+		return user, resource, false
+	}
+	if err := checkSCIMManageable(user); err != nil {
+		scimError(c, err)
+		return user, resource, false
+	}
+	if !checkSCIMPrecondition(c, resource.Meta.Version) {
+		return user, resource, false
+	}
+	return user, resource, true
+}
+
+// checkSCIMManageable은 전체 관리자 권한을 가진 사용자인지 확인합니다.
+// 조직 단위의 SCIM 토큰으로 조직 밖의 데이터를 다룰 수 있는 계정을 변경하지 못하게 합니다.
+func checkSCIMManageable(user models.User) error {
+	groups, err := repository.GetUserGroups(user.ID)
+	if err != nil {
+		return err
+	}
+	user.Groups = groups
+	if tenant.CrossTenant(user) {
+		return scim.NewError(http.StatusForbidden, "", "전체 관리자 계정은 SCIM으로 변경할 수 없습니다")
+	}
+	return nil
+}
+
+// scimUserResource는 사용자를 SCIM 사용자 표현으로 바꿉니다. groups에는 하위 그룹을 통해 속한 그룹도 포함됩니다.
+func scimUserResource(user models.User) (scim.User, error) {
+	groups, err := repository.GetUserGroups(user.ID)
+	if err != nil {
+		return scim.User{}, err
+	}
+
+	id := strconv.FormatInt(user.ID, 10)
+	active := user.Status != models.UserStatusDisabled
+	resource := scim.User{
+		Schemas:    []string{scim.SchemaUser},
+		ID:         id,
+		ExternalID: user.ExternalID,
+		UserName:   user.Username,
+		Active:     &active,
+		Emails:     []scim.MultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Roles:      []scim.MultiValue{{Value: user.Role, Primary: true}},
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     appConfig.SCIMBaseURL + "/Users/" + id,
+		},
+	}
+	for _, group := range groups {
+		groupID := strconv.FormatInt(group.ID, 10)
+		resource.Groups = append(resource.Groups, scim.Member{
+			Value:   groupID,
+			Ref:     appConfig.SCIMBaseURL + "/Groups/" + groupID,
+			Display: group.Name,
+		})
+	}
+
+	resource.Meta.Version, err = scim.ETag(resource)
+	return resource, err
+}
+
+// SCIMGetGroups는 SCIM 토큰의 조직에서 필터와 일치하는 그룹을 startIndex, count에 따라 한 페이지 반환합니다.
+func SCIMGetGroups(c *gin.Context) {
+	where, args, ok := scimFilter(c, scimGroupColumns)
+	if !ok {
+		return
+	}
+	start, offset, limit := scim.Pagination(c.Query("startIndex"), c.Query("count"))
+
+	groups, total, err := repository.SearchGroups(scimTenant(c), where, args, offset, limit)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		resource, err := scimGroupResource(group)
+		if err != nil {
+			scimError(c, err)
+			return
+		}
+		projected, err := scimProject(c, resource)
+		if err != nil {
+			scimError(c, err)
+			return
+		}
+		resources = append(resources, projected)
+	}
+	scimJSON(c, http.StatusOK, scim.NewListResponse(resources, total, start))
+}
+
+// SCIMGetGroup은 그룹 하나를 구성원과 함께 반환합니다.
+func SCIMGetGroup(c *gin.Context) {
+	group, ok := findSCIMGroup(c)
+	if !ok {
+		return
+	}
+	resource, err := scimGroupResource(group)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	respondSCIMResource(c, http.StatusOK, resource)
+}
+
+// SCIMCreateGroup은 SCIM 토큰의 조직에 그룹을 생성하고 구성원을 추가합니다.
+func SCIMCreateGroup(c *gin.Context) {
+	var input scim.Group
+	if !bindSCIM(c, scim.GroupSchema, &input) {
+		return
+	}
+
+	token, _ := middleware.GetSCIMToken(c)
+	group := models.Group{OrganizationID: token.OrganizationID}
+	if !applySCIMGroup(c, &group, input) {
+		return
+	}
+	users, subgroups, ok := resolveSCIMMembers(c, group, input.Members)
+	if !ok {
+		return
+	}
+
+	if err := repository.CreateGroup(&group); err != nil {
+		scimError(c, err)
+		return
+	}
+	if err := syncSCIMMembers(group, users, subgroups); err != nil {
+		scimError(c, err)
+		return
+	}
+
+	respondSCIMGroup(c, http.StatusCreated, group.ID)
+}
+
+// SCIMReplaceGroup은 그룹 이름과 구성원을 요청한 표현으로 교체합니다.
+func SCIMReplaceGroup(c *gin.Context) {
+	group, _, ok := findSCIMGroupForUpdate(c)
+	if !ok {
+		return
+	}
+
+	var input scim.Group
+	if !bindSCIM(c, scim.GroupSchema, &input) {
+		return
+	}
+	updateSCIMGroup(c, group, input)
+}
+
+// SCIMPatchGroup은 PATCH 연산으로 그룹 이름과 구성원을 변경합니다.
+func SCIMPatchGroup(c *gin.Context) {
+	group, current, ok := findSCIMGroupForUpdate(c)
+	if !ok {
+		return
+	}
+
+	doc, ok := patchSCIMResource(c, scim.GroupSchema, current)
+	if !ok {
+		return
+	}
+	var input scim.Group
+	if err := scim.FromMap(scim.GroupSchema, doc, &input); err != nil {
+		scimError(c, err)
+		return
+	}
+	updateSCIMGroup(c, group, input)
+}
+
+// SCIMDeleteGroup은 그룹을 삭제합니다. 구성원인 사용자는 삭제되지 않습니다.
+func SCIMDeleteGroup(c *gin.Context) {
+	group, _, ok := findSCIMGroupForUpdate(c)
+	if !ok {
+		return
+	}
+
+	if err := repository.DeleteGroup(group.ID); err != nil {
+		scimError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// updateSCIMGroup은 요청한 표현을 그룹에 반영하고 구성원을 맞춘 뒤 변경된 그룹을 응답합니다.
+func updateSCIMGroup(c *gin.Context, group models.Group, input scim.Group) {
+	if !applySCIMGroup(c, &group, input) {
+		return
+	}
+	users, subgroups, ok := resolveSCIMMembers(c, group, input.Members)
+	if !ok {
+		return
+	}
+
+	if err := repository.UpdateGroup(&group); err != nil {
+		scimError(c, err)
+		return
+	}
+	if err := syncSCIMMembers(group, users, subgroups); err != nil {
+		scimError(c, err)
+		return
+	}
+
+	respondSCIMGroup(c, http.StatusOK, group.ID)
+}
+
+// applySCIMGroup은 SCIM 그룹 표현을 검증하고 그룹 모델에 반영합니다.
+func applySCIMGroup(c *gin.Context, group *models.Group, input scim.Group) bool {
+	if n := utf8.RuneCountInString(input.DisplayName); n < 2 || n > 100 {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "displayName은 2~100자여야 합니다"))
+		return false
+	}
+	if len(input.ExternalID) > 255 {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "externalId는 255자 이하여야 합니다"))
+		return false
+	}
+
+	if input.DisplayName != group.Name {
+		if _, err := repository.GetGroupByName(group.OrganizationID, input.DisplayName); err == nil {
+			scimError(c, scim.NewError(http.StatusConflict, scim.ErrTypeUniqueness, "이미 사용 중인 그룹 이름입니다"))
+			return false
+		} else if err != gorm.ErrRecordNotFound {
+			scimError(c, err)
+			return false
+		}
+	}
+
+	group.Name = input.DisplayName
+	group.ExternalID = input.ExternalID
+	return true
+}
+
+// resolveSCIMMembers는 구성원 목록을 같은 조직의 사용자 ID와 하위 그룹 ID로 나눕니다.
+// type이 Group인 구성원은 하위 그룹이며, 순환이 생기는 그룹은 추가할 수 없습니다.
+func resolveSCIMMembers(c *gin.Context, group models.Group, members []scim.Member) ([]int64, []int64, bool) {
+	var ancestors []int64
+	if group.ID != 0 {
+		var err error
+		if ancestors, err = repository.GetGroupAncestorIDs(group.ID); err != nil {
+			scimError(c, err)
+			return nil, nil, false
+		}
+	}
+
+	var users, subgroups []int64
+	for _, member := range members {
+		invalid := scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "존재하지 않는 구성원입니다: %s", member.Value)
+		id, err := strconv.ParseInt(member.Value, 10, 64)
+		if err != nil {
+			scimError(c, invalid)
+			return nil, nil, false
+		}
+
+		if strings.EqualFold(member.Type, scim.MemberTypeGroup) {
+			if _, err := repository.GetTenantGroupByID(scimTenant(c), id); err == gorm.ErrRecordNotFound {
+				scimError(c, invalid)
+				return nil, nil, false
+			} else if err != nil {
+				scimError(c, err)
+				return nil, nil, false
+			}
+			if id == group.ID || containsID(ancestors, id) {
+				scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidValue, "상위 그룹이나 자신을 하위 그룹으로 추가할 수 없습니다: %s", member.Value))
+				return nil, nil, false
+			}
+			subgroups = append(subgroups, id)
+			continue
+		}
+
+		user, err := repository.GetTenantUserByID(scimTenant(c), id)
+		if err == gorm.ErrRecordNotFound {
+			scimError(c, invalid)
+			return nil, nil, false
+		} else if err != nil {
+			scimError(c, err)
+			return nil, nil, false
+		}
+		if err := checkSCIMManageable(user); err != nil {
+			scimError(c, err)
+			return nil, nil, false
+		}
+		users = append(users, id)
+	}
+	return users, subgroups, true
+}
+
+// syncSCIMMembers는 그룹의 직접 구성원을 요청한 사용자와 하위 그룹으로 맞춥니다. 바뀐 구성원만 추가하거나 제외합니다.
+func syncSCIMMembers(group models.Group, users, subgroups []int64) error {
+	var currentUsers, currentSubgroups []int64
+	for _, user := range group.Users {
+		currentUsers = append(currentUsers, user.ID)
+	}
+	for _, subgroup := range group.Subgroups {
+		currentSubgroups = append(currentSubgroups, subgroup.ID)
+	}
+
+	for _, id := range users {
+		if !containsID(currentUsers, id) {
+			if err := repository.AddGroupUser(group.ID, id); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range currentUsers {
+		if !containsID(users, id) {
+			if err := repository.RemoveGroupUser(group.ID, id); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range subgroups {
+		if !containsID(currentSubgroups, id) {
+			if err := repository.AddSubgroup(group.ID, id); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range currentSubgroups {
+		if !containsID(subgroups, id) {
+			if err := repository.RemoveSubgroup(group.ID, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// containsID는 ID 목록에 id가 있는지 확인합니다.
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// findSCIMGroup은 경로의 ID로 SCIM 토큰의 조직에 속한 그룹을 구성원과 함께 조회하고, 없으면 오류를 응답합니다.
+func findSCIMGroup(c *gin.Context) (models.Group, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		scimError(c, scim.ErrNotFound("Group", c.Param("id")))
+		return models.Group{}, false
+	}
+
+	group, err := repository.GetTenantGroupByID(scimTenant(c), id)
+	if err == gorm.ErrRecordNotFound {
+		scimError(c, scim.ErrNotFound("Group", c.Param("id")))
+		return group, false
+	} else if err != nil {
+		scimError(c, err)
+		return group, false
+	}
+	return group, true
+}
+
+// findSCIMGroupForUpdate는 변경할 그룹을 조회하고 현재 표현을 함께 반환합니다.
+// 전체 관리자 권한을 부여하는 역할이 지정된 그룹은 SCIM으로 변경할 수 없으며, If-Match가 현재 ETag와 다르면 412를 응답합니다.
+func findSCIMGroupForUpdate(c *gin.Context) (models.Group, scim.Group, bool) {
+	group, ok := findSCIMGroup(c)
+	if !ok {
+		return group, scim.Group{}, false
+	}
+
+	if group.Role != "" && auth.RoleHasPermission(group.Role, auth.PermOrgsManage) {
+		scimError(c, scim.NewError(http.StatusForbidden, "", "전체 관리자 역할이 지정된 그룹은 SCIM으로 변경할 수 없습니다"))
+		return group, scim.Group{}, false
+	}
+
+	resource, err := scimGroupResource(group)
+	if err != nil {
+		scimError(c, err)
+		return group, resource, false
+	}
+	if !checkSCIMPrecondition(c, resource.Meta.Version) {
+		return group, resource, false
+	}
+	return group, resource, true
+}
+
+// respondSCIMGroup은 구성원 변경을 반영하여 그룹을 다시 조회한 뒤 응답합니다.
+func respondSCIMGroup(c *gin.Context, status int, id int64) {
+	group, err := repository.GetTenantGroupByID(scimTenant(c), id)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	resource, err := scimGroupResource(group)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	if status == http.StatusCreated {
+		c.Header("Location", resource.Meta.Location)
+	}
+	respondSCIMResource(c, status, resource)
+}
+
+// scimGroupResource는 그룹을 SCIM 그룹 표현으로 바꿉니다. 구성원은 직접 구성원인 사용자와 하위 그룹입니다.
+func scimGroupResource(group models.Group) (scim.Group, error) {
+	id := strconv.FormatInt(group.ID, 10)
+	resource := scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          id,
+		ExternalID:  group.ExternalID,
+		DisplayName: group.Name,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      group.CreatedAt,
+			LastModified: group.UpdatedAt,
+			Location:     appConfig.SCIMBaseURL + "/Groups/" + id,
+		},
+	}
+	for _, user := range group.Users {
+		userID := strconv.FormatInt(user.ID, 10)
+		resource.Members = append(resource.Members, scim.Member{
+			Value:   userID,
+			Ref:     appConfig.SCIMBaseURL + "/Users/" + userID,
+			Display: user.Username,
+			Type:    scim.MemberTypeUser,
+		})
+	}
+	for _, subgroup := range group.Subgroups {
+		subgroupID := strconv.FormatInt(subgroup.ID, 10)
+		resource.Members = append(resource.Members, scim.Member{
+			Value:   subgroupID,
+			Ref:     appConfig.SCIMBaseURL + "/Groups/" + subgroupID,
+			Display: subgroup.Name,
+			Type:    scim.MemberTypeGroup,
+		})
+	}
+
+	var err error
+	resource.Meta.Version, err = scim.ETag(resource)
+	return resource, err
+}
+
+// scimTenant는 SCIM 토큰의 조직 범위를 반환합니다.
+func scimTenant(c *gin.Context) repository.Tenant {
+	token, _ := middleware.GetSCIMToken(c)
+	return repository.Tenant{OrganizationID: token.OrganizationID}
+}
+
+// scimFilter는 filter 쿼리 파라미터를 WHERE 조건식으로 바꿉니다. 필터가 없으면 빈 조건식을 반환합니다.
+func scimFilter(c *gin.Context, columns map[string]scim.Column) (string, []interface{}, bool) {
+	raw := c.Query("filter")
+	if raw == "" {
+		return "", nil, true
+	}
+
+	filter, err := scim.ParseFilter(raw)
+	if err != nil {
+		scimError(c, err)
+		return "", nil, false
+	}
+	where, args, err := filter.SQL(func(path string) (scim.Column, bool) {
+		column, ok := columns[path]
+		return column, ok
+	})
+	if err != nil {
+		scimError(c, err)
+		return "", nil, false
+	}
+	return where, args, true
+}
+
+// scimProject는 attributes, excludedAttributes 쿼리 파라미터에 따라 리소스 속성을 고릅니다.
+func scimProject(c *gin.Context, resource interface{}) (interface{}, error) {
+	attributes, excluded := c.Query("attributes"), c.Query("excludedAttributes")
+	if attributes == "" && excluded == "" {
+		return resource, nil
+	}
+	doc, err := scim.ToMap(resource)
+	if err != nil {
+		return nil, err
+	}
+	return scim.Project(doc, attributes, excluded), nil
+}
+
+// bindSCIM은 요청 본문을 스키마에 맞게 정규화하여 리소스 표현으로 바꿉니다.
+func bindSCIM(c *gin.Context, schema scim.Schema, resource interface{}) bool {
+	var doc map[string]interface{}
+	if err := json.NewDecoder(c.Request.Body).Decode(&doc); err != nil {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidSyntax, "잘못된 요청 형식입니다: %v", err))
+		return false
+	}
+	if err := scim.FromMap(schema, doc, resource); err != nil {
+		scimError(c, err)
+		return false
+	}
+	return true
+}
+
+// patchSCIMResource는 요청 본문의 PATCH 연산을 현재 리소스 표현에 적용한 결과를 반환합니다.
+func patchSCIMResource(c *gin.Context, schema scim.Schema, current interface{}) (map[string]interface{}, bool) {
+	var req scim.PatchRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		scimError(c, scim.NewError(http.StatusBadRequest, scim.ErrTypeInvalidSyntax, "잘못된 요청 형식입니다: %v", err))
+		return nil, false
+	}
+
+	doc, err := scim.ToMap(current)
+	if err != nil {
+		scimError(c, err)
+		return nil, false
+	}
+	doc, err = scim.Apply(schema, doc, req.Operations)
+	if err != nil {
+		scimError(c, err)
+		return nil, false
+	}
+	return doc, true
+}
+
+// checkSCIMPrecondition은 If-Match 헤더가 있으면 현재 ETag와 일치하는지 확인합니다.
+func checkSCIMPrecondition(c *gin.Context, etag string) bool {
+	if header := c.GetHeader("If-Match"); header != "" && !scim.MatchETag(header, etag) {
+		scimError(c, scim.NewError(http.StatusPreconditionFailed, "", "리소스가 변경되었습니다. 다시 조회한 뒤 시도하세요"))
+		return false
+	}
+	return true
+}
+
+// respondSCIMResource는 ETag 헤더와 함께 리소스를 응답합니다.
+// If-None-Match가 현재 ETag와 같으면 본문 없이 304를 응답합니다.
+func respondSCIMResource(c *gin.Context, status int, resource interface{}) {
+	var etag string
+	switch r := resource.(type) {
+	case scim.User:
+		etag = r.Meta.Version
+	case scim.Group:
+		etag = r.Meta.Version
+	}
+	c.Header("ETag", etag)
+
+	if status == http.StatusOK && scim.MatchETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	projected, err := scimProject(c, resource)
+	if err != nil {
+		scimError(c, err)
+		return
+	}
+	scimJSON(c, status, projected)
+}
+
+// scimJSON은 SCIM 미디어 유형으로 JSON을 응답합니다.
+func scimJSON(c *gin.Context, status int, body interface{}) {
+	c.Header("Content-Type", scim.ContentType)
+	c.JSON(status, body)
+}
+
+// scimError는 SCIM 오류 형식으로 응답합니다. SCIM 오류가 아닌 오류는 내용을 숨기고 500으로 응답합니다.
+func scimError(c *gin.Context, err error) {
+	scimErr, ok := err.(*scim.Error)
+	if !ok {
+		log.Printf("SCIM 요청 처리 실패 (%s %s): %v", c.Request.Method, c.Request.URL.Path, err)
+		scimErr = scim.NewError(http.StatusInternalServerError, "", "요청 처리 중 오류가 발생했습니다")
+	}
+	scimJSON(c, scimErr.Status, scimErr)
+}
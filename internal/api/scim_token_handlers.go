@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// GetSCIMTokens는 관리할 수 있는 조직의 SCIM 토큰 목록을 반환합니다.
+func GetSCIMTokens(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	tokens, err := repository.GetSCIMTokens(tenant.Of(authUser))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateSCIMToken은 ID 공급자가 조직의 사용자와 그룹을 프로비저닝할 때 사용할 SCIM 토큰을 발급합니다.
+// 토큰 원문은 이 응답에서만 확인할 수 있습니다.
+func CreateSCIMToken(c *gin.Context) {
+	var req models.CreateSCIMTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	organizationID, ok := resolveOrganization(c, authUser, req.OrganizationID)
+	if !ok {
+		return
+	}
+
+	raw, hash, err := auth.GenerateSCIMToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	token := models.SCIMToken{
+		Name:           req.Name,
+		TokenHash:      hash,
+		TokenPrefix:    raw[:len(auth.SCIMTokenPrefix)+4],
+		OrganizationID: organizationID,
+		CreatedBy:      authUser.ID,
+	}
+	if err := repository.CreateSCIMToken(&token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreateSCIMTokenResponse{
+		SCIMToken: token,
+		Token:     raw,
+	})
+}
+
+// RevokeSCIMToken은 SCIM 토큰을 폐기합니다. 폐기된 토큰으로는 즉시 프로비저닝할 수 없습니다.
+func RevokeSCIMToken(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 토큰 ID 형식입니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	revoked, err := repository.RevokeSCIMToken(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 폐기 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !revoked {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "토큰을 찾을 수 없습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "토큰이 폐기되었습니다",
+	})
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/choi-jiwoong/go-quickstart/pkg/useragent"
+	"github.com/gin-gonic/gin"
+)
+
+// startSession은 로그인에 성공한 사용자의 세션을 기록하고 첫 토큰을 발급합니다.
+// 사용자의 동시 세션 수가 제한을 넘으면 가장 오래된 세션부터 종료합니다.
+func startSession(c *gin.Context, user models.User) (models.LoginResponse, error) {
+	familyID, err := auth.RandomHex(16)
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	now := time.Now()
+	session := models.Session{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		IPAddress:  c.ClientIP(),
+		UserAgent:  truncate(c.Request.UserAgent(), 255),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(auth.RefreshTokenTTL()),
+	}
+	if err := repository.CreateSession(&session); err != nil {
+		return models.LoginResponse{}, err
+	}
+	if err := evictSessions(user.ID); err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	return issueTokens(user, familyID)
+}
+
+// evictSessions는 동시 세션 수 제한을 넘는 오래된 세션을 종료합니다.
+// 관리자가 가장하여 만든 세션은 사용자의 세션 수에 포함하지 않습니다.
+func evictSessions(userID int64) error {
+	limit := appConfig.MaxSessionsPerUser
+	if limit <= 0 {
+		return nil
+	}
+
+	sessions, err := repository.GetActiveSessions(userID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, session := range sessions {
+		if session.ImpersonatorID != 0 {
+			continue
+		}
+		count++
+		if count <= limit {
+			continue
+		}
+		if err := endSession(session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endSession은 세션의 리프레시 토큰과 이미 발급된 액세스 토큰을 모두 폐기합니다.
+func endSession(session models.Session) error {
+	if err := repository.RevokeRefreshTokenFamily(session.FamilyID); err != nil {
+		return err
+	}
+	return auth.RevokeSession(session.UserID, session.FamilyID)
+}
+
+// ListSessions는 인증된 사용자의 활성 세션 목록을 조회합니다.
+func ListSessions(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+	respondSessions(c, authUser.ID)
+}
+
+// RevokeSession은 인증된 사용자의 세션 하나를 종료합니다.
+func RevokeSession(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+	session, ok := findSession(c, authUser.ID, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	revokeSession(c, session)
+}
+
+// GetUserSessions는 특정 사용자의 활성 세션 목록을 조회합니다.
+// users:read 권한이 필요합니다.
+func GetUserSessions(c *gin.Context) {
+	user, ok := findSessionOwner(c)
+	if !ok {
+		return
+	}
+
+	respondSessions(c, user.ID)
+}
+
+// RevokeUserSession은 특정 사용자의 세션 하나를 종료합니다.
+// sessions:revoke 권한이 필요합니다.
+func RevokeUserSession(c *gin.Context) {
+	user, ok := findSessionOwner(c)
+	if !ok {
+		return
+	}
+	authUser, _ := middleware.GetAuthUser(c)
+	if !checkManageable(c, authUser, user) {
+		return
+	}
+
+	session, ok := findSession(c, user.ID, c.Param("sessionId"))
+	if !ok {
+		return
+	}
+
+	revokeSession(c, session)
+}
+
+// findSessionOwner는 경로의 사용자 ID로 같은 조직의 사용자를 조회합니다.
+func findSessionOwner(c *gin.Context) (models.User, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return models.User{}, false
+	}
+
+	// 다른 조직의 사용자는 찾을 수 없음
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return models.User{}, false
+	}
+	return user, true
+}
+
+// findSession은 사용자의 활성 세션을 조회합니다. 다른 사용자의 세션이나 이미 종료된 세션은 찾을 수 없습니다.
+func findSession(c *gin.Context, userID int64, idParam string) (models.Session, bool) {
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 세션 ID 형식입니다",
+		})
+		return models.Session{}, false
+	}
+
+	session, err := repository.GetSessionByID(id)
+	if err != nil || session.UserID != userID || session.RevokedAt != nil || !session.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "세션을 찾을 수 없습니다",
+		})
+		return models.Session{}, false
+	}
+	return session, true
+}
+
+// respondSessions는 사용자의 활성 세션 목록을 기기 정보와 함께 응답합니다.
+func respondSessions(c *gin.Context, userID int64) {
+	sessions, err := repository.GetActiveSessions(userID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "세션 목록 조회 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	var current string
+	if claims, ok := middleware.GetAuthClaims(c); ok {
+		current = claims.SessionID
+	}
+
+	response := make([]models.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, models.SessionResponse{
+			Session: session,
+			Device:  useragent.Parse(session.UserAgent),
+			Current: current != "" && session.FamilyID == current,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// revokeSession은 세션을 종료하고 결과를 응답합니다.
+func revokeSession(c *gin.Context, session models.Session) {
+	if err := endSession(session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "세션 종료 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "세션이 종료되었습니다",
+	})
+}
+
+// truncate는 문자열을 UTF-8 문자가 잘리지 않도록 최대 n바이트로 자릅니다.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePersonalAccessToken은 인증된 사용자의 개인 액세스 토큰을 생성합니다.
+// 토큰 원문은 이 응답에서만 확인할 수 있습니다.
+func CreatePersonalAccessToken(c *gin.Context) {
+	var req models.CreatePersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 요청 형식입니다: " + err.Error(),
+		})
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":  "지원하지 않는 권한 범위입니다: " + scope,
+				"scopes": auth.Scopes,
+			})
+			return
+		}
+	}
+
+	lifetime := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	if lifetime > appConfig.PATMaxLifetime {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "토큰 유효 기간이 허용된 최대 기간을 초과합니다",
+		})
+		return
+	}
+
+	raw, hash, err := auth.GeneratePersonalAccessToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+	token := models.PersonalAccessToken{
+		UserID:      authUser.ID,
+		Name:        req.Name,
+		TokenHash:   hash,
+		TokenPrefix: raw[:len(auth.PersonalAccessTokenPrefix)+4],
+		Scopes:      uniqueStrings(req.Scopes),
+		ExpiresAt:   time.Now().Add(lifetime),
+	}
+
+	if err := repository.CreatePersonalAccessToken(&token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 생성 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.CreatePersonalAccessTokenResponse{
+		PersonalAccessToken: token,
+		Token:               raw,
+	})
+}
+
+// ListPersonalAccessTokens는 인증된 사용자의 개인 액세스 토큰 목록을 반환합니다.
+func ListPersonalAccessTokens(c *gin.Context) {
+	authUser, _ := middleware.GetAuthUser(c)
+
+	tokens, err := repository.GetPersonalAccessTokensByUser(authUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 목록을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RevokePersonalAccessToken은 인증된 사용자의 개인 액세스 토큰을 폐기합니다.
+func RevokePersonalAccessToken(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 토큰 ID 형식입니다",
+		})
+		return
+	}
+
+	authUser, _ := middleware.GetAuthUser(c)
+
+	revoked, err := repository.RevokePersonalAccessToken(id, authUser.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "토큰 폐기 중 오류가 발생했습니다",
+		})
+		return
+	}
+	if !revoked {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "토큰을 찾을 수 없습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "토큰이 폐기되었습니다",
+	})
+}
+
+// uniqueStrings는 순서를 유지하면서 중복 값을 제거합니다.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
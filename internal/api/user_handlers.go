@@ -4,17 +4,35 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/authz"
 	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
 	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-// GetUsers는 모든 사용자 목록을 반환합니다.
-// 관리자만 접근 가능합니다.
+// GetUsers는 같은 조직의 모든 사용자 목록을 반환합니다.
+// users:read 권한이 필요하며, organizations:manage 권한이 있으면 모든 조직의 사용자를 조회하고
+// organization_id 쿼리 파라미터로 조직을 지정할 수 있습니다.
 func GetUsers(c *gin.Context) {
-	users, err := repository.GetAllUsers()
+	authUser, _ := middleware.GetAuthUser(c)
+	scope := tenant.Of(authUser)
+	if orgParam := c.Query("organization_id"); orgParam != "" && scope.All {
+		orgID, err := strconv.ParseInt(orgParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "잘못된 조직 ID 형식입니다",
+			})
+			return
+		}
+		scope = repository.Tenant{OrganizationID: orgID}
+	}
+
+	users, err := repository.GetAllUsers(scope)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "사용자 목록을 가져오는 중 오류가 발생했습니다",
@@ -25,8 +43,8 @@ func GetUsers(c *gin.Context) {
 	c.JSON(http.StatusOK, users)
 }
 
-// GetUser는 특정 ID의 사용자 정보를 반환합니다.
-// 관리자는 모든 사용자 정보를 볼 수 있고, 일반 사용자는 자신의 정보만 볼 수 있습니다.
+// GetUser는 특정 ID의 사용자 정보를 소속 그룹과 함께 반환합니다.
+// 접근 여부는 권한 정책으로 결정되며, 기본 정책에서는 users:read 권한이 있으면 모든 사용자, 없으면 자신의 정보만 볼 수 있습니다.
 func GetUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
@@ -37,18 +55,17 @@ func GetUser(c *gin.Context) {
 		return
 	}
 	
-	// 인증된 사용자 정보 가져오기
 	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
 	
-	// 권한 확인: 관리자가 아니고 자신의 정보가 아닌 경우 접근 거부
-	if authUser.Role != "ADMIN" && authUser.ID != id {
+	// 권한 확인: 존재하지 않거나 다른 조직의 사용자도 권한이 없으면 같은 응답으로 거부
+	if !authorize(c, auth.PermUsersRead, userResource(id, user)) {
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": "다른 사용자의 정보에 접근할 권한이 없습니다",
 		})
 		return
 	}
 	
-	user, err := repository.GetUserByID(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "사용자를 찾을 수 없습니다",
@@ -56,11 +73,20 @@ func GetUser(c *gin.Context) {
 		return
 	}
 	
+	// 소속 그룹 (상위 그룹 포함)
+	user.Groups, err = repository.GetUserGroups(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 그룹을 가져오는 중 오류가 발생했습니다",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, user)
 }
 
 // CreateUser는 새 사용자를 생성합니다.
-// 관리자만 접근 가능합니다.
+// users:create 권한이 필요하며, USER 이외의 역할을 지정하려면 roles:manage 권한도 필요합니다.
 func CreateUser(c *gin.Context) {
 	var req models.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -70,6 +96,18 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 	
+	// 역할 확인
+	authUser, _ := middleware.GetAuthUser(c)
+	if !checkRoleAssignment(c, authUser, req.Role, auth.RoleUser) {
+		return
+	}
+
+	// 조직 확인: 다른 조직에는 organizations:manage 권한이 있어야 생성 가능
+	organizationID, ok := resolveOrganization(c, authUser, req.OrganizationID)
+	if !ok {
+		return
+	}
+
 	// 사용자명 중복 확인
 	_, err := repository.GetUserByUsername(req.Username)
 	if err == nil {
@@ -84,12 +122,28 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 	
+	// 비밀번호 정책 검사
+	if !checkPasswordPolicy(c, req.Password, req.Username, req.Email) {
+		return
+	}
+
+	// 비밀번호 해싱
+	hashedPassword, err := password.Hash(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다",
+		})
+		return
+	}
+
 	// 새 사용자 생성
 	user := models.User{
 		Username: req.Username,
 		Email:    req.Email,
-		Password: req.Password, // 실제 구현에서는 비밀번호 해싱 필요
+		Password: hashedPassword,
 		Role:     req.Role,
+
+		OrganizationID: organizationID,
 	}
 	
 	if err := repository.CreateUser(&user); err != nil {
@@ -103,7 +157,8 @@ func CreateUser(c *gin.Context) {
 }
 
 // UpdateUser는 사용자 정보를 업데이트합니다.
-// 관리자는 모든 사용자 정보를 수정할 수 있고, 일반 사용자는 자신의 정보만 수정할 수 있습니다.
+// 접근 여부는 권한 정책으로 결정되며, 기본 정책에서는 users:update 권한이 있으면 모든 사용자, 없으면 자신의 정보만 수정할 수 있습니다.
+// 역할을 변경하려면 roles:manage 권한이 필요하며, 관리자가 가장한 상태에서는 비밀번호와 역할을 변경할 수 없습니다.
 func UpdateUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
@@ -117,22 +172,26 @@ func UpdateUser(c *gin.Context) {
 	// 인증된 사용자 정보 가져오기
 	authUser, _ := middleware.GetAuthUser(c)
 	
-	// 권한 확인: 관리자가 아니고 자신의 정보가 아닌 경우 접근 거부
-	if authUser.Role != "ADMIN" && authUser.ID != id {
+	// 기존 사용자 조회
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+
+	// 권한 확인: 존재하지 않거나 다른 조직의 사용자도 권한이 없으면 같은 응답으로 거부
+	if !authorize(c, auth.PermUsersUpdate, userResource(id, user)) {
 		c.JSON(http.StatusForbidden, gin.H{
 			"error": "다른 사용자의 정보를 수정할 권한이 없습니다",
 		})
 		return
 	}
 	
-	// 기존 사용자 조회
-	user, err := repository.GetUserByID(id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "사용자를 찾을 수 없습니다",
 		})
 		return
 	}
+	if !checkManageable(c, authUser, user) {
+		return
+	}
 	
 	// 요청 바인딩
 	var req models.UpdateUserRequest
@@ -143,13 +202,18 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 	
-	// 일반 사용자는 역할을 변경할 수 없음
-	if authUser.Role != "ADMIN" && req.Role != "" && req.Role != user.Role {
+	// 가장한 상태에서는 비밀번호와 역할을 변경할 수 없음
+	if middleware.IsImpersonated(c) && (req.Password != "" || req.Role != "") {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "역할을 변경할 권한이 없습니다",
+			"error": "다른 사용자로 가장한 상태에서는 비밀번호나 역할을 변경할 수 없습니다",
 		})
 		return
 	}
+
+	// 역할 변경은 역할 관리 권한이 있어야 가능
+	if req.Role != "" && !checkRoleAssignment(c, authUser, req.Role, user.Role) {
+		return
+	}
 	
 	// 사용자명 변경 시 중복 확인
 	if req.Username != "" && req.Username != user.Username {
@@ -173,9 +237,19 @@ func UpdateUser(c *gin.Context) {
 		user.Email = req.Email
 	}
 	if req.Password != "" {
-		user.Password = req.Password // 실제 구현에서는 비밀번호 해싱 필요
+		if !checkPasswordPolicy(c, req.Password, user.Username, user.Email) {
+			return
+		}
+		hashedPassword, err := password.Hash(req.Password)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "비밀번호 처리 중 오류가 발생했습니다",
+			})
+			return
+		}
+		user.Password = hashedPassword
 	}
-	if req.Role != "" && authUser.Role == "ADMIN" {
+	if req.Role != "" {
 		user.Role = req.Role
 	}
 	
@@ -187,11 +261,21 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 	
+	// 비밀번호가 변경되면 기존에 발급된 모든 토큰 폐기
+	if req.Password != "" {
+		if err := auth.RevokeAllUserTokens(user.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "기존 세션 종료 중 오류가 발생했습니다",
+			})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, user)
 }
 
 // DeleteUser는 사용자를 삭제합니다.
-// 관리자만 접근 가능합니다.
+// users:delete 권한이 필요합니다.
 func DeleteUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
@@ -202,14 +286,18 @@ func DeleteUser(c *gin.Context) {
 		return
 	}
 	
-	// 사용자 존재 여부 확인
-	_, err = repository.GetUserByID(id)
+	// 사용자 존재 여부 확인 (다른 조직의 사용자는 찾을 수 없음)
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "사용자를 찾을 수 없습니다",
 		})
 		return
 	}
+	if !checkManageable(c, authUser, user) {
+		return
+	}
 	
 	// 사용자 삭제
 	if err := repository.DeleteUser(id); err != nil {
@@ -222,4 +310,170 @@ func DeleteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message": "사용자가 성공적으로 삭제되었습니다",
 	})
-}
\ No newline at end of file
+}
+
+// RevokeUserSessions는 특정 사용자에게 발급된 모든 토큰을 폐기합니다.
+// sessions:revoke 권한이 필요합니다.
+func RevokeUserSessions(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	// 사용자 존재 여부 확인 (다른 조직의 사용자는 찾을 수 없음)
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+	if !checkManageable(c, authUser, user) {
+		return
+	}
+
+	if err := auth.RevokeAllUserTokens(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "세션 종료 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "사용자의 모든 세션이 종료되었습니다",
+	})
+}
+
+// checkPasswordPolicy는 비밀번호 정책을 검사하고 위반 시 위반한 규칙 목록을 응답합니다.
+func checkPasswordPolicy(c *gin.Context, plain, username, email string) bool {
+	violations := password.CheckPolicy(plain, username, email)
+	if len(violations) == 0 {
+		return true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":      "비밀번호가 정책을 만족하지 않습니다",
+		"violations": violations,
+	})
+	return false
+}
+
+// UnlockUser는 연속 로그인 실패로 잠긴 계정의 잠금을 해제합니다.
+// users:unlock 권한이 필요합니다.
+func UnlockUser(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "잘못된 사용자 ID 형식입니다",
+		})
+		return
+	}
+
+	// 사용자 존재 여부 확인 (다른 조직의 사용자는 찾을 수 없음)
+	authUser, _ := middleware.GetAuthUser(c)
+	user, err := repository.GetTenantUserByID(tenant.Of(authUser), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "사용자를 찾을 수 없습니다",
+		})
+		return
+	}
+	if !checkManageable(c, authUser, user) {
+		return
+	}
+
+	if err := repository.ResetUserLockout(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "계정 잠금 해제 중 오류가 발생했습니다",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "계정 잠금이 해제되었습니다",
+	})
+}
+
+// checkRoleAssignment는 지정하려는 역할이 존재하는지, 현재 역할과 다르면 역할 관리 권한이 있고 역할의 권한이 모두 자신에게 있는지 확인합니다.
+func checkRoleAssignment(c *gin.Context, authUser models.User, role, current string) bool {
+	if role != current && !auth.HasPermission(authUser, auth.PermRolesManage) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "역할을 변경할 권한이 없습니다",
+		})
+		return false
+	}
+	if !auth.RoleExists(role) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "존재하지 않는 역할입니다",
+		})
+		return false
+	}
+	return role == current || checkGrantableRole(c, authUser, role)
+}
+
+// resolveOrganization은 새로 만들 리소스가 속할 조직을 결정합니다.
+// 지정하지 않으면 요청한 사용자의 조직이며, 다른 조직은 organizations:manage 권한이 있어야 지정할 수 있습니다.
+func resolveOrganization(c *gin.Context, authUser models.User, requested int64) (int64, bool) {
+	if requested == 0 || requested == authUser.OrganizationID {
+		return authUser.OrganizationID, true
+	}
+
+	if !tenant.CrossTenant(authUser) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "다른 조직에 생성할 권한이 없습니다",
+		})
+		return 0, false
+	}
+	if _, err := repository.GetOrganizationByID(requested); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "존재하지 않는 조직입니다",
+		})
+		return 0, false
+	}
+	return requested, true
+}
+
+// checkManageable은 전체 관리자 계정을 다른 권한의 사용자가 변경하지 못하도록 확인합니다.
+// 그룹 역할로 전체 관리자 권한을 가진 사용자도 보호합니다.
+func checkManageable(c *gin.Context, authUser, target models.User) bool {
+	if target.ID == authUser.ID || tenant.CrossTenant(authUser) {
+		return true
+	}
+
+	groups, err := repository.GetUserGroups(target.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "사용자 확인 중 오류가 발생했습니다",
+		})
+		return false
+	}
+	target.Groups = groups
+	if tenant.CrossTenant(target) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "전체 관리자 계정은 변경할 수 없습니다",
+		})
+		return false
+	}
+	return true
+}
+
+// authorize는 인증된 사용자가 리소스에 대해 동작을 수행할 수 있는지 권한 정책으로 확인합니다.
+func authorize(c *gin.Context, action string, resource authz.Resource) bool {
+	authUser, _ := middleware.GetAuthUser(c)
+	decision := authz.Authorize(authz.SubjectFromUser(authUser), action, resource, authz.Environment{
+		IP: c.ClientIP(),
+	})
+	return decision.Allowed
+}
+
+// userResource는 사용자 계정 리소스를 만듭니다. 조회에 실패한 경우 ID만 사용합니다.
+func userResource(id int64, user models.User) authz.Resource {
+	user.ID = id
+	return authz.UserResource(user)
+}
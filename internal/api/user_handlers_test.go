@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/middleware"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
 	"github.com/choi-jiwoong/go-quickstart/internal/repository"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -20,8 +24,8 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) GetAllUsers() ([]models.User, error) {
-	args := m.Called()
+func (m *MockUserRepository) GetAllUsers(tenant repository.Tenant) ([]models.User, error) {
+	args := m.Called(tenant)
 	return args.Get(0).([]models.User), args.Error(1)
 }
 
@@ -30,6 +34,11 @@ func (m *MockUserRepository) GetUserByID(id int64) (models.User, error) {
 	return args.Get(0).(models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetTenantUserByID(tenant repository.Tenant, id int64) (models.User, error) {
+	args := m.Called(tenant, id)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) CreateUser(user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
@@ -50,6 +59,42 @@ func (m *MockUserRepository) GetUserByUsername(username string) (models.User, er
 	return args.Get(0).(models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) CreateLoginHistory(history *models.LoginHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateSession(session *models.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserPassword(id int64, passwordHash string) error {
+	args := m.Called(id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetLoginFailureStreak(userID int64, since *time.Time) (int64, *time.Time, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).(int64), args.Get(1).(*time.Time), args.Error(2)
+}
+
+// withAuthUser는 인증 미들웨어 대신 주어진 사용자를 컨텍스트에 설정합니다.
+func withAuthUser(user models.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUser, user)
+		c.Next()
+	}
+}
+
+// testAdmin은 핸들러 테스트에서 사용하는 전체 관리자 사용자입니다.
+var testAdmin = models.User{ID: 100, Username: "admin", Email: "admin@example.com", Role: "SUPER_ADMIN"}
+
 // 테스트 설정
 func setupTest(t *testing.T) (*gin.Engine, *MockUserRepository) {
 	gin.SetMode(gin.TestMode)
@@ -59,27 +104,51 @@ func setupTest(t *testing.T) (*gin.Engine, *MockUserRepository) {
 	// 원래 함수 저장
 	originalGetAllUsers := repository.GetAllUsers
 	originalGetUserByID := repository.GetUserByID
+	originalGetTenantUserByID := repository.GetTenantUserByID
 	originalCreateUser := repository.CreateUser
 	originalUpdateUser := repository.UpdateUser
 	originalDeleteUser := repository.DeleteUser
 	originalGetUserByUsername := repository.GetUserByUsername
+	originalCreateLoginHistory := repository.CreateLoginHistory
+	originalCreateRefreshToken := repository.CreateRefreshToken
+	originalCreateSession := repository.CreateSession
+	originalUpdateUserPassword := repository.UpdateUserPassword
+	originalGetLoginFailureStreak := repository.GetLoginFailureStreak
+	originalGetUserGroups := repository.GetUserGroups
 	
 	// 모의 함수로 대체
 	repository.GetAllUsers = mockRepo.GetAllUsers
 	repository.GetUserByID = mockRepo.GetUserByID
+	repository.GetTenantUserByID = mockRepo.GetTenantUserByID
 	repository.CreateUser = mockRepo.CreateUser
 	repository.UpdateUser = mockRepo.UpdateUser
 	repository.DeleteUser = mockRepo.DeleteUser
 	repository.GetUserByUsername = mockRepo.GetUserByUsername
+	repository.CreateLoginHistory = mockRepo.CreateLoginHistory
+	repository.CreateRefreshToken = mockRepo.CreateRefreshToken
+	repository.CreateSession = mockRepo.CreateSession
+	repository.UpdateUserPassword = mockRepo.UpdateUserPassword
+	repository.GetLoginFailureStreak = mockRepo.GetLoginFailureStreak
+	// 그룹 구성원 정보는 사용하지 않음
+	repository.GetUserGroups = func(userID int64) ([]models.Group, error) {
+		return nil, nil
+	}
 	
 	t.Cleanup(func() {
 		// 테스트 후 원래 함수 복원
 		repository.GetAllUsers = originalGetAllUsers
 		repository.GetUserByID = originalGetUserByID
+		repository.GetTenantUserByID = originalGetTenantUserByID
 		repository.CreateUser = originalCreateUser
 		repository.UpdateUser = originalUpdateUser
 		repository.DeleteUser = originalDeleteUser
 		repository.GetUserByUsername = originalGetUserByUsername
+		repository.CreateLoginHistory = originalCreateLoginHistory
+		repository.CreateRefreshToken = originalCreateRefreshToken
+		repository.CreateSession = originalCreateSession
+		repository.UpdateUserPassword = originalUpdateUserPassword
+		repository.GetLoginFailureStreak = originalGetLoginFailureStreak
+		repository.GetUserGroups = originalGetUserGroups
 	})
 	
 	return router, mockRepo
@@ -88,6 +157,7 @@ func setupTest(t *testing.T) (*gin.Engine, *MockUserRepository) {
 // TestGetUsers는 GetUsers 핸들러를 테스트합니다.
 func TestGetUsers(t *testing.T) {
 	router, mockRepo := setupTest(t)
+	router.Use(withAuthUser(testAdmin))
 	router.GET("/users", GetUsers)
 	
 	// 모의 데이터 설정
@@ -95,7 +165,7 @@ func TestGetUsers(t *testing.T) {
 		{ID: 1, Username: "user1", Email: "user1@example.com", Role: "USER"},
 		{ID: 2, Username: "user2", Email: "user2@example.com", Role: "ADMIN"},
 	}
-	mockRepo.On("GetAllUsers").Return(users, nil)
+	mockRepo.On("GetAllUsers", repository.AllTenants).Return(users, nil)
 	
 	// 요청 실행
 	w := httptest.NewRecorder()
@@ -118,11 +188,12 @@ func TestGetUsers(t *testing.T) {
 // TestGetUser는 GetUser 핸들러를 테스트합니다.
 func TestGetUser(t *testing.T) {
 	router, mockRepo := setupTest(t)
+	router.Use(withAuthUser(testAdmin))
 	router.GET("/user/:id", GetUser)
 	
 	// 모의 데이터 설정
 	user := models.User{ID: 1, Username: "user1", Email: "user1@example.com", Role: "USER"}
-	mockRepo.On("GetUserByID", int64(1)).Return(user, nil)
+	mockRepo.On("GetTenantUserByID", repository.AllTenants, int64(1)).Return(user, nil)
 	
 	// 요청 실행
 	w := httptest.NewRecorder()
@@ -175,17 +246,98 @@ func TestCreateUser(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "newuser", response.Username)
 	
+	// 비밀번호는 해싱되어 저장되어야 함
+	mockRepo.AssertCalled(t, "CreateUser", mock.MatchedBy(func(user *models.User) bool {
+		if user.Password == "password123" {
+			return false
+		}
+		matched, err := password.Verify(user.Password, "password123")
+		return err == nil && matched
+	}))
+
 	mockRepo.AssertExpectations(t)
 }
 
+// TestCreateUserPasswordPolicy는 비밀번호 정책 위반 시 위반 규칙 목록이 응답되는지 테스트합니다.
+func TestCreateUserPasswordPolicy(t *testing.T) {
+	router, mockRepo := setupTest(t)
+	router.POST("/user", CreateUser)
+
+	mockRepo.On("GetUserByUsername", "policyuser").Return(models.User{}, gorm.ErrRecordNotFound)
+
+	reqBody := models.CreateUserRequest{
+		Username: "policyuser",
+		Email:    "owner@example.com",
+		Password: "policyuser",
+		Role:     "USER",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/user", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response struct {
+		Error      string               `json:"error"`
+		Violations []password.Violation `json:"violations"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "비밀번호가 정책을 만족하지 않습니다", response.Error)
+	if assert.Len(t, response.Violations, 1) {
+		assert.Equal(t, password.RuleNoUsername, response.Violations[0].Rule)
+	}
+
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+// TestCreateUserPasswordTooLong은 bcrypt의 72바이트 제한을 넘는 비밀번호가 거부되는지 테스트합니다.
+func TestCreateUserPasswordTooLong(t *testing.T) {
+	router, mockRepo := setupTest(t)
+	router.POST("/user", CreateUser)
+
+	mockRepo.On("GetUserByUsername", "longpassword").Return(models.User{}, gorm.ErrRecordNotFound)
+
+	// 한글 25자는 75바이트
+	reqBody := models.CreateUserRequest{
+		Username: "longpassword",
+		Email:    "long@example.com",
+		Password: strings.Repeat("가", 25),
+		Role:     "USER",
+	}
+	body, _ := json.Marshal(reqBody)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/user", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response struct {
+		Violations []password.Violation `json:"violations"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	if assert.Len(t, response.Violations, 1) {
+		assert.Equal(t, password.RuleMaxLength, response.Violations[0].Rule)
+	}
+
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
 // TestUpdateUser는 UpdateUser 핸들러를 테스트합니다.
 func TestUpdateUser(t *testing.T) {
 	router, mockRepo := setupTest(t)
+	router.Use(withAuthUser(testAdmin))
 	router.PUT("/user/:id", UpdateUser)
 	
 	// 모의 데이터 설정
 	user := models.User{ID: 1, Username: "user1", Email: "user1@example.com", Role: "USER"}
-	mockRepo.On("GetUserByID", int64(1)).Return(user, nil)
+	mockRepo.On("GetTenantUserByID", repository.AllTenants, int64(1)).Return(user, nil)
 	mockRepo.On("UpdateUser", mock.AnythingOfType("*models.User")).Return(nil)
 	
 	// 요청 데이터 생성
@@ -216,11 +368,12 @@ func TestUpdateUser(t *testing.T) {
 // TestDeleteUser는 DeleteUser 핸들러를 테스트합니다.
 func TestDeleteUser(t *testing.T) {
 	router, mockRepo := setupTest(t)
+	router.Use(withAuthUser(testAdmin))
 	router.DELETE("/user/:id", DeleteUser)
 	
 	// 모의 데이터 설정
 	user := models.User{ID: 1, Username: "user1", Email: "user1@example.com", Role: "USER"}
-	mockRepo.On("GetUserByID", int64(1)).Return(user, nil)
+	mockRepo.On("GetTenantUserByID", repository.AllTenants, int64(1)).Return(user, nil)
 	mockRepo.On("DeleteUser", int64(1)).Return(nil)
 	
 	// 요청 실행
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// APIKeyPrefix는 API 키 ID 앞에 붙는 접두사입니다.
+const APIKeyPrefix = "gqk_"
+
+// MaxNonceLength는 서명 요청 nonce의 최대 길이입니다.
+const MaxNonceLength = 64
+
+// 서명 요청의 허용 시각 차이
+var signatureSkew = 5 * time.Minute
+
+// nonceCache는 데이터베이스에 기록한 서명 요청 nonce의 메모리 캐시입니다.
+// 이미 본 nonce는 데이터베이스를 조회하지 않고 거부하며, 허용 시각 차이가 지난 요청은
+// 시각 검사에서 거부되므로 그동안만 기억합니다.
+type nonceCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // API 키 ID와 nonce → 기억할 기한
+	swept   time.Time
+}
+
+var nonces = &nonceCache{entries: map[string]time.Time{}}
+
+// InitSignatures는 설정에서 서명 요청의 허용 시각 차이를 구성합니다.
+func InitSignatures(cfg *config.Config) {
+	signatureSkew = cfg.SignatureClockSkew
+}
+
+// GenerateAPIKey는 접두사가 붙은 API 키 ID와 서명에 사용할 비밀 값을 생성합니다.
+func GenerateAPIKey() (string, string, error) {
+	id, err := RandomHex(12)
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := RandomHex(32)
+	if err != nil {
+		return "", "", err
+	}
+	return APIKeyPrefix + id, secret, nil
+}
+
+// WithinSignatureSkew는 서명 시각이 현재 시각에서 허용 시각 차이 안에 있는지 확인합니다.
+func WithinSignatureSkew(signedAt time.Time) bool {
+	diff := time.Since(signedAt)
+	return diff <= signatureSkew && diff >= -signatureSkew
+}
+
+// UseNonce는 API 키의 nonce를 사용 처리합니다. 허용 시각 차이 안에서 이미 사용된 nonce이면 false를 반환합니다.
+// 사용 기록은 데이터베이스에 저장하므로 여러 인스턴스에서도 같은 요청은 한 번만 받아들여집니다.
+func UseNonce(keyID, nonce string, signedAt time.Time) (bool, error) {
+	key := keyID + "\n" + nonce
+	if nonces.seen(key) {
+		return false, nil
+	}
+
+	until := signedAt.Add(signatureSkew)
+	first, err := repository.RecordAPINonce(keyID, nonce, until)
+	if err != nil {
+		return false, err
+	}
+	nonces.use(key, until)
+	return first, nil
+}
+
+// seen은 key를 아직 기억 중인지 확인합니다.
+// 허용 시각 차이마다 한 번씩 만료된 항목과 데이터베이스의 만료된 기록을 정리합니다.
+func (nc *nonceCache) seen(key string) bool {
+	now := time.Now()
+
+	nc.mu.Lock()
+	sweep := now.Sub(nc.swept) >= signatureSkew
+	if sweep {
+		for k, expiresAt := range nc.entries {
+			if !expiresAt.After(now) {
+				delete(nc.entries, k)
+			}
+		}
+		nc.swept = now
+	}
+	expiresAt, ok := nc.entries[key]
+	nc.mu.Unlock()
+
+	if sweep {
+		if err := repository.DeleteExpiredAPINonces(now); err != nil {
+			log.Printf("만료된 nonce 기록 삭제 실패: %v", err)
+		}
+	}
+	return ok && expiresAt.After(now)
+}
+
+// use는 key를 until까지 기억합니다. 아직 기억 중인 key이면 false를 반환합니다.
+func (nc *nonceCache) use(key string, until time.Time) bool {
+	now := time.Now()
+
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if expiresAt, ok := nc.entries[key]; ok && expiresAt.After(now) {
+		return false
+	}
+	nc.entries[key] = until
+	return true
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	keyID, secret, err := GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(keyID, APIKeyPrefix))
+	assert.Len(t, secret, 64)
+
+	other, _, err := GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, keyID, other)
+}
+
+func TestSignatureSkewAndNonce(t *testing.T) {
+	now := time.Now()
+	assert.True(t, WithinSignatureSkew(now))
+	assert.True(t, WithinSignatureSkew(now.Add(-signatureSkew+time.Second)))
+	assert.True(t, WithinSignatureSkew(now.Add(signatureSkew-time.Second)))
+	assert.False(t, WithinSignatureSkew(now.Add(-signatureSkew-time.Second)))
+	assert.False(t, WithinSignatureSkew(now.Add(signatureSkew+time.Second)))
+
+	// 여러 인스턴스가 함께 쓰는 데이터베이스 대신 사용하는 기록
+	recorded := map[string]time.Time{}
+	originalRecord, originalDelete := repository.RecordAPINonce, repository.DeleteExpiredAPINonces
+	repository.RecordAPINonce = func(keyID, nonce string, expiresAt time.Time) (bool, error) {
+		key := keyID + "\n" + nonce
+		if existing, ok := recorded[key]; ok && existing.After(time.Now()) {
+			return false, nil
+		}
+		recorded[key] = expiresAt
+		return true, nil
+	}
+	repository.DeleteExpiredAPINonces = func(now time.Time) error { return nil }
+	t.Cleanup(func() {
+		repository.RecordAPINonce = originalRecord
+		repository.DeleteExpiredAPINonces = originalDelete
+	})
+
+	// use는 UseNonce의 결과를 오류 없이 반환합니다.
+	use := func(keyID, nonce string) bool {
+		first, err := UseNonce(keyID, nonce, now)
+		if err != nil {
+			t.Fatalf("예상하지 못한 오류: %v", err)
+		}
+		return first
+	}
+
+	// 같은 키의 같은 nonce는 한 번만 사용 가능, 다른 키는 같은 nonce 사용 가능
+	assert.True(t, use("gqk_test1", "nonce-1"))
+	assert.False(t, use("gqk_test1", "nonce-1"))
+	assert.True(t, use("gqk_test2", "nonce-1"))
+
+	// 캐시에 없는 다른 인스턴스에서도 데이터베이스 기록으로 거부
+	nonces.mu.Lock()
+	nonces.entries = map[string]time.Time{}
+	nonces.mu.Unlock()
+	assert.False(t, use("gqk_test1", "nonce-1"))
+
+	// 캐시에서 거부할 수 있는 nonce는 데이터베이스를 조회하지 않음
+	repository.RecordAPINonce = func(keyID, nonce string, expiresAt time.Time) (bool, error) {
+		return false, errors.New("조회하지 않아야 함")
+	}
+	assert.False(t, use("gqk_test1", "nonce-1"))
+
+	// 데이터베이스 오류는 호출자에게 전달
+	_, err := UseNonce("gqk_test1", "nonce-2", now)
+	assert.Error(t, err)
+
+	// 기억할 기한이 지난 항목은 다시 사용 가능
+	assert.True(t, nonces.use("expired", now.Add(-time.Second)))
+	assert.True(t, nonces.use("expired", now.Add(time.Minute)))
+}
@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 토큰 관련 오류
+var (
+	ErrInvalidToken     = errors.New("유효하지 않은 토큰입니다")
+	ErrJWTNotConfigured = errors.New("JWT 설정이 초기화되지 않았습니다")
+)
+
+// AccessClaims는 액세스 토큰에 담기는 클레임입니다.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	// SessionID는 토큰이 속한 로그인 세션(리프레시 토큰 계열)의 ID입니다.
+	SessionID string `json:"sid,omitempty"`
+	// Actor는 관리자가 다른 사용자로 가장하여 발급받은 토큰에서 실제로 요청하는 관리자입니다.
+	Actor *ActorClaim `json:"act,omitempty"`
+}
+
+// ActorClaim은 가장 토큰의 act 클레임입니다 (RFC 8693).
+type ActorClaim struct {
+	Subject string `json:"sub"`
+}
+
+// UserID는 sub 클레임에 담긴 사용자 ID를 반환합니다.
+func (c *AccessClaims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// IsImpersonation은 관리자가 다른 사용자로 가장하여 발급받은 토큰인지 확인합니다.
+func (c *AccessClaims) IsImpersonation() bool {
+	return c.Actor != nil
+}
+
+// ActorID는 가장 토큰의 act 클레임에 담긴 실제 사용자 ID를 반환합니다.
+func (c *AccessClaims) ActorID() (int64, error) {
+	if c.Actor == nil {
+		return 0, ErrInvalidToken
+	}
+	id, err := strconv.ParseInt(c.Actor.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// ChallengeClaims는 추가 인증 단계(예: MFA)에 사용하는 단기 챌린지 토큰의 클레임입니다.
+// 대상(aud)이 액세스 토큰과 다르므로 액세스 토큰으로 사용할 수 없습니다.
+type ChallengeClaims struct {
+	jwt.RegisteredClaims
+	Purpose string `json:"purpose"`
+}
+
+// UserID는 sub 클레임에 담긴 사용자 ID를 반환합니다.
+func (c *ChallengeClaims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+// jwtKeys는 토큰 서명과 검증에 사용하는 키와 설정을 보관합니다.
+type jwtKeys struct {
+	method     jwt.SigningMethod
+	signKey    interface{}
+	verifyKey  interface{}
+	keyID      string
+	issuer     string
+	audience   string
+	ttl        time.Duration
+	refreshTTL time.Duration
+	// 가장 토큰 유효 기간. 사용자 단위 폐기 기록이 가장 토큰도 덮도록 액세스 토큰 유효 기간을 넘지 않습니다.
+	impersonationTTL time.Duration
+}
+
+var keys *jwtKeys
+
+// InitJWT는 설정에 지정된 알고리즘과 키로 토큰 서명기를 초기화합니다.
+func InitJWT(cfg *config.Config) error {
+	k := &jwtKeys{
+		keyID:      cfg.JWTKeyID,
+		issuer:     cfg.JWTIssuer,
+		audience:   cfg.JWTAudience,
+		ttl:        cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+
+		impersonationTTL: cfg.ImpersonationTTL,
+	}
+	if k.impersonationTTL <= 0 || k.impersonationTTL > k.ttl {
+		k.impersonationTTL = k.ttl
+	}
+
+	switch cfg.JWTAlgorithm {
+	case "HS256":
+		secret := []byte(cfg.JWTSecret)
+		if len(secret) == 0 {
+			log.Println("경고: JWT_SECRET이 설정되지 않아 임시 비밀 키를 생성합니다")
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return err
+			}
+		}
+		k.method = jwt.SigningMethodHS256
+		k.signKey = secret
+		k.verifyKey = secret
+	case "RS256":
+		var privateKey *rsa.PrivateKey
+		if cfg.JWTPrivateKeyFile != "" {
+			pem, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+			if err != nil {
+				return fmt.Errorf("JWT 개인 키 파일 읽기 실패: %w", err)
+			}
+			if privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pem); err != nil {
+				return fmt.Errorf("RSA 개인 키 파싱 실패: %w", err)
+			}
+		} else {
+			log.Println("경고: JWT_PRIVATE_KEY_FILE이 설정되지 않아 임시 RSA 키를 생성합니다")
+			var err error
+			if privateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+				return err
+			}
+		}
+		k.method = jwt.SigningMethodRS256
+		k.signKey = privateKey
+		k.verifyKey = &privateKey.PublicKey
+	case "EDDSA":
+		var privateKey ed25519.PrivateKey
+		if cfg.JWTPrivateKeyFile != "" {
+			pem, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+			if err != nil {
+				return fmt.Errorf("JWT 개인 키 파일 읽기 실패: %w", err)
+			}
+			key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+			if err != nil {
+				return fmt.Errorf("Ed25519 개인 키 파싱 실패: %w", err)
+			}
+			privateKey = key.(ed25519.PrivateKey)
+		} else {
+			log.Println("경고: JWT_PRIVATE_KEY_FILE이 설정되지 않아 임시 Ed25519 키를 생성합니다")
+			var err error
+			if _, privateKey, err = ed25519.GenerateKey(rand.Reader); err != nil {
+				return err
+			}
+		}
+		k.method = jwt.SigningMethodEdDSA
+		k.signKey = privateKey
+		k.verifyKey = privateKey.Public()
+	default:
+		return fmt.Errorf("지원하지 않는 JWT 알고리즘입니다: %s", cfg.JWTAlgorithm)
+	}
+
+	keys = k
+	return nil
+}
+
+// GenerateAccessToken은 사용자에 대한 서명된 액세스 토큰과 만료 시각을 반환합니다.
+func GenerateAccessToken(user models.User, sessionID string) (string, time.Time, error) {
+	if keys == nil {
+		return "", time.Time{}, ErrJWTNotConfigured
+	}
+	return generateAccessToken(user, sessionID, nil, keys.ttl)
+}
+
+// GenerateImpersonationToken은 관리자 actor가 target 사용자로 가장하여 요청할 수 있는 단기 액세스 토큰을 발급합니다.
+// 토큰의 주체(sub)는 target이고 act 클레임에 actor가 기록되며, 리프레시 토큰이 없으므로 만료되면 다시 발급받아야 합니다.
+func GenerateImpersonationToken(target, actor models.User, sessionID string) (string, time.Time, error) {
+	if keys == nil {
+		return "", time.Time{}, ErrJWTNotConfigured
+	}
+	return generateAccessToken(target, sessionID, &ActorClaim{Subject: strconv.FormatInt(actor.ID, 10)}, keys.impersonationTTL)
+}
+
+// generateAccessToken은 액세스 토큰 클레임을 만들어 서명합니다.
+func generateAccessToken(user models.User, sessionID string, actor *ActorClaim, ttl time.Duration) (string, time.Time, error) {
+	jti, err := RandomHex(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    keys.issuer,
+			Subject:   strconv.FormatInt(user.ID, 10),
+			Audience:  jwt.ClaimStrings{keys.audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+		SessionID: sessionID,
+		Actor:     actor,
+	}
+
+	token := jwt.NewWithClaims(keys.method, claims)
+	token.Header["kid"] = keys.keyID
+
+	signed, err := token.SignedString(keys.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// RefreshTokenTTL은 설정된 리프레시 토큰 유효 기간을 반환합니다.
+func RefreshTokenTTL() time.Duration {
+	if keys == nil {
+		return 0
+	}
+	return keys.refreshTTL
+}
+
+// ParseAccessToken은 토큰의 서명, 만료 시각, 발급자와 대상을 검증하고 클레임을 반환합니다.
+func ParseAccessToken(tokenString string) (*AccessClaims, error) {
+	if keys == nil {
+		return nil, ErrJWTNotConfigured
+	}
+
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, _ := token.Header["kid"].(string); kid != keys.keyID {
+			return nil, ErrInvalidToken
+		}
+		return keys.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{keys.method.Alg()}),
+		jwt.WithIssuer(keys.issuer),
+		jwt.WithAudience(keys.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// GenerateChallengeToken은 특정 용도로만 사용할 수 있는 단기 챌린지 토큰을 발급합니다.
+func GenerateChallengeToken(userID int64, purpose string, ttl time.Duration) (string, time.Time, error) {
+	if keys == nil {
+		return "", time.Time{}, ErrJWTNotConfigured
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := ChallengeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    keys.issuer,
+			Subject:   strconv.FormatInt(userID, 10),
+			Audience:  jwt.ClaimStrings{keys.audience + ":" + purpose},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Purpose: purpose,
+	}
+
+	token := jwt.NewWithClaims(keys.method, claims)
+	token.Header["kid"] = keys.keyID
+
+	signed, err := token.SignedString(keys.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseChallengeToken은 챌린지 토큰을 검증하고 용도가 일치하는지 확인합니다.
+func ParseChallengeToken(tokenString, purpose string) (*ChallengeClaims, error) {
+	if keys == nil {
+		return nil, ErrJWTNotConfigured
+	}
+
+	claims := &ChallengeClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, _ := token.Header["kid"].(string); kid != keys.keyID {
+			return nil, ErrInvalidToken
+		}
+		return keys.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{keys.method.Alg()}),
+		jwt.WithIssuer(keys.issuer),
+		jwt.WithAudience(keys.audience+":"+purpose),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
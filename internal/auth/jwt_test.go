@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig는 테스트용 JWT 설정을 반환합니다.
+func testConfig(algorithm string) *config.Config {
+	return &config.Config{
+		JWTAlgorithm:   algorithm,
+		JWTSecret:      "test-secret",
+		JWTKeyID:       "test-key",
+		JWTIssuer:      "test-issuer",
+		JWTAudience:    "test-audience",
+		AccessTokenTTL: time.Minute,
+	}
+}
+
+// TestAccessTokenRoundTrip은 알고리즘별 토큰 발급과 검증을 테스트합니다.
+func TestAccessTokenRoundTrip(t *testing.T) {
+	user := models.User{ID: 42, Username: "testuser", Role: "USER"}
+
+	for _, algorithm := range []string{"HS256", "RS256", "EDDSA"} {
+		t.Run(algorithm, func(t *testing.T) {
+			assert.NoError(t, InitJWT(testConfig(algorithm)))
+
+			token, expiresAt, err := GenerateAccessToken(user, "")
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+			claims, err := ParseAccessToken(token)
+			assert.NoError(t, err)
+
+			userID, err := claims.UserID()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(42), userID)
+			assert.Equal(t, "test-issuer", claims.Issuer)
+		})
+	}
+}
+
+// TestImpersonationToken은 가장 토큰에 대상 사용자와 관리자가 함께 담기는지 테스트합니다.
+func TestImpersonationToken(t *testing.T) {
+	cfg := testConfig("HS256")
+	cfg.ImpersonationTTL = time.Hour
+	assert.NoError(t, InitJWT(cfg))
+
+	token, expiresAt, err := GenerateImpersonationToken(models.User{ID: 42}, models.User{ID: 1}, "session-1")
+	assert.NoError(t, err)
+	// 액세스 토큰 유효 기간보다 길게 설정해도 액세스 토큰 유효 기간을 넘지 않음
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	claims, err := ParseAccessToken(token)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	userID, _ := claims.UserID()
+	actorID, err := claims.ActorID()
+	assert.NoError(t, err)
+	assert.True(t, claims.IsImpersonation())
+	assert.Equal(t, int64(42), userID)
+	assert.Equal(t, int64(1), actorID)
+	assert.Equal(t, "session-1", claims.SessionID)
+
+	// 일반 액세스 토큰에는 관리자가 없음
+	token, _, _ = GenerateAccessToken(models.User{ID: 42}, "")
+	claims, _ = ParseAccessToken(token)
+	assert.False(t, claims.IsImpersonation())
+	_, err = claims.ActorID()
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+// TestParseAccessTokenRejects는 검증에 실패해야 하는 토큰을 테스트합니다.
+func TestParseAccessTokenRejects(t *testing.T) {
+	user := models.User{ID: 1}
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{
+			name: "만료된 토큰",
+			token: func() string {
+				cfg := testConfig("HS256")
+				cfg.AccessTokenTTL = -time.Minute
+				InitJWT(cfg)
+				token, _, _ := GenerateAccessToken(user, "")
+				InitJWT(testConfig("HS256"))
+				return token
+			},
+		},
+		{
+			name: "다른 대상(aud)의 토큰",
+			token: func() string {
+				cfg := testConfig("HS256")
+				cfg.JWTAudience = "other-audience"
+				InitJWT(cfg)
+				token, _, _ := GenerateAccessToken(user, "")
+				InitJWT(testConfig("HS256"))
+				return token
+			},
+		},
+		{
+			name: "다른 키로 서명된 토큰",
+			token: func() string {
+				cfg := testConfig("HS256")
+				cfg.JWTSecret = "other-secret"
+				InitJWT(cfg)
+				token, _, _ := GenerateAccessToken(user, "")
+				InitJWT(testConfig("HS256"))
+				return token
+			},
+		},
+		{
+			name: "변조된 토큰",
+			token: func() string {
+				InitJWT(testConfig("HS256"))
+				token, _, _ := GenerateAccessToken(user, "")
+				parts := strings.Split(token, ".")
+				return parts[0] + "." + parts[1] + "x." + parts[2]
+			},
+		},
+		{
+			name: "형식이 잘못된 토큰",
+			token: func() string {
+				InitJWT(testConfig("HS256"))
+				return "admin-token"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAccessToken(tt.token())
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+// TestInitJWTUnsupportedAlgorithm은 지원하지 않는 알고리즘 설정을 테스트합니다.
+func TestInitJWTUnsupportedAlgorithm(t *testing.T) {
+	assert.Error(t, InitJWT(testConfig("none")))
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// lockoutPolicy는 연속 로그인 실패에 따른 계정 잠금 정책입니다.
+type lockoutPolicy struct {
+	threshold  int64
+	baseWindow time.Duration
+	maxWindow  time.Duration
+}
+
+var lockout = lockoutPolicy{
+	threshold:  5,
+	baseWindow: time.Minute,
+	maxWindow:  time.Hour,
+}
+
+// InitLockout은 설정에서 계정 잠금 정책을 구성합니다.
+func InitLockout(cfg *config.Config) {
+	lockout = lockoutPolicy{
+		threshold:  int64(cfg.LockoutThreshold),
+		baseWindow: cfg.LockoutBaseWindow,
+		maxWindow:  cfg.LockoutMaxWindow,
+	}
+}
+
+// LockoutWindow는 연속 실패 횟수에 따른 잠금 기간을 반환합니다.
+// 임계값에 도달하면 기본 기간만큼 잠기고, 이후 실패할 때마다 두 배씩 늘어나며 최대 기간을 넘지 않습니다.
+func LockoutWindow(failures int64) time.Duration {
+	if lockout.threshold <= 0 || failures < lockout.threshold {
+		return 0
+	}
+
+	window := lockout.baseWindow
+	for i := lockout.threshold; i < failures && window < lockout.maxWindow; i++ {
+		window *= 2
+	}
+	if window > lockout.maxWindow {
+		window = lockout.maxWindow
+	}
+	return window
+}
+
+// CheckLockout은 로그인 기록에서 연속 실패를 계산하여 계정이 잠겨 있는지 확인합니다.
+// 잠겨 있으면 잠금이 풀리는 시각과 true를 반환합니다.
+func CheckLockout(user models.User) (time.Time, bool, error) {
+	if lockout.threshold <= 0 {
+		return time.Time{}, false, nil
+	}
+
+	failures, lastFailure, err := repository.GetLoginFailureStreak(user.ID, user.LockoutResetAt)
+	if err != nil || lastFailure == nil {
+		return time.Time{}, false, err
+	}
+
+	window := LockoutWindow(failures)
+	if window == 0 {
+		return time.Time{}, false, nil
+	}
+
+	lockedUntil := lastFailure.Add(window)
+	return lockedUntil, time.Now().Before(lockedUntil), nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLockoutWindow는 연속 실패 횟수에 따른 지수적 잠금 기간을 테스트합니다.
+func TestLockoutWindow(t *testing.T) {
+	InitLockout(&config.Config{
+		LockoutThreshold:  3,
+		LockoutBaseWindow: time.Minute,
+		LockoutMaxWindow:  10 * time.Minute,
+	})
+
+	tests := []struct {
+		failures int64
+		expected time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Minute},
+		{4, 2 * time.Minute},
+		{5, 4 * time.Minute},
+		{6, 8 * time.Minute},
+		{7, 10 * time.Minute},
+		{50, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, LockoutWindow(tt.failures), "failures=%d", tt.failures)
+	}
+}
+
+// TestCheckLockout은 로그인 기록 기반 잠금 여부 판단을 테스트합니다.
+func TestCheckLockout(t *testing.T) {
+	InitLockout(&config.Config{
+		LockoutThreshold:  3,
+		LockoutBaseWindow: time.Minute,
+		LockoutMaxWindow:  time.Hour,
+	})
+
+	var failures int64
+	var lastFailure *time.Time
+	original := repository.GetLoginFailureStreak
+	repository.GetLoginFailureStreak = func(userID int64, since *time.Time) (int64, *time.Time, error) {
+		return failures, lastFailure, nil
+	}
+	t.Cleanup(func() {
+		repository.GetLoginFailureStreak = original
+	})
+
+	user := models.User{ID: 1}
+
+	// 실패 기록 없음
+	_, locked, err := CheckLockout(user)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	// 임계값 도달 직후 잠김
+	recent := time.Now().Add(-30 * time.Second)
+	failures, lastFailure = 3, &recent
+	until, locked, err := CheckLockout(user)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	assert.WithinDuration(t, recent.Add(time.Minute), until, time.Millisecond)
+
+	// 잠금 기간이 지나면 해제
+	old := time.Now().Add(-90 * time.Second)
+	lastFailure = &old
+	_, locked, _ = CheckLockout(user)
+	assert.False(t, locked)
+
+	// 실패가 더 쌓이면 잠금 기간이 늘어남
+	failures = 4
+	_, locked, _ = CheckLockout(user)
+	assert.True(t, locked)
+
+	// 임계값이 0이면 잠금 비활성화
+	InitLockout(&config.Config{})
+	_, locked, _ = CheckLockout(user)
+	assert.False(t, locked)
+}
@@ -0,0 +1,42 @@
+package auth
+
+import "strings"
+
+// PersonalAccessTokenPrefix는 개인 액세스 토큰 앞에 붙는 접두사입니다.
+// JWT 액세스 토큰과 구분하고, 유출 탐지 도구가 토큰을 식별할 수 있게 합니다.
+const PersonalAccessTokenPrefix = "gqp_"
+
+// 개인 액세스 토큰 권한 범위
+const (
+	ScopeUsersRead  = "users:read"
+	ScopeUsersWrite = "users:write"
+	ScopeLoginsRead = "logins:read"
+)
+
+// Scopes는 개인 액세스 토큰에 부여할 수 있는 모든 권한 범위입니다.
+var Scopes = []string{ScopeUsersRead, ScopeUsersWrite, ScopeLoginsRead}
+
+// ValidScope는 지원하는 권한 범위인지 확인합니다.
+func ValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratePersonalAccessToken은 접두사가 붙은 개인 액세스 토큰과 저장용 해시를 생성합니다.
+func GeneratePersonalAccessToken() (string, string, error) {
+	token, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	token = PersonalAccessTokenPrefix + token
+	return token, HashToken(token), nil
+}
+
+// IsPersonalAccessToken은 토큰이 개인 액세스 토큰 형식인지 확인합니다.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// 권한 이름
+const (
+	PermUsersRead        = "users:read"
+	PermUsersCreate      = "users:create"
+	PermUsersUpdate      = "users:update"
+	PermUsersDelete      = "users:delete"
+	PermUsersUnlock      = "users:unlock"
+	PermUsersImpersonate = "users:impersonate"
+	PermSessionsRevoke   = "sessions:revoke"
+	PermLoginsRead       = "logins:read"
+	PermRolesManage      = "roles:manage"
+	PermOrgsManage       = "organizations:manage"
+	PermGroupsManage     = "groups:manage"
+	PermClientsManage    = "clients:manage"
+	PermSCIMManage       = "scim:manage"
+)
+
+// 기본 역할 이름
+const (
+	RoleSuperAdmin = "SUPER_ADMIN" // 모든 조직에 대한 모든 권한
+	RoleAdmin      = "ADMIN"       // 자신의 조직 안에서 사용자 관리
+	RoleUser       = "USER"        // 본인 정보만 조회 및 수정 가능
+)
+
+// Permissions는 코드에서 정의한 모든 권한입니다.
+var Permissions = []models.Permission{
+	{Name: PermUsersRead, Description: "모든 사용자 정보 조회"},
+	{Name: PermUsersCreate, Description: "사용자 생성"},
+	{Name: PermUsersUpdate, Description: "모든 사용자 정보 수정"},
+	{Name: PermUsersDelete, Description: "사용자 삭제"},
+	{Name: PermUsersUnlock, Description: "잠긴 계정 잠금 해제"},
+	{Name: PermUsersImpersonate, Description: "다른 사용자로 가장하여 API 호출"},
+	{Name: PermSessionsRevoke, Description: "사용자 세션 종료"},
+	{Name: PermLoginsRead, Description: "로그인 기록 조회"},
+	{Name: PermRolesManage, Description: "역할 생성, 수정, 삭제 및 사용자 역할 지정"},
+	{Name: PermOrgsManage, Description: "조직 관리 및 모든 조직의 사용자 관리"},
+	{Name: PermGroupsManage, Description: "그룹 생성, 수정, 삭제 및 구성원 관리"},
+	{Name: PermClientsManage, Description: "OAuth2 클라이언트 애플리케이션 등록 및 삭제"},
+	{Name: PermSCIMManage, Description: "SCIM 프로비저닝 토큰 발급 및 폐기"},
+}
+
+// defaultRoles는 서버 시작 시 없으면 생성되는 역할입니다.
+// Builtin 역할의 권한은 변경할 수 없으며 서버 시작 시 항상 이 목록으로 맞춰집니다.
+var defaultRoles = []models.Role{
+	{Name: RoleSuperAdmin, Description: "전체 관리자", Builtin: true, Permissions: permissionsExcept()},
+	{Name: RoleAdmin, Description: "조직 관리자", Builtin: true, Permissions: permissionsExcept(PermRolesManage, PermOrgsManage, PermClientsManage, PermSCIMManage)},
+	{Name: RoleUser, Description: "일반 사용자", Builtin: true},
+	{Name: "SUPPORT", Description: "고객 지원", Permissions: []models.Permission{
+		{Name: PermUsersRead}, {Name: PermUsersUnlock}, {Name: PermSessionsRevoke}, {Name: PermUsersImpersonate},
+	}},
+	{Name: "AUDITOR", Description: "감사", Permissions: []models.Permission{
+		{Name: PermUsersRead}, {Name: PermLoginsRead},
+	}},
+}
+
+// roleCache는 역할별 권한 목록의 메모리 캐시입니다.
+// 여러 인스턴스가 같은 데이터베이스를 사용할 수 있도록 ttl마다 데이터베이스에서 다시 읽어옵니다.
+type roleCache struct {
+	mu       sync.RWMutex
+	roles    map[string]map[string]bool // 역할 이름 → 권한 집합
+	loadedAt time.Time
+	ttl      time.Duration
+	loaded   bool
+
+	reloadMu sync.Mutex
+}
+
+// 데이터베이스에서 읽기 전에는 기본 역할만 사용
+var roles = &roleCache{roles: builtinRoles()}
+
+// permissionsExcept는 excluded를 제외한 모든 권한을 반환합니다.
+func permissionsExcept(excluded ...string) []models.Permission {
+	skip := map[string]bool{}
+	for _, name := range excluded {
+		skip[name] = true
+	}
+
+	var result []models.Permission
+	for _, p := range Permissions {
+		if !skip[p.Name] {
+			result = append(result, models.Permission{Name: p.Name})
+		}
+	}
+	return result
+}
+
+// builtinRoles는 기본 역할의 권한 집합을 반환합니다.
+func builtinRoles() map[string]map[string]bool {
+	result := map[string]map[string]bool{}
+	for _, r := range defaultRoles {
+		set := map[string]bool{}
+		for _, p := range r.Permissions {
+			set[p.Name] = true
+		}
+		result[r.Name] = set
+	}
+	return result
+}
+
+// InitRBAC는 권한과 기본 역할을 데이터베이스에 등록하고 역할 캐시를 초기화합니다.
+func InitRBAC(cfg *config.Config) error {
+	if err := repository.SeedRBAC(Permissions, defaultRoles); err != nil {
+		return err
+	}
+	roles.ttl = cfg.RBACCacheTTL
+	roles.loaded = true
+	return roles.reload()
+}
+
+// ReloadRoles는 역할이 변경된 후 캐시를 즉시 다시 읽어옵니다.
+func ReloadRoles() error {
+	if !roles.loaded {
+		return nil
+	}
+	return roles.reload()
+}
+
+// reload는 데이터베이스에서 역할별 권한 목록을 읽어 캐시를 교체합니다.
+func (rc *roleCache) reload() error {
+	list, err := repository.GetRoles()
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[string]map[string]bool, len(list))
+	for _, r := range list {
+		set := make(map[string]bool, len(r.Permissions))
+		for _, p := range r.Permissions {
+			set[p.Name] = true
+		}
+		loaded[r.Name] = set
+	}
+
+	rc.mu.Lock()
+	rc.roles = loaded
+	rc.loadedAt = time.Now()
+	rc.mu.Unlock()
+	return nil
+}
+
+// refreshIfStale은 캐시가 오래되었으면 데이터베이스에서 다시 읽어옵니다.
+func (rc *roleCache) refreshIfStale() {
+	if !rc.loaded {
+		return
+	}
+
+	rc.mu.RLock()
+	stale := time.Since(rc.loadedAt) > rc.ttl
+	rc.mu.RUnlock()
+	if !stale || !rc.reloadMu.TryLock() {
+		return
+	}
+	defer rc.reloadMu.Unlock()
+
+	if err := rc.reload(); err != nil {
+		log.Printf("역할 권한 목록 갱신 실패: %v", err)
+	}
+}
+
+// HasPermission은 사용자의 역할이나 사용자가 속한 그룹의 역할에 권한이 부여되어 있는지 확인합니다.
+func HasPermission(user models.User, permission string) bool {
+	if RoleHasPermission(user.Role, permission) {
+		return true
+	}
+	for _, g := range user.Groups {
+		if g.Role != "" && RoleHasPermission(g.Role, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleHasPermission은 역할에 권한이 부여되어 있는지 확인합니다.
+func RoleHasPermission(role, permission string) bool {
+	if allowed, ok := serviceRoleHasPermission(role, permission); ok {
+		return allowed
+	}
+
+	roles.refreshIfStale()
+
+	roles.mu.RLock()
+	defer roles.mu.RUnlock()
+	return roles.roles[role][permission]
+}
+
+// RoleExists는 역할이 등록되어 있는지 확인합니다.
+func RoleExists(name string) bool {
+	roles.refreshIfStale()
+
+	roles.mu.RLock()
+	defer roles.mu.RUnlock()
+	_, ok := roles.roles[name]
+	return ok
+}
+
+// ValidPermission은 코드에서 정의한 권한인지 확인합니다.
+func ValidPermission(name string) bool {
+	for _, p := range Permissions {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
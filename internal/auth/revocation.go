@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// revocationCache는 데이터베이스에 저장된 폐기 목록의 메모리 캐시입니다.
+// 여러 인스턴스가 같은 데이터베이스를 사용할 수 있도록 ttl마다 데이터베이스에서 다시 읽어옵니다.
+type revocationCache struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // jti → 폐기 기록 만료 시각
+	sessions map[string]time.Time // 세션 ID → 폐기 기록 만료 시각
+	users    map[int64]time.Time  // 사용자 ID → 사용자 단위 폐기 시각
+	loadedAt time.Time
+	ttl      time.Duration
+	loaded   bool
+
+	reloadMu sync.Mutex
+}
+
+var revocations = &revocationCache{
+	tokens:   map[string]time.Time{},
+	sessions: map[string]time.Time{},
+	users:    map[int64]time.Time{},
+}
+
+// InitRevocations는 데이터베이스에서 폐기 목록을 읽어 캐시를 초기화합니다.
+func InitRevocations(cfg *config.Config) error {
+	revocations.ttl = cfg.RevocationCacheTTL
+	revocations.loaded = true
+	return revocations.reload()
+}
+
+// reload는 만료된 폐기 기록을 정리하고 유효한 기록을 캐시에 병합합니다.
+func (rc *revocationCache) reload() error {
+	now := time.Now()
+	if err := repository.DeleteExpiredTokenRevocations(now); err != nil {
+		log.Printf("만료된 토큰 폐기 기록 삭제 실패: %v", err)
+	}
+
+	list, err := repository.GetActiveTokenRevocations(now)
+	if err != nil {
+		return err
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, r := range list {
+		rc.add(r)
+	}
+	for jti, expiresAt := range rc.tokens {
+		if !expiresAt.After(now) {
+			delete(rc.tokens, jti)
+		}
+	}
+	for sessionID, expiresAt := range rc.sessions {
+		if !expiresAt.After(now) {
+			delete(rc.sessions, sessionID)
+		}
+	}
+	if keys != nil {
+		for userID, revokedAt := range rc.users {
+			if revokedAt.Add(keys.ttl + time.Second).Before(now) {
+				delete(rc.users, userID)
+			}
+		}
+	}
+	rc.loadedAt = now
+	return nil
+}
+
+// add는 폐기 기록 하나를 캐시에 추가합니다. 호출자가 잠금을 보유해야 합니다.
+func (rc *revocationCache) add(r models.TokenRevocation) {
+	if r.JTI != "" {
+		rc.tokens[r.JTI] = r.ExpiresAt
+		return
+	}
+	if r.SessionID != "" {
+		rc.sessions[r.SessionID] = r.ExpiresAt
+		return
+	}
+	if current, ok := rc.users[r.UserID]; !ok || r.RevokedAt.After(current) {
+		rc.users[r.UserID] = r.RevokedAt
+	}
+}
+
+// refreshIfStale은 캐시가 오래되었으면 데이터베이스에서 다시 읽어옵니다.
+func (rc *revocationCache) refreshIfStale() {
+	if !rc.loaded {
+		return
+	}
+
+	rc.mu.RLock()
+	stale := time.Since(rc.loadedAt) > rc.ttl
+	rc.mu.RUnlock()
+	if !stale || !rc.reloadMu.TryLock() {
+		return
+	}
+	defer rc.reloadMu.Unlock()
+
+	if err := rc.reload(); err != nil {
+		log.Printf("토큰 폐기 목록 갱신 실패: %v", err)
+	}
+}
+
+// store는 폐기 기록을 데이터베이스에 저장하고 캐시에 반영합니다.
+func (rc *revocationCache) store(r models.TokenRevocation) error {
+	if err := repository.CreateTokenRevocation(&r); err != nil {
+		return err
+	}
+
+	rc.mu.Lock()
+	rc.add(r)
+	rc.mu.Unlock()
+	return nil
+}
+
+// RevokeToken은 하나의 액세스 토큰을 만료 시각까지 폐기합니다.
+func RevokeToken(claims *AccessClaims) error {
+	userID, err := claims.UserID()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now()
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	return revocations.store(models.TokenRevocation{
+		JTI:       claims.ID,
+		UserID:    userID,
+		RevokedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// RevokeSession은 세션에서 발급된 모든 액세스 토큰을 폐기합니다.
+// 리프레시 토큰과 세션 기록은 호출자가 repository.RevokeRefreshTokenFamily로 폐기해야 합니다.
+func RevokeSession(userID int64, sessionID string) error {
+	now := time.Now()
+	var ttl time.Duration
+	if keys != nil {
+		ttl = keys.ttl
+	}
+
+	return revocations.store(models.TokenRevocation{
+		SessionID: sessionID,
+		UserID:    userID,
+		RevokedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// RevokeAllUserTokens는 사용자에게 지금까지 발급된 모든 액세스 토큰과 리프레시 토큰을 폐기합니다.
+// 사용자가 가장하여 만든 세션의 토큰도 함께 폐기됩니다.
+func RevokeAllUserTokens(userID int64) error {
+	sessions, err := repository.RevokeUserRefreshTokens(userID)
+	if err != nil {
+		return err
+	}
+
+	// iat는 초 단위라 폐기와 같은 초에 발급된 토큰은 발급 시각으로 구분할 수 없으므로 종료한 세션 단위로도 폐기합니다.
+	for _, session := range sessions {
+		if err := RevokeSession(session.UserID, session.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	// 폐기한 뒤 같은 초에 새로 로그인하여 발급된 토큰이 폐기되지 않도록 사용자 단위 폐기 시각은 초 단위로 자릅니다.
+	revokedAt := time.Now().Truncate(time.Second)
+	var ttl time.Duration
+	if keys != nil {
+		ttl = keys.ttl
+	}
+
+	return revocations.store(models.TokenRevocation{
+		UserID:    userID,
+		RevokedAt: revokedAt,
+		ExpiresAt: revokedAt.Add(ttl + time.Second),
+	})
+}
+
+// IsRevoked는 액세스 토큰이 폐기되었는지 확인합니다.
+func IsRevoked(claims *AccessClaims) bool {
+	revocations.refreshIfStale()
+
+	revocations.mu.RLock()
+	defer revocations.mu.RUnlock()
+
+	if claims.ID != "" {
+		if _, ok := revocations.tokens[claims.ID]; ok {
+			return true
+		}
+	}
+	if claims.SessionID != "" {
+		if _, ok := revocations.sessions[claims.SessionID]; ok {
+			return true
+		}
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return true
+	}
+	if revocations.revokedUser(userID, claims) {
+		return true
+	}
+
+	// 가장 토큰은 가장한 관리자의 세션이 모두 종료되어도 폐기
+	if claims.IsImpersonation() {
+		actorID, err := claims.ActorID()
+		if err != nil {
+			return true
+		}
+		return revocations.revokedUser(actorID, claims)
+	}
+	return false
+}
+
+// revokedUser는 토큰이 발급된 뒤 사용자 단위 폐기가 있었는지 확인합니다. 호출자가 잠금을 보유해야 합니다.
+func (rc *revocationCache) revokedUser(userID int64, claims *AccessClaims) bool {
+	revokedAt, ok := rc.users[userID]
+	if !ok {
+		return false
+	}
+	return claims.IssuedAt == nil || claims.IssuedAt.Time.Before(revokedAt)
+}
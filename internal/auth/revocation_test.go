@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupRevocationTest는 폐기 기록 저장소를 메모리 저장소로 대체합니다.
+func setupRevocationTest(t *testing.T) *[]models.TokenRevocation {
+	stored := &[]models.TokenRevocation{}
+
+	originalCreate := repository.CreateTokenRevocation
+	originalRevokeRefresh := repository.RevokeUserRefreshTokens
+	repository.CreateTokenRevocation = func(r *models.TokenRevocation) error {
+		*stored = append(*stored, *r)
+		return nil
+	}
+	repository.RevokeUserRefreshTokens = func(userID int64) ([]models.Session, error) {
+		return nil, nil
+	}
+	t.Cleanup(func() {
+		repository.CreateTokenRevocation = originalCreate
+		repository.RevokeUserRefreshTokens = originalRevokeRefresh
+	})
+
+	InitJWT(testConfig("HS256"))
+	return stored
+}
+
+// TestRevokeToken은 단일 토큰 폐기를 테스트합니다.
+func TestRevokeToken(t *testing.T) {
+	stored := setupRevocationTest(t)
+
+	token, _, _ := GenerateAccessToken(models.User{ID: 10}, "")
+	other, _, _ := GenerateAccessToken(models.User{ID: 10}, "")
+	claims, _ := ParseAccessToken(token)
+	otherClaims, _ := ParseAccessToken(other)
+
+	assert.False(t, IsRevoked(claims))
+	assert.NoError(t, RevokeToken(claims))
+
+	assert.True(t, IsRevoked(claims))
+	assert.False(t, IsRevoked(otherClaims))
+	assert.Len(t, *stored, 1)
+	assert.Equal(t, claims.ID, (*stored)[0].JTI)
+}
+
+// TestRevokeSession은 세션 단위 토큰 폐기를 테스트합니다.
+func TestRevokeSession(t *testing.T) {
+	stored := setupRevocationTest(t)
+
+	token, _, _ := GenerateAccessToken(models.User{ID: 15}, "session-a")
+	sameSession, _, _ := GenerateAccessToken(models.User{ID: 15}, "session-a")
+	otherSession, _, _ := GenerateAccessToken(models.User{ID: 15}, "session-b")
+	claims, _ := ParseAccessToken(token)
+	sameClaims, _ := ParseAccessToken(sameSession)
+	otherClaims, _ := ParseAccessToken(otherSession)
+
+	assert.NoError(t, RevokeSession(15, "session-a"))
+
+	assert.True(t, IsRevoked(claims))
+	assert.True(t, IsRevoked(sameClaims))
+	assert.False(t, IsRevoked(otherClaims))
+	if assert.Len(t, *stored, 1) {
+		assert.Equal(t, "session-a", (*stored)[0].SessionID)
+		assert.Empty(t, (*stored)[0].JTI)
+	}
+}
+
+// TestRevokeAllUserTokens는 사용자 단위 토큰 폐기를 테스트합니다.
+func TestRevokeAllUserTokens(t *testing.T) {
+	setupRevocationTest(t)
+
+	issuedBefore := &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:  "20",
+		IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	}}
+	otherUser := &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:  "21",
+		IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	}}
+
+	assert.NoError(t, RevokeAllUserTokens(20))
+
+	issuedAfter := &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:  "20",
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}}
+
+	assert.True(t, IsRevoked(issuedBefore))
+	assert.False(t, IsRevoked(issuedAfter))
+	assert.False(t, IsRevoked(otherUser))
+}
+
+// TestRevokeAllUserTokensSameSecond는 폐기와 같은 초에 발급된 세션 토큰도 폐기되는지 테스트합니다.
+func TestRevokeAllUserTokensSameSecond(t *testing.T) {
+	setupRevocationTest(t)
+	repository.RevokeUserRefreshTokens = func(userID int64) ([]models.Session, error) {
+		return []models.Session{{UserID: userID, FamilyID: "session-same-second"}}, nil
+	}
+
+	// 발급과 폐기가 같은 초에 일어나도록 다음 초가 시작될 때까지 대기
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+
+	token, _, _ := GenerateAccessToken(models.User{ID: 25}, "session-same-second")
+	claims, _ := ParseAccessToken(token)
+	assert.NoError(t, RevokeAllUserTokens(25))
+	assert.Equal(t, time.Now().Unix(), claims.IssuedAt.Unix())
+
+	assert.True(t, IsRevoked(claims))
+}
+
+// TestRevokeActorTokens는 가장한 관리자의 토큰을 모두 폐기하면 가장 토큰도 폐기되는지 테스트합니다.
+func TestRevokeActorTokens(t *testing.T) {
+	setupRevocationTest(t)
+
+	issuedAt := jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	impersonation := &AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "30", IssuedAt: issuedAt},
+		Actor:            &ActorClaim{Subject: "31"},
+	}
+	target := &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "30", IssuedAt: issuedAt}}
+
+	assert.NoError(t, RevokeAllUserTokens(31))
+
+	assert.True(t, IsRevoked(impersonation))
+	assert.False(t, IsRevoked(target))
+
+	// act 클레임을 해석할 수 없으면 폐기된 것으로 처리
+	malformed := &AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "30", IssuedAt: jwt.NewNumericDate(time.Now())},
+		Actor:            &ActorClaim{Subject: "admin"},
+	}
+	assert.True(t, IsRevoked(malformed))
+}
+
+// TestRevocationCacheReload는 다른 인스턴스가 저장한 폐기 기록이 캐시에 반영되는지 테스트합니다.
+func TestRevocationCacheReload(t *testing.T) {
+	setupRevocationTest(t)
+
+	originalGet := repository.GetActiveTokenRevocations
+	originalDelete := repository.DeleteExpiredTokenRevocations
+	var fromDB []models.TokenRevocation
+	repository.GetActiveTokenRevocations = func(now time.Time) ([]models.TokenRevocation, error) {
+		return fromDB, nil
+	}
+	repository.DeleteExpiredTokenRevocations = func(now time.Time) error {
+		return nil
+	}
+	t.Cleanup(func() {
+		repository.GetActiveTokenRevocations = originalGet
+		repository.DeleteExpiredTokenRevocations = originalDelete
+		revocations.loaded = false
+	})
+
+	cfg := testConfig("HS256")
+	cfg.RevocationCacheTTL = 0
+	assert.NoError(t, InitRevocations(cfg))
+
+	claims := &AccessClaims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:       "revoked-elsewhere",
+		Subject:  "30",
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}}
+	assert.False(t, IsRevoked(claims))
+
+	// 다른 인스턴스에서 폐기한 기록이 데이터베이스에 추가됨
+	fromDB = append(fromDB, models.TokenRevocation{
+		JTI:       "revoked-elsewhere",
+		UserID:    30,
+		RevokedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	time.Sleep(time.Millisecond)
+	assert.True(t, IsRevoked(claims))
+}
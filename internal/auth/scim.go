@@ -0,0 +1,22 @@
+package auth
+
+import "strings"
+
+// SCIMTokenPrefix는 SCIM 프로비저닝 토큰 앞에 붙는 접두사입니다.
+// 개인 액세스 토큰과 마찬가지로 JWT와 구분하고 유출 탐지 도구가 토큰을 식별할 수 있게 합니다.
+const SCIMTokenPrefix = "gqs_"
+
+// GenerateSCIMToken은 접두사가 붙은 SCIM 토큰과 저장용 해시를 생성합니다.
+func GenerateSCIMToken() (string, string, error) {
+	token, _, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	token = SCIMTokenPrefix + token
+	return token, HashToken(token), nil
+}
+
+// IsSCIMToken은 토큰이 SCIM 토큰 형식인지 확인합니다.
+func IsSCIMToken(token string) bool {
+	return strings.HasPrefix(token, SCIMTokenPrefix)
+}
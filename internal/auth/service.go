@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+)
+
+// ServiceRolePrefix는 서비스 계정 역할 이름 앞에 붙는 접두사입니다.
+// 역할 API로 만드는 역할 이름은 대문자로 바뀌므로 데이터베이스의 역할과 겹치지 않습니다.
+const ServiceRolePrefix = "service:"
+
+// serviceRoles는 서비스 계정 역할별 권한 집합입니다. 데이터베이스의 역할과 달리 설정으로만 정합니다.
+var serviceRoles = struct {
+	mu    sync.RWMutex
+	roles map[string]map[string]bool
+}{roles: map[string]map[string]bool{}}
+
+// ServiceRole은 서비스 계정의 역할 이름을 반환합니다.
+func ServiceRole(name string) string {
+	return ServiceRolePrefix + name
+}
+
+// SetServiceRoles는 서비스 계정 이름별 권한 목록으로 서비스 계정 역할을 교체합니다.
+func SetServiceRoles(permissions map[string][]string) {
+	loaded := make(map[string]map[string]bool, len(permissions))
+	for name, list := range permissions {
+		set := make(map[string]bool, len(list))
+		for _, p := range list {
+			set[p] = true
+		}
+		loaded[ServiceRole(name)] = set
+	}
+
+	serviceRoles.mu.Lock()
+	serviceRoles.roles = loaded
+	serviceRoles.mu.Unlock()
+}
+
+// serviceRoleHasPermission은 서비스 계정 역할에 권한이 있는지 확인합니다.
+// 서비스 계정 역할이 아니면 두 번째 반환값이 false입니다.
+func serviceRoleHasPermission(role, permission string) (bool, bool) {
+	if !strings.HasPrefix(role, ServiceRolePrefix) {
+		return false, false
+	}
+
+	serviceRoles.mu.RLock()
+	defer serviceRoles.mu.RUnlock()
+	return serviceRoles.roles[role][permission], true
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 부록 B의 SHA-1 테스트 비밀 키("12345678901234567890")
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestTOTPCode는 RFC 6238 테스트 벡터로 코드 계산을 검증합니다.
+func TestTOTPCode(t *testing.T) {
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range cases {
+		code, err := TOTPCode(rfcTOTPSecret, TOTPStep(time.Unix(tc.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code)
+	}
+}
+
+// TestValidateTOTP는 허용 범위 안팎의 코드 검증을 테스트합니다.
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	current := TOTPStep(now)
+
+	code, _ := TOTPCode(secret, current)
+	step, ok := ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, current, step)
+
+	// 시계 오차를 고려해 앞뒤 한 단계는 허용
+	code, _ = TOTPCode(secret, current-1)
+	step, ok = ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, current-1, step)
+
+	// 범위를 벗어난 코드와 형식이 잘못된 코드는 거부
+	code, _ = TOTPCode(secret, current-3)
+	_, ok = ValidateTOTP(secret, code, now)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok)
+	_, ok = ValidateTOTP("", "123456", now)
+	assert.False(t, ok)
+}
+
+// TestTOTPProvisioningURI는 인증 앱 등록 URI 형식을 테스트합니다.
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("go-quickstart", "alice", rfcTOTPSecret)
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/go-quickstart:alice?"))
+	assert.Contains(t, uri, "secret="+rfcTOTPSecret)
+	assert.Contains(t, uri, "issuer=go-quickstart")
+}
+
+// TestRecoveryCodes는 복구 코드 생성과 정규화를 테스트합니다.
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	for _, code := range codes {
+		assert.Len(t, code, 9)
+		assert.Equal(t, code, NormalizeRecoveryCode(code))
+		assert.Equal(t, code, NormalizeRecoveryCode(" "+strings.ToUpper(strings.Replace(code, "-", "", 1))+" "))
+	}
+}
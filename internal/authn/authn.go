@@ -0,0 +1,81 @@
+// Package authn은 사용자명과 비밀번호로 로그인하는 사용자를 확인하는 인증 방식을 제공합니다.
+// 로컬 데이터베이스와 LDAP 디렉터리를 설정한 순서대로 시도하며, 처음 성공한 인증 방식의 사용자로 로그인합니다.
+package authn
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 인증 관련 오류
+var (
+	// ErrInvalidCredentials는 사용자가 없거나 비밀번호가 일치하지 않을 때 반환됩니다.
+	ErrInvalidCredentials = errors.New("사용자명 또는 비밀번호가 올바르지 않습니다")
+	// ErrUnavailable은 인증 서버에 연결할 수 없어 사용자를 확인하지 못했을 때 반환됩니다.
+	ErrUnavailable = errors.New("인증 서버에 연결할 수 없습니다")
+)
+
+// Authenticator는 인증 방식 구현이 제공해야 하는 인터페이스입니다.
+type Authenticator interface {
+	// Name은 AUTH_BACKENDS에 사용하는 인증 방식 이름을 반환합니다.
+	Name() string
+	// Authenticate는 사용자명과 비밀번호를 확인하고 로그인할 로컬 사용자를 반환합니다.
+	// 사용자가 없거나 비밀번호가 틀리면 ErrInvalidCredentials를 반환합니다.
+	Authenticate(username, password string) (models.User, error)
+}
+
+// authenticators는 순서대로 시도할 인증 방식입니다.
+var authenticators = []Authenticator{Local{}}
+
+// Init은 설정에 나열된 인증 방식을 구성합니다.
+func Init(cfg *config.Config) error {
+	var configured []Authenticator
+	for _, name := range cfg.AuthBackends {
+		switch name {
+		case "local":
+			configured = append(configured, Local{})
+		case "ldap":
+			ldap, err := NewLDAP(cfg)
+			if err != nil {
+				return err
+			}
+			configured = append(configured, ldap)
+		default:
+			return fmt.Errorf("지원하지 않는 인증 방식입니다: %s", name)
+		}
+	}
+	if len(configured) == 0 {
+		return errors.New("인증 방식이 하나 이상 필요합니다")
+	}
+	authenticators = configured
+	return nil
+}
+
+// SetAuthenticators는 사용할 인증 방식을 교체합니다.
+func SetAuthenticators(list ...Authenticator) {
+	authenticators = list
+}
+
+// Authenticate는 인증 방식을 순서대로 시도하여 처음 성공한 인증 방식의 사용자를 반환합니다.
+// 모든 인증 방식이 실패했을 때 하나라도 인증 서버 오류였으면 ErrUnavailable, 아니면 ErrInvalidCredentials를 반환합니다.
+func Authenticate(username, password string) (models.User, error) {
+	unavailable := false
+	for _, a := range authenticators {
+		user, err := a.Authenticate(username, password)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, ErrInvalidCredentials) {
+			log.Printf("인증 실패 (%s): %v", a.Name(), err)
+			unavailable = true
+		}
+	}
+	if unavailable {
+		return models.User{}, ErrUnavailable
+	}
+	return models.User{}, ErrInvalidCredentials
+}
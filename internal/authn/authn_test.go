@@ -0,0 +1,98 @@
+package authn_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/authn"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAuthenticator는 정해진 사용자 한 명만 확인하는 테스트용 인증 방식입니다.
+type fakeAuthenticator struct {
+	name     string
+	user     models.User
+	password string
+	err      error
+	calls    int
+}
+
+func (f *fakeAuthenticator) Name() string {
+	return f.name
+}
+
+func (f *fakeAuthenticator) Authenticate(username, password string) (models.User, error) {
+	f.calls++
+	if f.err != nil {
+		return models.User{}, f.err
+	}
+	if username != f.user.Username || password != f.password {
+		return models.User{}, authn.ErrInvalidCredentials
+	}
+	return f.user, nil
+}
+
+// TestAuthenticateOrder는 인증 방식을 순서대로 시도하고 처음 성공한 결과를 사용하는지 테스트합니다.
+func TestAuthenticateOrder(t *testing.T) {
+	first := &fakeAuthenticator{name: "first", user: models.User{ID: 1, Username: "alice"}, password: "one"}
+	second := &fakeAuthenticator{name: "second", user: models.User{ID: 2, Username: "alice"}, password: "two"}
+	authn.SetAuthenticators(first, second)
+	t.Cleanup(func() { authn.SetAuthenticators(authn.Local{}) })
+
+	user, err := authn.Authenticate("alice", "one")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+	assert.Equal(t, 0, second.calls)
+
+	user, err = authn.Authenticate("alice", "two")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), user.ID)
+
+	_, err = authn.Authenticate("alice", "three")
+	assert.ErrorIs(t, err, authn.ErrInvalidCredentials)
+}
+
+// TestAuthenticateUnavailable은 인증 서버 오류를 비밀번호 불일치와 구분하는지 테스트합니다.
+func TestAuthenticateUnavailable(t *testing.T) {
+	local := &fakeAuthenticator{name: "local", user: models.User{ID: 1, Username: "alice"}, password: "one"}
+	directory := &fakeAuthenticator{name: "ldap", err: errors.New("connection refused")}
+	authn.SetAuthenticators(local, directory)
+	t.Cleanup(func() { authn.SetAuthenticators(authn.Local{}) })
+
+	// 앞선 인증 방식이 성공하면 뒤의 서버 오류는 영향 없음
+	user, err := authn.Authenticate("alice", "one")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.ID)
+
+	_, err = authn.Authenticate("alice", "wrong")
+	assert.ErrorIs(t, err, authn.ErrUnavailable)
+
+	// 로컬 계정과 이름이 겹치는 경우는 서버 오류가 아닌 로그인 실패
+	directory.err = authn.ErrAccountConflict
+	_, err = authn.Authenticate("alice", "wrong")
+	assert.ErrorIs(t, err, authn.ErrInvalidCredentials)
+}
+
+// TestInit은 설정한 인증 방식 목록을 검증하는지 테스트합니다.
+func TestInit(t *testing.T) {
+	t.Cleanup(func() { authn.SetAuthenticators(authn.Local{}) })
+
+	cfg := config.NewConfig()
+	cfg.AuthBackends = []string{"local", "kerberos"}
+	assert.Error(t, authn.Init(cfg))
+
+	// LDAP에는 서버 주소와 기준 DN이 필요
+	cfg.AuthBackends = []string{"ldap"}
+	cfg.LDAPURL = ""
+	assert.Error(t, authn.Init(cfg))
+
+	cfg.LDAPURL = "ldap://127.0.0.1:389"
+	cfg.LDAPBaseDN = "dc=example,dc=com"
+	cfg.LDAPUserFilter = "(&(objectClass=person)(uid=*))"
+	assert.Error(t, authn.Init(cfg))
+
+	cfg.LDAPUserFilter = "(&(objectClass=person)(uid=%s))"
+	assert.NoError(t, authn.Init(cfg))
+}
@@ -0,0 +1,247 @@
+package authn
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+	"github.com/go-ldap/ldap/v3"
+	"gorm.io/gorm"
+)
+
+// LDAPProvider는 LDAP으로 로그인한 사용자의 외부 계정 공급자 이름입니다.
+const LDAPProvider = "ldap"
+
+// ErrAccountConflict는 LDAP 사용자와 이름이 같은 로컬 사용자가 이미 있을 때 반환됩니다.
+// 로컬 계정을 LDAP 계정으로 넘겨받지 않도록 로그인을 거부하며, 로그인 응답은 비밀번호가 틀린 경우와 같습니다.
+var ErrAccountConflict = fmt.Errorf("같은 사용자명의 로컬 계정이 이미 있습니다: %w", ErrInvalidCredentials)
+
+// LDAP은 디렉터리에서 사용자를 검색한 뒤 사용자의 DN과 비밀번호로 바인드하여 사용자를 확인합니다.
+// 처음 로그인한 사용자는 로컬 사용자를 생성하고, 이후 로그인마다 이메일 주소와 역할을 디렉터리에 맞춥니다.
+type LDAP struct {
+	cfg *config.Config
+}
+
+// NewLDAP은 설정으로 LDAP 인증 방식을 생성합니다.
+func NewLDAP(cfg *config.Config) (*LDAP, error) {
+	if cfg.LDAPURL == "" || cfg.LDAPBaseDN == "" {
+		return nil, errors.New("LDAP 인증에는 LDAP_URL과 LDAP_BASE_DN이 필요합니다")
+	}
+	if strings.Count(cfg.LDAPUserFilter, "%s") != 1 {
+		return nil, fmt.Errorf("LDAP_USER_FILTER에는 사용자명 자리(%%s)가 하나 있어야 합니다: %s", cfg.LDAPUserFilter)
+	}
+	return &LDAP{cfg: cfg}, nil
+}
+
+// Name은 인증 방식 이름을 반환합니다.
+func (l *LDAP) Name() string {
+	return LDAPProvider
+}
+
+// Authenticate는 디렉터리에서 사용자를 찾아 비밀번호로 바인드하고 연결된 로컬 사용자를 반환합니다.
+func (l *LDAP) Authenticate(username, plain string) (models.User, error) {
+	// 비밀번호가 비어 있는 바인드는 많은 서버에서 익명 바인드로 성공하므로 거부
+	if plain == "" {
+		return models.User{}, ErrInvalidCredentials
+	}
+
+	entry, err := l.bind(username, plain)
+	if err != nil {
+		return models.User{}, err
+	}
+	return l.resolveUser(entry)
+}
+
+// bind는 사용자를 검색하고 사용자의 DN으로 바인드한 뒤 사용자 항목을 반환합니다.
+func (l *LDAP) bind(username, plain string) (*ldap.Entry, error) {
+	conn, err := ldap.DialURL(l.cfg.LDAPURL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: l.cfg.LDAPTimeout}),
+		ldap.DialWithTLSConfig(l.tlsConfig()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP 서버 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(l.cfg.LDAPTimeout)
+
+	if l.cfg.LDAPStartTLS {
+		if err := conn.StartTLS(l.tlsConfig()); err != nil {
+			return nil, fmt.Errorf("LDAP StartTLS 실패: %w", err)
+		}
+	}
+
+	if l.cfg.LDAPBindDN != "" {
+		if err := conn.Bind(l.cfg.LDAPBindDN, l.cfg.LDAPBindPassword); err != nil {
+			return nil, fmt.Errorf("LDAP 서비스 계정 바인드 실패: %w", err)
+		}
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		l.cfg.LDAPBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(l.cfg.LDAPTimeout.Seconds()), false,
+		fmt.Sprintf(l.cfg.LDAPUserFilter, ldap.EscapeFilter(username)),
+		[]string{l.cfg.LDAPUsernameAttribute, l.cfg.LDAPEmailAttribute, l.cfg.LDAPGroupAttribute},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("LDAP 사용자 검색 실패: %w", err)
+	}
+	if result == nil || len(result.Entries) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+	if len(result.Entries) > 1 {
+		log.Printf("LDAP 사용자 검색 결과가 여러 개이므로 로그인을 거부합니다: %s", username)
+		return nil, ErrInvalidCredentials
+	}
+
+	entry := result.Entries[0]
+	if err := conn.Bind(entry.DN, plain); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("LDAP 사용자 바인드 실패: %w", err)
+	}
+	return entry, nil
+}
+
+// tlsConfig는 ldaps와 StartTLS 연결에 사용할 TLS 설정을 반환합니다.
+func (l *LDAP) tlsConfig() *tls.Config {
+	return &tls.Config{InsecureSkipVerify: l.cfg.LDAPInsecureSkipVerify}
+}
+
+// resolveUser는 LDAP 계정에 연결된 로컬 사용자를 찾아 디렉터리 정보로 갱신하고, 없으면 새로 생성합니다.
+func (l *LDAP) resolveUser(entry *ldap.Entry) (models.User, error) {
+	subject := strings.ToLower(entry.DN)
+	email := entry.GetAttributeValue(l.cfg.LDAPEmailAttribute)
+
+	identity, err := repository.GetIdentity(LDAPProvider, subject)
+	if err == gorm.ErrRecordNotFound {
+		return l.provisionUser(entry, subject, email)
+	} else if err != nil {
+		return models.User{}, err
+	}
+
+	user, err := repository.GetUserByID(identity.UserID)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	changed := false
+	if email != "" && email != user.Email {
+		user.Email = email
+		changed = true
+	}
+	// 역할 매핑이 설정된 경우에만 디렉터리 그룹으로 역할을 맞춤
+	if len(l.cfg.LDAPRoleMapping) > 0 {
+		if role := l.mapRole(entry); role != user.Role {
+			user.Role = role
+			changed = true
+		}
+	}
+	if changed {
+		if err := repository.UpdateUser(&user); err != nil {
+			return models.User{}, err
+		}
+	}
+
+	if err := repository.RecordIdentityLogin(identity.ID, email); err != nil {
+		log.Printf("LDAP 로그인 기록 실패 (사용자 %d): %v", user.ID, err)
+	}
+	return user, nil
+}
+
+// provisionUser는 처음 로그인한 LDAP 사용자의 로컬 사용자를 생성합니다.
+// 비밀번호는 알 수 없는 임의 값으로 설정하여 로컬 인증 방식으로는 로그인할 수 없습니다.
+func (l *LDAP) provisionUser(entry *ldap.Entry, subject, email string) (models.User, error) {
+	username := entry.GetAttributeValue(l.cfg.LDAPUsernameAttribute)
+	if username == "" {
+		return models.User{}, fmt.Errorf("LDAP 사용자에 %s 속성이 없습니다: %s", l.cfg.LDAPUsernameAttribute, entry.DN)
+	}
+
+	if _, err := repository.GetUserByUsername(username); err == nil {
+		log.Printf("LDAP 사용자와 이름이 같은 로컬 계정이 있어 로그인을 거부합니다: %s", username)
+		return models.User{}, ErrAccountConflict
+	} else if err != gorm.ErrRecordNotFound {
+		return models.User{}, err
+	}
+
+	organizationID := tenant.DefaultOrganizationID()
+	if l.cfg.LDAPOrganization != "" {
+		org, err := repository.GetOrganizationByName(l.cfg.LDAPOrganization)
+		if err != nil {
+			return models.User{}, fmt.Errorf("LDAP 사용자의 조직을 찾을 수 없습니다 (%s): %w", l.cfg.LDAPOrganization, err)
+		}
+		organizationID = org.ID
+	}
+
+	secret, err := auth.RandomHex(32)
+	if err != nil {
+		return models.User{}, err
+	}
+	hashedPassword, err := password.Hash(secret)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	user := models.User{
+		Username: username,
+		Email:    email,
+		Password: hashedPassword,
+		Role:     l.mapRole(entry),
+		Status:   models.UserStatusActive,
+
+		OrganizationID: organizationID,
+	}
+	identity := models.Identity{
+		Provider: LDAPProvider,
+		Subject:  subject,
+		Email:    email,
+	}
+	if err := repository.CreateUserWithIdentity(&user, &identity); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+// mapRole은 사용자가 속한 그룹을 설정된 매핑으로 역할로 바꿉니다.
+// 그룹은 DN 전체 또는 첫 번째 RDN 값(CN)으로 매핑하며, 일치하는 그룹이 없으면 기본 역할을 반환합니다.
+func (l *LDAP) mapRole(entry *ldap.Entry) string {
+	role := l.cfg.LDAPDefaultRole
+	for _, group := range entry.GetAttributeValues(l.cfg.LDAPGroupAttribute) {
+		if mapped, ok := l.lookupRole(group); ok {
+			role = mapped
+			break
+		}
+	}
+	if !auth.RoleExists(role) {
+		log.Printf("LDAP 역할 매핑 결과가 존재하지 않는 역할입니다: %s", role)
+		return auth.RoleUser
+	}
+	return role
+}
+
+// lookupRole은 그룹 DN이나 그룹 이름에 매핑된 역할을 찾습니다. 대소문자는 구분하지 않습니다.
+func (l *LDAP) lookupRole(group string) (string, bool) {
+	names := []string{group}
+	if dn, err := ldap.ParseDN(group); err == nil && len(dn.RDNs) > 0 && len(dn.RDNs[0].Attributes) > 0 {
+		names = append(names, dn.RDNs[0].Attributes[0].Value)
+	}
+	for _, name := range names {
+		for key, role := range l.cfg.LDAPRoleMapping {
+			if strings.EqualFold(key, name) {
+				return role, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,302 @@
+// Package ldaptest는 테스트에서 사용하는 프로세스 내 LDAP 서버를 제공합니다.
+// 단순 바인드, 검색(and, or, not, 일치, 존재 필터)과 언바인드만 지원합니다.
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP 프로토콜 연산 태그 (RFC 4511)
+const (
+	opBindRequest       = 0
+	opBindResponse      = 1
+	opUnbindRequest     = 2
+	opSearchRequest     = 3
+	opSearchResultEntry = 4
+	opSearchResultDone  = 5
+)
+
+// LDAP 결과 코드
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+	resultInsufficientAccess = 50
+	resultUnwillingToPerform = 53
+)
+
+// 검색 필터 태그
+const (
+	filterAnd      = 0
+	filterOr       = 1
+	filterNot      = 2
+	filterEquality = 3
+	filterPresent  = 7
+)
+
+// Entry는 디렉터리 항목입니다. Password가 비어 있지 않으면 DN과 비밀번호로 바인드할 수 있습니다.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server는 TCP로 LDAP 요청을 받는 모의 디렉터리 서버입니다.
+// 익명 연결은 검색할 수 없으며, 바인드한 연결만 하위 트리 전체를 검색할 수 있습니다.
+type Server struct {
+	// URL은 ldap://127.0.0.1:포트 형식의 서버 주소입니다.
+	URL string
+
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries []Entry
+	binds   []string
+}
+
+// NewServer는 항목을 담은 모의 서버를 시작합니다. 사용 후 Close를 호출해야 합니다.
+func NewServer(entries ...Entry) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		URL:      "ldap://" + listener.Addr().String(),
+		listener: listener,
+		entries:  entries,
+	}
+	go s.serve()
+	return s
+}
+
+// Close는 서버를 종료합니다.
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+// SetEntries는 디렉터리 항목을 교체합니다.
+func (s *Server) SetEntries(entries ...Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = entries
+}
+
+// Binds는 지금까지 바인드를 시도한 DN 목록을 반환합니다.
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+// serve는 연결을 받아 각각 처리합니다.
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle은 연결이 끊기거나 언바인드할 때까지 요청을 처리합니다.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	bound := false
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case opBindRequest:
+			var code int
+			code, bound = s.bind(op)
+			responses = append(responses, result(opBindResponse, code))
+		case opSearchRequest:
+			if !bound {
+				responses = append(responses, result(opSearchResultDone, resultInsufficientAccess))
+				break
+			}
+			responses = append(responses, s.search(op)...)
+		case opUnbindRequest:
+			return
+		default:
+			return
+		}
+
+		for _, response := range responses {
+			message := ber.NewSequence("LDAP Message")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			message.AppendChild(response)
+			if _, err := conn.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind는 단순 바인드를 처리하고 결과 코드와 바인드 성공 여부를 반환합니다.
+func (s *Server) bind(op *ber.Packet) (int, bool) {
+	if len(op.Children) < 3 || op.Children[2].Tag != 0 {
+		return resultProtocolError, false
+	}
+	dn, _ := op.Children[1].Value.(string)
+	password := op.Children[2].Data.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.binds = append(s.binds, dn)
+
+	// 비밀번호 없는 단순 바인드는 인증되지 않은 바인드로 거부 (RFC 4513 5.1.2)
+	if dn != "" && password == "" {
+		return resultUnwillingToPerform, false
+	}
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.DN, dn) && entry.Password != "" && entry.Password == password {
+			return resultSuccess, true
+		}
+	}
+	return resultInvalidCredentials, false
+}
+
+// search는 기준 DN 아래에서 필터와 일치하는 항목을 찾아 응답 목록을 만듭니다.
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(opSearchResultDone, resultProtocolError)}
+	}
+	baseDN, _ := op.Children[0].Value.(string)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var attributes []string
+	for _, attr := range op.Children[7].Children {
+		if name, ok := attr.Value.(string); ok {
+			attributes = append(attributes, name)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var responses []*ber.Packet
+	suffix := "," + strings.ToLower(baseDN)
+	for _, entry := range s.entries {
+		dn := strings.ToLower(entry.DN)
+		if dn != strings.ToLower(baseDN) && !strings.HasSuffix(dn, suffix) {
+			continue
+		}
+		if !matches(entry, filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(responses)) >= sizeLimit {
+			return append(responses, result(opSearchResultDone, resultSizeLimitExceeded))
+		}
+		responses = append(responses, searchEntry(entry, attributes))
+	}
+	return append(responses, result(opSearchResultDone, resultSuccess))
+}
+
+// matches는 항목이 검색 필터와 일치하는지 확인합니다. 지원하지 않는 필터는 일치하지 않는 것으로 처리합니다.
+func matches(entry Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case filterAnd:
+		for _, child := range filter.Children {
+			if !matches(entry, child) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, child := range filter.Children {
+			if matches(entry, child) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(filter.Children) == 1 && !matches(entry, filter.Children[0])
+	case filterEquality:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		name, _ := filter.Children[0].Value.(string)
+		value, _ := filter.Children[1].Value.(string)
+		for _, v := range attributeValues(entry, name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case filterPresent:
+		return len(attributeValues(entry, filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// attributeValues는 대소문자를 구분하지 않고 속성 값을 찾습니다.
+func attributeValues(entry Entry, name string) []string {
+	for key, values := range entry.Attributes {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// searchEntry는 요청한 속성만 담은 검색 결과 항목을 만듭니다. 요청한 속성이 없으면 모든 속성을 담습니다.
+func searchEntry(entry Entry, attributes []string) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchResultEntry, nil, "Search Result Entry")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+
+	attrs := ber.NewSequence("Attributes")
+	for key, values := range entry.Attributes {
+		if !requested(attributes, key) {
+			continue
+		}
+		attr := ber.NewSequence("Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, key, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	packet.AppendChild(attrs)
+	return packet
+}
+
+// requested는 검색 요청에 속성이 포함되어 있는지 확인합니다.
+func requested(attributes []string, name string) bool {
+	if len(attributes) == 0 {
+		return true
+	}
+	for _, attr := range attributes {
+		if attr == "*" || strings.EqualFold(attr, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// result는 결과 코드만 담은 LDAPResult 응답을 만듭니다.
+func result(op ber.Tag, code int) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "Result")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return packet
+}
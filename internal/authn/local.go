@@ -0,0 +1,50 @@
+package authn
+
+import (
+	"log"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/password"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// Local은 로컬 데이터베이스에 저장된 비밀번호 해시로 사용자를 확인합니다.
+type Local struct{}
+
+// Name은 인증 방식 이름을 반환합니다.
+func (Local) Name() string {
+	return "local"
+}
+
+// Authenticate는 사용자의 비밀번호 해시를 확인합니다.
+// 사용자 존재 여부와 관계없이 비밀번호 검증을 수행하여 응답 시간으로 계정 존재 여부를 알 수 없도록 합니다.
+func (Local) Authenticate(username, plain string) (models.User, error) {
+	user, err := repository.GetUserByUsername(username)
+	if err != nil {
+		password.VerifyDummy(plain)
+		return models.User{}, ErrInvalidCredentials
+	}
+
+	if matched, _ := password.Verify(user.Password, plain); !matched {
+		return models.User{}, ErrInvalidCredentials
+	}
+
+	// 오래된 알고리즘이나 매개변수로 저장된 해시는 현재 설정으로 다시 해싱
+	if password.NeedsRehash(user.Password) {
+		rehashPassword(user.ID, plain)
+	}
+	return user, nil
+}
+
+// rehashPassword는 비밀번호를 현재 설정으로 다시 해싱하여 저장합니다.
+// 실패하더라도 로그인은 계속 진행되며 다음 로그인에서 다시 시도합니다.
+func rehashPassword(userID int64, plain string) {
+	hashed, err := password.Hash(plain)
+	if err != nil {
+		log.Printf("비밀번호 재해싱 실패 (사용자 %d): %v", userID, err)
+		return
+	}
+	if err := repository.UpdateUserPassword(userID, hashed); err != nil {
+		log.Printf("재해싱된 비밀번호 저장 실패 (사용자 %d): %v", userID, err)
+	}
+}
@@ -0,0 +1,115 @@
+package authz
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// defaultPolicy는 POLICY_FILE이 설정되지 않았을 때 사용하는 기본 정책입니다.
+//
+//go:embed default_policy.json
+var defaultPolicy []byte
+
+// DecisionRecord는 결정 로그에 기록되는 항목입니다.
+type DecisionRecord struct {
+	Time         time.Time `json:"time"`
+	SubjectID    int64     `json:"subject_id"`
+	SubjectRole  string    `json:"subject_role"`
+	ActorID      int64     `json:"actor_id,omitempty"` // 관리자가 주체로 가장한 경우 실제로 요청한 관리자
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	IP           string    `json:"ip,omitempty"`
+	Allowed      bool      `json:"allowed"`
+	Rule         string    `json:"rule"`
+}
+
+// 현재 적용 중인 정책 엔진과 결정 로그
+var (
+	mu          sync.RWMutex
+	engine      = mustParse(defaultPolicy)
+	decisionLog = log.New(log.Writer(), "권한 결정: ", log.LstdFlags)
+)
+
+// mustParse는 기본 정책을 파싱합니다. 기본 정책은 코드와 함께 배포되므로 실패하면 패닉을 발생시킵니다.
+func mustParse(data []byte) *Engine {
+	e, err := ParsePolicy(data)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Init은 설정에서 정책 파일과 결정 로그 출력 위치를 구성합니다.
+func Init(cfg *config.Config) error {
+	e := mustParse(defaultPolicy)
+	if cfg.PolicyFile != "" {
+		loaded, err := LoadPolicy(cfg.PolicyFile)
+		if err != nil {
+			return err
+		}
+		e = loaded
+	}
+
+	if cfg.PolicyDecisionLog != "" {
+		f, err := os.OpenFile(cfg.PolicyDecisionLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		SetDecisionLog(f)
+	}
+
+	SetEngine(e)
+	return nil
+}
+
+// SetEngine은 적용할 정책 엔진을 교체합니다.
+func SetEngine(e *Engine) {
+	mu.Lock()
+	engine = e
+	mu.Unlock()
+}
+
+// SetDecisionLog는 결정 로그를 JSON 한 줄씩 w에 기록하도록 설정합니다.
+func SetDecisionLog(w io.Writer) {
+	mu.Lock()
+	decisionLog = log.New(w, "", 0)
+	mu.Unlock()
+}
+
+// Authorize는 주체가 리소스에 대해 동작을 수행할 수 있는지 정책으로 평가하고 결정을 기록합니다.
+func Authorize(subject Subject, action string, resource Resource, env Environment) Decision {
+	if env.Time.IsZero() {
+		env.Time = time.Now()
+	}
+
+	mu.RLock()
+	e, logger := engine, decisionLog
+	mu.RUnlock()
+
+	decision := e.Evaluate(subject, action, resource, env)
+
+	record, err := json.Marshal(DecisionRecord{
+		Time:         env.Time,
+		SubjectID:    subject.ID,
+		SubjectRole:  subject.Role,
+		ActorID:      subject.ActorID,
+		Action:       action,
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		IP:           env.IP,
+		Allowed:      decision.Allowed,
+		Rule:         decision.Rule,
+	})
+	if err == nil {
+		logger.Println(string(record))
+	}
+	return decision
+}
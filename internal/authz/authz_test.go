@@ -0,0 +1,128 @@
+package authz
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+  "rules": [
+    {"name": "deny-outside-office", "effect": "deny", "actions": ["documents:delete"], "resources": ["document"],
+     "conditions": [{"type": "ip_in", "values": ["10.0.0.0/8"], "not": true}]},
+    {"name": "owner", "effect": "allow", "actions": ["*"], "resources": ["document"],
+     "conditions": [{"type": "owner"}]},
+    {"name": "same-org-reviewer", "effect": "allow", "actions": ["documents:read"], "resources": ["document"],
+     "conditions": [{"type": "same_organization"}, {"type": "role", "values": ["REVIEWER", "SUPPORT"]}]},
+    {"name": "auditor", "effect": "allow", "actions": ["documents:read"], "resources": ["*"],
+     "conditions": [{"type": "permission", "values": ["logins:read"]}]}
+  ]
+}`
+
+// TestEvaluate는 정책 규칙과 조건 평가를 테스트합니다.
+func TestEvaluate(t *testing.T) {
+	engine, err := ParsePolicy([]byte(testPolicy))
+	assert.NoError(t, err)
+
+	owner := Subject{ID: 1, Role: auth.RoleUser, Attributes: map[string]string{OrganizationAttribute: "acme"}}
+	reviewer := Subject{ID: 2, Role: "REVIEWER", Attributes: map[string]string{OrganizationAttribute: "acme"}}
+	outsider := Subject{ID: 3, Role: "REVIEWER", Attributes: map[string]string{OrganizationAttribute: "other"}}
+	auditor := Subject{ID: 4, Role: "AUDITOR"}
+	doc := Resource{Type: "document", ID: "9", OwnerID: 1, Attributes: map[string]string{OrganizationAttribute: "acme"}}
+	office := Environment{IP: "10.1.2.3"}
+	home := Environment{IP: "203.0.113.5"}
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   string
+		env      Environment
+		expected Decision
+	}{
+		{"소유자 수정", owner, "documents:update", home, Decision{Allowed: true, Rule: "owner"}},
+		{"사무실에서 소유자 삭제", owner, "documents:delete", office, Decision{Allowed: true, Rule: "owner"}},
+		{"외부에서 소유자 삭제", owner, "documents:delete", home, Decision{Allowed: false, Rule: "deny-outside-office"}},
+		{"같은 조직 검토자 조회", reviewer, "documents:read", home, Decision{Allowed: true, Rule: "same-org-reviewer"}},
+		{"같은 조직 검토자 수정", reviewer, "documents:update", home, Decision{Allowed: false, Rule: DefaultDenyRule}},
+		{"다른 조직 검토자 조회", outsider, "documents:read", home, Decision{Allowed: false, Rule: DefaultDenyRule}},
+		{"감사 권한 조회", auditor, "documents:read", home, Decision{Allowed: true, Rule: "auditor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, engine.Evaluate(tt.subject, tt.action, doc, tt.env))
+		})
+	}
+}
+
+// TestParsePolicyErrors는 잘못된 정책 파일이 거부되는지 테스트합니다.
+func TestParsePolicyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{"잘못된 JSON", `{"rules": [`},
+		{"이름 없음", `{"rules": [{"effect": "allow", "actions": ["*"], "resources": ["*"]}]}`},
+		{"중복 이름", `{"rules": [{"name": "a", "effect": "allow", "actions": ["*"], "resources": ["*"]}, {"name": "a", "effect": "deny", "actions": ["*"], "resources": ["*"]}]}`},
+		{"잘못된 효과", `{"rules": [{"name": "a", "effect": "maybe", "actions": ["*"], "resources": ["*"]}]}`},
+		{"동작 없음", `{"rules": [{"name": "a", "effect": "allow", "resources": ["*"]}]}`},
+		{"알 수 없는 조건", `{"rules": [{"name": "a", "effect": "allow", "actions": ["*"], "resources": ["*"], "conditions": [{"type": "weekday"}]}]}`},
+		{"역할 값 없음", `{"rules": [{"name": "a", "effect": "allow", "actions": ["*"], "resources": ["*"], "conditions": [{"type": "role"}]}]}`},
+		{"잘못된 CIDR", `{"rules": [{"name": "a", "effect": "allow", "actions": ["*"], "resources": ["*"], "conditions": [{"type": "ip_in", "values": ["10.0.0.300/8"]}]}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.policy))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestAuthorizeDecisionLog는 Authorize가 정책 파일로 결정하고 결정 로그를 남기는지 테스트합니다.
+func TestAuthorizeDecisionLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	assert.NoError(t, os.WriteFile(path, []byte(testPolicy), 0o600))
+
+	cfg := config.NewConfig()
+	cfg.PolicyFile = path
+	assert.NoError(t, Init(cfg))
+	t.Cleanup(func() { SetEngine(mustParse(defaultPolicy)) })
+
+	var buf bytes.Buffer
+	SetDecisionLog(&buf)
+
+	subject := Subject{ID: 1, Role: auth.RoleUser}
+	decision := Authorize(subject, "documents:update", Resource{Type: "document", ID: "9", OwnerID: 1}, Environment{IP: "10.1.2.3"})
+	assert.True(t, decision.Allowed)
+
+	var record DecisionRecord
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, int64(1), record.SubjectID)
+	assert.Equal(t, "documents:update", record.Action)
+	assert.Equal(t, "9", record.ResourceID)
+	assert.Equal(t, "10.1.2.3", record.IP)
+	assert.True(t, record.Allowed)
+	assert.Equal(t, "owner", record.Rule)
+	assert.False(t, record.Time.IsZero())
+	assert.Zero(t, record.ActorID)
+
+	// 관리자가 가장한 요청은 실제로 요청한 관리자도 기록
+	buf.Reset()
+	admin := models.User{ID: 2, Role: auth.RoleAdmin}
+	Authorize(SubjectFromUser(models.User{ID: 1, Role: auth.RoleUser, ImpersonatedBy: &admin}), "documents:update", Resource{Type: "document", ID: "9", OwnerID: 1}, Environment{})
+	record = DecisionRecord{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, int64(1), record.SubjectID)
+	assert.Equal(t, int64(2), record.ActorID)
+
+	cfg.PolicyFile = filepath.Join(t.TempDir(), "missing.json")
+	assert.Error(t, Init(cfg))
+}
@@ -0,0 +1,98 @@
+package authz
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+)
+
+// 조건 종류
+const (
+	ConditionOwner            = "owner"             // 주체가 리소스의 소유자
+	ConditionSameOrganization = "same_organization" // 주체와 리소스가 같은 조직에 속함
+	ConditionRole             = "role"              // 주체의 역할이나 그룹 역할이 values 중 하나
+	ConditionPermission       = "permission"        // 주체의 역할이나 그룹 역할에 values 중 하나의 권한이 있음
+	ConditionIPIn             = "ip_in"             // 요청 IP가 values의 CIDR 대역 중 하나에 속함
+)
+
+// OrganizationAttribute는 주체와 리소스의 조직을 나타내는 속성 이름입니다.
+const OrganizationAttribute = "organization"
+
+// Condition은 규칙이 적용되기 위한 조건 하나입니다. Not이 true이면 결과를 반전합니다.
+type Condition struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values,omitempty"`
+	Not    bool     `json:"not,omitempty"`
+
+	networks []*net.IPNet
+}
+
+// compile은 조건을 검증하고 평가에 필요한 값을 미리 준비합니다.
+func (c *Condition) compile() error {
+	switch c.Type {
+	case ConditionOwner, ConditionSameOrganization:
+	case ConditionRole, ConditionPermission:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s 조건에는 values가 필요합니다", c.Type)
+		}
+	case ConditionIPIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%s 조건에는 values가 필요합니다", c.Type)
+		}
+		c.networks = nil
+		for _, v := range c.Values {
+			_, network, err := net.ParseCIDR(v)
+			if err != nil {
+				return fmt.Errorf("잘못된 CIDR 대역입니다: %s", v)
+			}
+			c.networks = append(c.networks, network)
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 조건입니다: %s", c.Type)
+	}
+	return nil
+}
+
+// evaluate는 조건을 만족하는지 확인합니다.
+func (c Condition) evaluate(subject Subject, resource Resource, env Environment) bool {
+	return c.check(subject, resource, env) != c.Not
+}
+
+// check는 Not을 적용하기 전의 조건 결과를 반환합니다.
+func (c Condition) check(subject Subject, resource Resource, env Environment) bool {
+	switch c.Type {
+	case ConditionOwner:
+		return subject.ID != 0 && subject.ID == resource.OwnerID
+	case ConditionSameOrganization:
+		org := subject.Attributes[OrganizationAttribute]
+		return org != "" && org == resource.Attributes[OrganizationAttribute]
+	case ConditionRole:
+		for _, role := range subject.roles() {
+			for _, v := range c.Values {
+				if role == v {
+					return true
+				}
+			}
+		}
+	case ConditionPermission:
+		for _, role := range subject.roles() {
+			for _, v := range c.Values {
+				if auth.RoleHasPermission(role, v) {
+					return true
+				}
+			}
+		}
+	case ConditionIPIn:
+		ip := net.ParseIP(env.IP)
+		if ip == nil {
+			return false
+		}
+		for _, network := range c.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
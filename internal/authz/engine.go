@@ -0,0 +1,192 @@
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 규칙 효과
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// DefaultDenyRule은 일치하는 규칙이 없을 때 결정에 기록되는 규칙 이름입니다.
+const DefaultDenyRule = "default-deny"
+
+// Subject는 요청을 보낸 주체입니다.
+type Subject struct {
+	ID         int64
+	Role       string
+	GroupRoles []string // 주체가 속한 그룹에 지정된 역할
+	Attributes map[string]string
+	// ActorID는 관리자가 주체로 가장한 경우 실제로 요청한 관리자의 ID입니다. 정책 평가에는 사용하지 않고 결정 로그에만 기록합니다.
+	ActorID int64
+}
+
+// Resource는 접근하려는 대상입니다.
+type Resource struct {
+	Type       string
+	ID         string
+	OwnerID    int64
+	Attributes map[string]string
+}
+
+// Environment는 요청이 발생한 환경입니다.
+type Environment struct {
+	IP   string
+	Time time.Time
+}
+
+// Decision은 정책 평가 결과입니다.
+type Decision struct {
+	Allowed bool
+	Rule    string // 결정에 사용된 규칙 이름
+}
+
+// Rule은 정책 파일의 규칙 하나입니다. 모든 조건을 만족해야 규칙이 적용됩니다.
+type Rule struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Effect      string      `json:"effect"`
+	Actions     []string    `json:"actions"`
+	Resources   []string    `json:"resources"`
+	Conditions  []Condition `json:"conditions,omitempty"`
+}
+
+// Policy는 정책 파일 전체입니다.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Engine은 정책 규칙으로 접근 요청을 평가합니다.
+// 일치하는 deny 규칙이 하나라도 있으면 거부하고, 없으면 첫 번째로 일치하는 allow 규칙으로 허용합니다.
+type Engine struct {
+	rules []Rule
+}
+
+// SubjectFromUser는 사용자 정보로 주체를 만듭니다.
+func SubjectFromUser(user models.User) Subject {
+	subject := Subject{
+		ID:         user.ID,
+		Role:       user.Role,
+		Attributes: map[string]string{OrganizationAttribute: strconv.FormatInt(user.OrganizationID, 10)},
+	}
+	for _, g := range user.Groups {
+		if g.Role != "" {
+			subject.GroupRoles = append(subject.GroupRoles, g.Role)
+		}
+	}
+	if user.ImpersonatedBy != nil {
+		subject.ActorID = user.ImpersonatedBy.ID
+	}
+	return subject
+}
+
+// roles는 주체의 역할과 그룹 역할을 모두 반환합니다.
+func (s Subject) roles() []string {
+	return append([]string{s.Role}, s.GroupRoles...)
+}
+
+// UserResource는 사용자 계정을 대상으로 하는 리소스를 만듭니다. 사용자 계정의 소유자는 자기 자신입니다.
+func UserResource(user models.User) Resource {
+	return Resource{
+		Type:       "user",
+		ID:         strconv.FormatInt(user.ID, 10),
+		OwnerID:    user.ID,
+		Attributes: map[string]string{OrganizationAttribute: strconv.FormatInt(user.OrganizationID, 10)},
+	}
+}
+
+// LoadPolicy는 JSON 정책 파일을 읽어 엔진을 만듭니다.
+func LoadPolicy(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("정책 파일을 읽을 수 없습니다: %w", err)
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy는 JSON 정책을 검증하여 엔진을 만듭니다.
+func ParsePolicy(data []byte) (*Engine, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("잘못된 정책 형식입니다: %w", err)
+	}
+
+	names := map[string]bool{}
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if r.Name == "" {
+			return nil, fmt.Errorf("%d번째 규칙에 이름이 없습니다", i+1)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("규칙 이름이 중복되었습니다: %s", r.Name)
+		}
+		names[r.Name] = true
+
+		if r.Effect != EffectAllow && r.Effect != EffectDeny {
+			return nil, fmt.Errorf("규칙 %s: 지원하지 않는 효과입니다: %s", r.Name, r.Effect)
+		}
+		if len(r.Actions) == 0 || len(r.Resources) == 0 {
+			return nil, fmt.Errorf("규칙 %s: actions와 resources가 필요합니다", r.Name)
+		}
+		for j := range r.Conditions {
+			if err := r.Conditions[j].compile(); err != nil {
+				return nil, fmt.Errorf("규칙 %s: %w", r.Name, err)
+			}
+		}
+	}
+
+	return &Engine{rules: p.Rules}, nil
+}
+
+// Evaluate는 요청에 적용되는 규칙을 찾아 허용 여부를 결정합니다.
+func (e *Engine) Evaluate(subject Subject, action string, resource Resource, env Environment) Decision {
+	var allow *Rule
+	for i := range e.rules {
+		r := &e.rules[i]
+		if !r.matches(subject, action, resource, env) {
+			continue
+		}
+		if r.Effect == EffectDeny {
+			return Decision{Allowed: false, Rule: r.Name}
+		}
+		if allow == nil {
+			allow = r
+		}
+	}
+
+	if allow != nil {
+		return Decision{Allowed: true, Rule: allow.Name}
+	}
+	return Decision{Allowed: false, Rule: DefaultDenyRule}
+}
+
+// matches는 규칙이 요청에 적용되는지 확인합니다.
+func (r *Rule) matches(subject Subject, action string, resource Resource, env Environment) bool {
+	if !matchAny(r.Actions, action) || !matchAny(r.Resources, resource.Type) {
+		return false
+	}
+	for _, c := range r.Conditions {
+		if !c.evaluate(subject, resource, env) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny는 목록에 값이나 와일드카드(*)가 있는지 확인합니다.
+func matchAny(list []string, value string) bool {
+	for _, item := range list {
+		if item == "*" || item == value {
+			return true
+		}
+	}
+	return false
+}
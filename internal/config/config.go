@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config 구조체는 애플리케이션 설정을 저장합니다.
@@ -16,6 +18,191 @@ type Config struct {
 	DBUser          string
 	DBPassword      string
 	DBName          string
+
+	// JWT 설정
+	JWTAlgorithm      string
+	JWTSecret         string
+	JWTPrivateKeyFile string
+	JWTKeyID          string
+	JWTIssuer         string
+	JWTAudience       string
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+	// 관리자가 다른 사용자로 가장할 때 발급하는 토큰의 유효 기간 (액세스 토큰 유효 기간을 넘지 않음)
+	ImpersonationTTL time.Duration
+	// 사용자당 동시 로그인 세션 수 제한 (0이면 제한 없음, 넘으면 가장 오래된 세션 종료)
+	MaxSessionsPerUser int
+
+	// 토큰 폐기 목록 캐시를 데이터베이스에서 다시 읽어오는 주기
+	RevocationCacheTTL time.Duration
+
+	// 비밀번호 해싱 설정
+	PasswordHashAlgorithm string
+	BcryptCost            int
+	Argon2Memory          uint32
+	Argon2Iterations      uint32
+	Argon2Parallelism     uint8
+
+	// 비밀번호 정책 설정
+	PasswordMinLength      int
+	PasswordRequireUpper   bool
+	PasswordRequireLower   bool
+	PasswordRequireDigit   bool
+	PasswordRequireSymbol  bool
+	PasswordForbidUserInfo bool
+	BreachedPasswordFile   string
+
+	// 계정 잠금 설정 (LockoutThreshold가 0이면 잠금 비활성화)
+	LockoutThreshold  int
+	LockoutBaseWindow time.Duration
+	LockoutMaxWindow  time.Duration
+
+	// 2단계 인증 설정
+	TOTPIssuer      string
+	MFAChallengeTTL time.Duration
+
+	// 메일 발송 설정 (SMTPHost가 비어 있으면 메일은 발송되지 않고 대기열에 남음)
+	SMTPHost             string
+	SMTPPort             string
+	SMTPUsername         string
+	SMTPPassword         string
+	SMTPTimeout          time.Duration
+	MailFrom             string
+	MailDispatchInterval time.Duration
+	MailMaxAttempts      int
+
+	// 비밀번호 재설정 설정 (PasswordResetCooldown 안에 발급한 토큰이 남아 있으면 새로 발급하지 않음)
+	PasswordResetTTL      time.Duration
+	PasswordResetURL      string
+	PasswordResetCooldown time.Duration
+
+	// 회원 가입 설정 (SignupMode: open, invite, domain, disabled)
+	SignupMode                string
+	SignupInviteCodes         []string
+	SignupAllowedDomains      []string
+	DisposableDomainsFile     string
+	EmailVerificationTTL      time.Duration
+	EmailVerificationURL      string
+	EmailVerificationCooldown time.Duration // 이 시간 안에 보낸 인증 토큰이 남아 있으면 재발송하지 않음
+
+	// 가입 초대 설정
+	InvitationTTL time.Duration
+	InvitationURL string
+
+	// 캡차 설정 (CaptchaVerifyURL이 비어 있으면 캡차 확인 안 함)
+	CaptchaVerifyURL string
+	CaptchaSecret    string
+
+	// 개인 액세스 토큰의 최대 유효 기간
+	PATMaxLifetime time.Duration
+
+	// 서명 요청용 API 키의 최대 유효 기간과 서명 시각의 허용 차이
+	APIKeyMaxLifetime  time.Duration
+	SignatureClockSkew time.Duration
+
+	// 역할별 권한 캐시를 데이터베이스에서 다시 읽어오는 주기
+	RBACCacheTTL time.Duration
+
+	// 권한 정책 설정 (PolicyFile이 비어 있으면 기본 정책 사용, PolicyDecisionLog가 비어 있으면 서버 로그에 기록)
+	PolicyFile        string
+	PolicyDecisionLog string
+
+	// 회원 가입한 사용자가 속하는 기본 조직 이름
+	DefaultOrganization string
+
+	// 외부 OpenID Connect 로그인 설정 (OIDC_PROVIDERS에 나열된 공급자만 사용)
+	OIDCProviders []OIDCProviderConfig
+	OIDCStateTTL  time.Duration
+
+	// OAuth2/OpenID Connect 인가 서버 설정 (다른 애플리케이션에 로그인 제공)
+	OAuthIssuer         string
+	OAuthSigningKeyFile string // RSA 개인 키 PEM 파일, 비어 있으면 임시 키 생성
+	OAuthKeyID          string
+	OAuthAccessTokenTTL time.Duration
+	OAuthCodeTTL        time.Duration
+	OAuthLoginURL       string // 인가 요청을 받으면 사용자를 보낼 로그인 화면 주소
+
+	// 비밀번호 로그인에 사용할 인증 방식 목록 (local, ldap), 나열한 순서대로 시도
+	AuthBackends []string
+
+	// LDAP 인증 설정 (AUTH_BACKENDS에 ldap이 있을 때만 사용)
+	LDAPURL                string
+	LDAPStartTLS           bool
+	LDAPInsecureSkipVerify bool
+	LDAPBindDN             string // 사용자 검색에 사용할 서비스 계정, 비어 있으면 익명으로 검색
+	LDAPBindPassword       string
+	LDAPBaseDN             string
+	LDAPUserFilter         string // %s에 로그인한 사용자명이 들어감
+	LDAPUsernameAttribute  string
+	LDAPEmailAttribute     string
+	LDAPGroupAttribute     string
+	LDAPRoleMapping        map[string]string // 그룹 CN 또는 DN -> 역할
+	LDAPDefaultRole        string
+	LDAPOrganization       string // 비어 있으면 기본 조직
+	LDAPTimeout            time.Duration
+
+	// SCIM 프로비저닝 API의 외부 주소 (리소스의 meta.location에 사용)
+	SCIMBaseURL string
+
+	// SAML 로그인 설정 (SAML_PROVIDERS에 나열된 IdP만 사용)
+	SAMLProviders  []SAMLProviderConfig
+	SAMLRequestTTL time.Duration
+
+	// 클라이언트 인증서(mTLS)로 서비스 간 호출을 받는 리스너 설정 (MTLS_PORT가 비어 있으면 사용 안 함)
+	MTLSPort            string
+	MTLSCertFile        string // 서버 인증서 PEM 파일
+	MTLSKeyFile         string // 서버 개인 키 PEM 파일
+	MTLSClientCAFile    string // 클라이언트 인증서를 발급한 CA 인증서 PEM 파일
+	MTLSServiceAccounts []ServiceAccountConfig
+}
+
+// ServiceAccountConfig는 클라이언트 인증서로 인증하는 서비스 계정 하나의 설정입니다.
+// 환경 변수는 MTLS_<이름>_SUBJECTS처럼 서비스 계정 이름을 대문자로 바꿔 지정합니다.
+type ServiceAccountConfig struct {
+	Name string
+	// Subjects는 서비스 계정으로 인정할 인증서 주체 목록입니다 (CN:이름, DNS:호스트, URI:주소, EMAIL:주소).
+	Subjects     []string
+	Permissions  []string
+	Organization string // 비어 있으면 기본 조직
+}
+
+// OIDCProviderConfig는 외부 OpenID Connect 공급자 하나의 설정입니다.
+// 환경 변수는 OIDC_<이름>_ISSUER처럼 공급자 이름을 대문자로 바꿔 지정합니다.
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// 처음 로그인한 사용자의 계정을 자동으로 생성할지 여부와 생성된 계정의 역할, 조직
+	AutoProvision bool
+	RoleClaim     string
+	RoleMapping   map[string]string // 클레임 값 -> 역할
+	DefaultRole   string
+	Organization  string // 비어 있으면 기본 조직
+}
+
+// SAMLProviderConfig는 SAML IdP 하나와 이 서비스(SP)의 설정입니다.
+// 환경 변수는 SAML_<이름>_IDP_SSO_URL처럼 IdP 이름을 대문자로 바꿔 지정합니다.
+type SAMLProviderConfig struct {
+	Name               string
+	EntityID           string // 이 서비스의 SP 엔티티 ID
+	ACSURL             string // IdP가 응답을 보낼 주소
+	IDPEntityID        string
+	IDPSSOURL          string
+	IDPCertificateFile string // IdP 서명 인증서 PEM 파일
+	AllowIDPInitiated  bool   // 이 서비스가 보내지 않은 요청에 대한 응답(IdP에서 시작한 로그인) 허용 여부
+
+	// 처음 로그인한 사용자의 계정을 자동으로 생성할지 여부와 속성 매핑, 생성된 계정의 조직
+	AutoProvision     bool
+	UsernameAttribute string // 비어 있거나 값이 없으면 NameID 또는 이메일 주소의 앞부분 사용
+	EmailAttribute    string
+	RoleAttribute     string
+	RoleMapping       map[string]string // 속성 값 -> 역할
+	DefaultRole       string
+	Organization      string // 비어 있으면 기본 조직
 }
 
 // NewConfig는 환경 변수에서 설정을 로드하여 새 Config 인스턴스를 반환합니다.
@@ -29,7 +216,204 @@ func NewConfig() *Config {
 		DBUser:         getEnv("DB_USER", "root"),
 		DBPassword:     getEnv("DB_PASSWORD", "rootpassword"),
 		DBName:         getEnv("DB_NAME", "MAIN"),
+
+		JWTAlgorithm:      strings.ToUpper(getEnv("JWT_ALGORITHM", "HS256")),
+		JWTSecret:         getEnv("JWT_SECRET", ""),
+		JWTPrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+		JWTKeyID:          getEnv("JWT_KEY_ID", "default"),
+		JWTIssuer:         getEnv("JWT_ISSUER", "go-quickstart"),
+		JWTAudience:       getEnv("JWT_AUDIENCE", "go-quickstart-api"),
+		AccessTokenTTL:    getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:   getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		ImpersonationTTL:  getEnvDuration("IMPERSONATION_TTL", 10*time.Minute),
+
+		MaxSessionsPerUser: getEnvInt("MAX_SESSIONS_PER_USER", 0),
+
+		RevocationCacheTTL: getEnvDuration("REVOCATION_CACHE_TTL", 30*time.Second),
+
+		PasswordHashAlgorithm: getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+		BcryptCost:            getEnvInt("BCRYPT_COST", 10),
+		Argon2Memory:          uint32(getEnvInt("ARGON2_MEMORY_KIB", 64*1024)),
+		Argon2Iterations:      uint32(getEnvInt("ARGON2_ITERATIONS", 3)),
+		Argon2Parallelism:     uint8(getEnvInt("ARGON2_PARALLELISM", 2)),
+
+		PasswordMinLength:      getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordRequireUpper:   getEnvBool("PASSWORD_REQUIRE_UPPER", false),
+		PasswordRequireLower:   getEnvBool("PASSWORD_REQUIRE_LOWER", false),
+		PasswordRequireDigit:   getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+		PasswordRequireSymbol:  getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		PasswordForbidUserInfo: getEnvBool("PASSWORD_FORBID_USER_INFO", true),
+		BreachedPasswordFile:   getEnv("BREACHED_PASSWORD_FILE", ""),
+
+		LockoutThreshold:  getEnvInt("LOCKOUT_THRESHOLD", 5),
+		LockoutBaseWindow: getEnvDuration("LOCKOUT_BASE_WINDOW", time.Minute),
+		LockoutMaxWindow:  getEnvDuration("LOCKOUT_MAX_WINDOW", time.Hour),
+
+		TOTPIssuer:      getEnv("TOTP_ISSUER", "go-quickstart"),
+		MFAChallengeTTL: getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnv("SMTP_PORT", "587"),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		SMTPTimeout:          getEnvDuration("SMTP_TIMEOUT", 10*time.Second),
+		MailFrom:             getEnv("MAIL_FROM", "no-reply@localhost"),
+		MailDispatchInterval: getEnvDuration("MAIL_DISPATCH_INTERVAL", 10*time.Second),
+		MailMaxAttempts:      getEnvInt("MAIL_MAX_ATTEMPTS", 5),
+
+		PasswordResetTTL:      getEnvDuration("PASSWORD_RESET_TTL", 30*time.Minute),
+		PasswordResetURL:      getEnv("PASSWORD_RESET_URL", "http://localhost:8080/password/reset"),
+		PasswordResetCooldown: getEnvDuration("PASSWORD_RESET_COOLDOWN", 5*time.Minute),
+
+		SignupMode:                strings.ToLower(getEnv("SIGNUP_MODE", "disabled")),
+		SignupInviteCodes:         getEnvList("SIGNUP_INVITE_CODES"),
+		SignupAllowedDomains:      getEnvList("SIGNUP_ALLOWED_DOMAINS"),
+		DisposableDomainsFile:     getEnv("DISPOSABLE_DOMAINS_FILE", ""),
+		EmailVerificationTTL:      getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+		EmailVerificationURL:      getEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/register/verify"),
+		EmailVerificationCooldown: getEnvDuration("EMAIL_VERIFICATION_COOLDOWN", 5*time.Minute),
+
+		InvitationTTL: getEnvDuration("INVITATION_TTL", 7*24*time.Hour),
+		InvitationURL: getEnv("INVITATION_URL", "http://localhost:8080/invitations/accept"),
+
+		CaptchaVerifyURL: getEnv("CAPTCHA_VERIFY_URL", ""),
+		CaptchaSecret:    getEnv("CAPTCHA_SECRET", ""),
+
+		PATMaxLifetime: getEnvDuration("PAT_MAX_LIFETIME", 365*24*time.Hour),
+
+		APIKeyMaxLifetime:  getEnvDuration("API_KEY_MAX_LIFETIME", 365*24*time.Hour),
+		SignatureClockSkew: getEnvDuration("SIGNATURE_CLOCK_SKEW", 5*time.Minute),
+
+		RBACCacheTTL: getEnvDuration("RBAC_CACHE_TTL", 30*time.Second),
+
+		PolicyFile:        getEnv("POLICY_FILE", ""),
+		PolicyDecisionLog: getEnv("POLICY_DECISION_LOG", ""),
+
+		DefaultOrganization: getEnv("DEFAULT_ORGANIZATION", "default"),
+
+		OIDCProviders: getOIDCProviders(),
+		OIDCStateTTL:  getEnvDuration("OIDC_STATE_TTL", 10*time.Minute),
+
+		OAuthIssuer:         strings.TrimSuffix(getEnv("OAUTH_ISSUER", "http://localhost:8080"), "/"),
+		OAuthSigningKeyFile: getEnv("OAUTH_SIGNING_KEY_FILE", ""),
+		OAuthKeyID:          getEnv("OAUTH_KEY_ID", "oauth-1"),
+		OAuthAccessTokenTTL: getEnvDuration("OAUTH_ACCESS_TOKEN_TTL", time.Hour),
+		OAuthCodeTTL:        getEnvDuration("OAUTH_CODE_TTL", time.Minute),
+		OAuthLoginURL:       getEnv("OAUTH_LOGIN_URL", "http://localhost:3000/login"),
+
+		AuthBackends: getAuthBackends(),
+
+		LDAPURL:                getEnv("LDAP_URL", ""),
+		LDAPStartTLS:           getEnvBool("LDAP_START_TLS", false),
+		LDAPInsecureSkipVerify: getEnvBool("LDAP_INSECURE_SKIP_VERIFY", false),
+		LDAPBindDN:             getEnv("LDAP_BIND_DN", ""),
+		LDAPBindPassword:       getEnv("LDAP_BIND_PASSWORD", ""),
+		LDAPBaseDN:             getEnv("LDAP_BASE_DN", ""),
+		LDAPUserFilter:         getEnv("LDAP_USER_FILTER", "(uid=%s)"),
+		LDAPUsernameAttribute:  getEnv("LDAP_USERNAME_ATTRIBUTE", "uid"),
+		LDAPEmailAttribute:     getEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+		LDAPGroupAttribute:     getEnv("LDAP_GROUP_ATTRIBUTE", "memberOf"),
+		LDAPRoleMapping:        getEnvMap("LDAP_ROLE_MAPPING"),
+		LDAPDefaultRole:        getEnv("LDAP_DEFAULT_ROLE", "USER"),
+		LDAPOrganization:       getEnv("LDAP_ORGANIZATION", ""),
+		LDAPTimeout:            getEnvDuration("LDAP_TIMEOUT", 5*time.Second),
+
+		SCIMBaseURL: strings.TrimSuffix(getEnv("SCIM_BASE_URL", "http://localhost:8080/scim/v2"), "/"),
+
+		SAMLProviders:  getSAMLProviders(),
+		SAMLRequestTTL: getEnvDuration("SAML_REQUEST_TTL", 10*time.Minute),
+
+		MTLSPort:            getEnv("MTLS_PORT", ""),
+		MTLSCertFile:        getEnv("MTLS_CERT_FILE", ""),
+		MTLSKeyFile:         getEnv("MTLS_KEY_FILE", ""),
+		MTLSClientCAFile:    getEnv("MTLS_CLIENT_CA_FILE", ""),
+		MTLSServiceAccounts: getServiceAccounts(),
+	}
+}
+
+// getOIDCProviders는 OIDC_PROVIDERS에 나열된 공급자의 설정을 가져옵니다.
+func getOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range getEnvList("OIDC_PROVIDERS") {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		scopes := getEnvList(prefix + "SCOPES")
+		if len(scopes) == 0 {
+			scopes = []string{"openid", "profile", "email"}
+		}
+
+		providers = append(providers, OIDCProviderConfig{
+			Name:          name,
+			Issuer:        getEnv(prefix+"ISSUER", ""),
+			ClientID:      getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret:  getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:   getEnv(prefix+"REDIRECT_URL", "http://localhost:8080/oidc/"+name+"/callback"),
+			Scopes:        scopes,
+			AutoProvision: getEnvBool(prefix+"AUTO_PROVISION", false),
+			RoleClaim:     getEnv(prefix+"ROLE_CLAIM", "groups"),
+			RoleMapping:   getEnvMap(prefix + "ROLE_MAPPING"),
+			DefaultRole:   getEnv(prefix+"DEFAULT_ROLE", "USER"),
+			Organization:  getEnv(prefix+"ORGANIZATION", ""),
+		})
+	}
+	return providers
+}
+
+// getSAMLProviders는 SAML_PROVIDERS에 나열된 IdP의 설정을 가져옵니다.
+func getSAMLProviders() []SAMLProviderConfig {
+	var providers []SAMLProviderConfig
+	for _, name := range getEnvList("SAML_PROVIDERS") {
+		name = strings.ToLower(name)
+		prefix := "SAML_" + strings.ToUpper(name) + "_"
+
+		providers = append(providers, SAMLProviderConfig{
+			Name:               name,
+			EntityID:           getEnv(prefix+"ENTITY_ID", "http://localhost:8080/saml/"+name+"/metadata"),
+			ACSURL:             getEnv(prefix+"ACS_URL", "http://localhost:8080/saml/"+name+"/acs"),
+			IDPEntityID:        getEnv(prefix+"IDP_ENTITY_ID", ""),
+			IDPSSOURL:          getEnv(prefix+"IDP_SSO_URL", ""),
+			IDPCertificateFile: getEnv(prefix+"IDP_CERT_FILE", ""),
+			AllowIDPInitiated:  getEnvBool(prefix+"IDP_INITIATED", true),
+			AutoProvision:      getEnvBool(prefix+"AUTO_PROVISION", false),
+			UsernameAttribute:  getEnv(prefix+"USERNAME_ATTRIBUTE", "uid"),
+			EmailAttribute:     getEnv(prefix+"EMAIL_ATTRIBUTE", "email"),
+			RoleAttribute:      getEnv(prefix+"ROLE_ATTRIBUTE", "groups"),
+			RoleMapping:        getEnvMap(prefix + "ROLE_MAPPING"),
+			DefaultRole:        getEnv(prefix+"DEFAULT_ROLE", "USER"),
+			Organization:       getEnv(prefix+"ORGANIZATION", ""),
+		})
+	}
+	return providers
+}
+
+// getServiceAccounts는 MTLS_SERVICE_ACCOUNTS에 나열된 서비스 계정의 설정을 가져옵니다.
+func getServiceAccounts() []ServiceAccountConfig {
+	var accounts []ServiceAccountConfig
+	for _, name := range getEnvList("MTLS_SERVICE_ACCOUNTS") {
+		name = strings.ToLower(name)
+		prefix := "MTLS_" + strings.ToUpper(name) + "_"
+
+		accounts = append(accounts, ServiceAccountConfig{
+			Name:         name,
+			Subjects:     getEnvList(prefix + "SUBJECTS"),
+			Permissions:  getEnvList(prefix + "PERMISSIONS"),
+			Organization: getEnv(prefix+"ORGANIZATION", ""),
+		})
+	}
+	return accounts
+}
+
+// getAuthBackends는 AUTH_BACKENDS에서 인증 방식 목록을 가져옵니다. 지정하지 않으면 로컬 데이터베이스만 사용합니다.
+func getAuthBackends() []string {
+	var backends []string
+	for _, name := range getEnvList("AUTH_BACKENDS") {
+		backends = append(backends, strings.ToLower(name))
+	}
+	if len(backends) == 0 {
+		backends = []string{"local"}
 	}
+	return backends
 }
 
 // GetDSN은 데이터베이스 연결 문자열을 반환합니다.
@@ -46,6 +430,56 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt는 환경 변수 값을 정수로 가져오거나 기본값을 반환합니다.
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvBool은 환경 변수 값을 불리언으로 가져오거나 기본값을 반환합니다.
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration은 환경 변수 값을 기간(예: "15m", "720h")으로 가져오거나 기본값을 반환합니다.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList는 쉼표로 구분된 환경 변수 값을 목록으로 가져옵니다. 빈 항목은 제외됩니다.
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvMap은 "키=값" 항목을 쉼표로 구분한 환경 변수 값을 맵으로 가져옵니다. 형식이 잘못된 항목은 제외됩니다.
+func getEnvMap(key string) map[string]string {
+	values := map[string]string{}
+	for _, item := range getEnvList(key) {
+		k, v, ok := strings.Cut(item, "=")
+		if k, v = strings.TrimSpace(k), strings.TrimSpace(v); ok && k != "" && v != "" {
+			values[k] = v
+		}
+	}
+	return values
+}
+
 // getTrustedProxies는 TRUSTED_PROXIES 환경 변수에서 신뢰할 수 있는 프록시 목록을 가져옵니다.
 func getTrustedProxies() []string {
 	proxiesStr := getEnv("TRUSTED_PROXIES", "192.168.1.2")
@@ -53,4 +487,4 @@ func getTrustedProxies() []string {
 		return []string{}
 	}
 	return strings.Split(proxiesStr, ",")
-}
\ No newline at end of file
+}
@@ -113,4 +113,32 @@ func TestNewConfig(t *testing.T) {
 	if !reflect.DeepEqual(cfg.TrustedProxies, expectedProxies) {
 		t.Errorf("Expected TrustedProxies to be %v, got %v", expectedProxies, cfg.TrustedProxies)
 	}
-}
\ No newline at end of file
+}
+
+func TestGetOIDCProviders(t *testing.T) {
+	t.Setenv("OIDC_PROVIDERS", "Corp")
+	t.Setenv("OIDC_CORP_ISSUER", "https://sso.example.com")
+	t.Setenv("OIDC_CORP_CLIENT_ID", "quickstart")
+	t.Setenv("OIDC_CORP_AUTO_PROVISION", "true")
+	t.Setenv("OIDC_CORP_ROLE_MAPPING", "admins=ADMIN, support = SUPPORT, broken")
+
+	providers := getOIDCProviders()
+	if len(providers) != 1 {
+		t.Fatalf("공급자 수 = %d, expected 1", len(providers))
+	}
+
+	expected := OIDCProviderConfig{
+		Name:          "corp",
+		Issuer:        "https://sso.example.com",
+		ClientID:      "quickstart",
+		RedirectURL:   "http://localhost:8080/oidc/corp/callback",
+		Scopes:        []string{"openid", "profile", "email"},
+		AutoProvision: true,
+		RoleClaim:     "groups",
+		RoleMapping:   map[string]string{"admins": "ADMIN", "support": "SUPPORT"},
+		DefaultRole:   "USER",
+	}
+	if !reflect.DeepEqual(providers[0], expected) {
+		t.Errorf("getOIDCProviders() = %+v, expected %+v", providers[0], expected)
+	}
+}
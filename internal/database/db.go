@@ -32,7 +32,14 @@ func InitDB(cfg *config.Config) {
 	fmt.Println("데이터베이스 연결 성공")
 	
 	// 모델 마이그레이션
-	err = DB.AutoMigrate(&models.User{})
+	err = DB.AutoMigrate(&models.User{}, &models.LoginHistory{}, &models.RefreshToken{}, &models.Session{}, &models.ImpersonationAudit{}, &models.TokenRevocation{}, &models.RecoveryCode{},
+		&models.PasswordResetToken{}, &models.OutboxMail{}, &models.EmailVerificationToken{},
+		&models.PersonalAccessToken{}, &models.APIKey{}, &models.APINonce{},
+		&models.Role{}, &models.Permission{}, &models.Organization{},
+		&models.Group{}, &models.Invitation{},
+		&models.Identity{}, &models.OIDCLoginState{}, &models.SAMLLoginRequest{}, &models.SAMLAssertion{},
+		&models.OAuthClient{}, &models.OAuthAuthorizationCode{},
+		&models.SCIMToken{})
 	if err != nil {
 		log.Fatalf("마이그레이션 실패: %v", err)
 	}
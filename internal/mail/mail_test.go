@@ -0,0 +1,237 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// smtpStandIn은 테스트용 최소 SMTP 서버입니다. 받은 메일 원문을 기록합니다.
+type smtpStandIn struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []string
+	rcpts    []string
+}
+
+// startSMTPStandIn은 로컬 포트에서 SMTP 서버를 시작합니다.
+func startSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("SMTP 서버 시작 실패: %v", err)
+	}
+	s := &smtpStandIn{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.TrimSpace(line))
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// received는 받은 메일 원문 목록을 반환합니다.
+func (s *smtpStandIn) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// config는 이 서버로 발송하는 설정을 반환합니다.
+func (s *smtpStandIn) config() *config.Config {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return &config.Config{
+		SMTPHost:             host,
+		SMTPPort:             port,
+		SMTPTimeout:          5 * time.Second,
+		MailFrom:             "no-reply@example.com",
+		MailDispatchInterval: time.Second,
+		MailMaxAttempts:      2,
+	}
+}
+
+// decodeMessage는 메일 원문에서 제목과 본문을 디코딩합니다.
+func decodeMessage(t *testing.T, raw string) (string, string) {
+	msg, err := netmail.ReadMessage(strings.NewReader(raw))
+	assert.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+	encoded, _ := io.ReadAll(msg.Body)
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	assert.NoError(t, err)
+	return subject, string(body)
+}
+
+// setupOutboxDB는 발송 대기열 테스트용 데이터베이스를 설정합니다.
+func setupOutboxDB(t *testing.T) {
+	var err error
+	database.DB, err = gorm.Open(sqlite.Open("file:outbox?mode=memory&cache=shared"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("테스트 데이터베이스 연결 실패: %v", err)
+	}
+	if err := database.DB.AutoMigrate(&models.OutboxMail{}); err != nil {
+		t.Fatalf("테스트 테이블 마이그레이션 실패: %v", err)
+	}
+	database.DB.Exec("DELETE FROM outbox_mails")
+}
+
+// TestSMTPTransport는 SMTP 서버로 메일이 전달되는지 테스트합니다.
+func TestSMTPTransport(t *testing.T) {
+	server := startSMTPStandIn(t)
+	transport := NewSMTPTransport(server.config())
+
+	body := strings.Repeat("비밀번호 재설정 안내입니다.\n", 10)
+	err := transport.Send(Message{To: "user@example.com", Subject: "비밀번호 재설정 안내", Body: body})
+	assert.NoError(t, err)
+
+	messages := server.received()
+	assert.Len(t, messages, 1)
+	subject, decoded := decodeMessage(t, messages[0])
+	assert.Equal(t, "비밀번호 재설정 안내", subject)
+	assert.Equal(t, body, decoded)
+	assert.Contains(t, server.rcpts[0], "user@example.com")
+
+	// 헤더 삽입 시도 거부
+	err = transport.Send(Message{To: "user@example.com\r\nBcc: other@example.com", Subject: "x", Body: "x"})
+	assert.Error(t, err)
+}
+
+// failingTransport는 항상 실패하는 Transport입니다.
+type failingTransport struct{ calls int }
+
+func (f *failingTransport) Send(msg Message) error {
+	f.calls++
+	return errors.New("연결 거부")
+}
+
+// TestDispatchPending은 대기열 메일 발송과 실패 시 재시도를 테스트합니다.
+func TestDispatchPending(t *testing.T) {
+	setupOutboxDB(t)
+	server := startSMTPStandIn(t)
+	Init(server.config())
+
+	assert.NoError(t, Enqueue("first@example.com", "첫 번째", "본문 1"))
+	assert.NoError(t, Enqueue("second@example.com", "두 번째", "본문 2"))
+
+	sent, err := DispatchPending(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Len(t, server.received(), 2)
+
+	// 이미 발송된 메일은 다시 발송하지 않음
+	sent, err = DispatchPending(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	var mails []models.OutboxMail
+	database.DB.Order("id").Find(&mails)
+	for _, m := range mails {
+		assert.Equal(t, models.MailStatusSent, m.Status)
+		assert.Equal(t, 1, m.Attempts)
+		assert.NotNil(t, m.SentAt)
+	}
+
+	// 발송 실패 시 재시도 시각까지 대기 후 최대 시도 횟수에 도달하면 실패로 표시
+	failing := &failingTransport{}
+	SetTransport(failing)
+	assert.NoError(t, Enqueue("third@example.com", "세 번째", "본문 3"))
+
+	now := time.Now()
+	_, err = DispatchPending(now)
+	assert.NoError(t, err)
+
+	var m models.OutboxMail
+	database.DB.Where("recipient = ?", "third@example.com").First(&m)
+	assert.Equal(t, models.MailStatusPending, m.Status)
+	assert.Equal(t, 1, m.Attempts)
+	assert.Equal(t, "연결 거부", m.LastError)
+
+	_, err = DispatchPending(now.Add(30 * time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failing.calls)
+
+	_, err = DispatchPending(now.Add(2 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, failing.calls)
+
+	database.DB.First(&m, m.ID)
+	assert.Equal(t, models.MailStatusFailed, m.Status)
+	assert.Equal(t, 2, m.Attempts)
+}
+
+// TestRetryDelay는 재시도 간격 계산을 테스트합니다.
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 2*time.Minute, retryDelay(2))
+	assert.Equal(t, 8*time.Minute, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(20))
+}
@@ -0,0 +1,144 @@
+package mail
+
+import (
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// 한 번의 발송 주기에 처리하는 최대 메일 수
+const dispatchBatchSize = 50
+
+// dispatcher는 발송 대기열의 메일을 Transport로 전달합니다.
+type dispatcher struct {
+	transport   Transport
+	interval    time.Duration
+	maxAttempts int
+	lease       time.Duration
+}
+
+var outbox = dispatcher{
+	interval:    10 * time.Second,
+	maxAttempts: 5,
+	lease:       time.Minute,
+}
+
+// Init은 설정으로 메일 발송을 구성합니다.
+// SMTP_HOST가 설정되지 않으면 메일은 대기열에 저장만 되고 발송되지 않습니다.
+func Init(cfg *config.Config) {
+	var transport Transport
+	if cfg.SMTPHost != "" {
+		transport = NewSMTPTransport(cfg)
+	} else {
+		log.Println("경고: SMTP_HOST가 설정되지 않아 메일이 발송되지 않고 대기열에 남습니다")
+	}
+
+	outbox = dispatcher{
+		transport:   transport,
+		interval:    cfg.MailDispatchInterval,
+		maxAttempts: cfg.MailMaxAttempts,
+		// 발송 중인 메일은 SMTP 시간 제한보다 충분히 길게 선점
+		lease: 2*cfg.SMTPTimeout + time.Minute,
+	}
+}
+
+// SetTransport는 메일 전달 구현을 교체합니다.
+func SetTransport(transport Transport) {
+	outbox.transport = transport
+}
+
+// Enqueue는 메일을 발송 대기열에 저장합니다. 실제 발송은 발송기가 비동기로 처리합니다.
+func Enqueue(to, subject, body string) error {
+	return repository.CreateOutboxMail(&models.OutboxMail{
+		Recipient:     to,
+		Subject:       subject,
+		Body:          body,
+		Status:        models.MailStatusPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// StartDispatcher는 발송 주기마다 대기열을 처리하는 백그라운드 발송기를 시작합니다.
+func StartDispatcher() {
+	if outbox.transport == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(outbox.interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := DispatchPending(time.Now()); err != nil {
+				log.Printf("메일 발송 대기열 처리 실패: %v", err)
+			}
+		}
+	}()
+}
+
+// DispatchPending은 발송 시각이 된 대기 메일을 발송하고 발송에 성공한 메일 수를 반환합니다.
+// 실패한 메일은 재시도 간격을 두 배씩 늘려 다시 시도하며, 최대 시도 횟수에 도달하면 실패로 표시합니다.
+func DispatchPending(now time.Time) (int, error) {
+	if outbox.transport == nil {
+		return 0, nil
+	}
+
+	mails, err := repository.GetDueOutboxMails(now, dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, m := range mails {
+		claimed, err := repository.ClaimOutboxMail(m.ID, now, outbox.lease)
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			continue
+		}
+		attempts := m.Attempts + 1
+
+		sendErr := outbox.transport.Send(Message{To: m.Recipient, Subject: m.Subject, Body: m.Body})
+		if sendErr == nil {
+			if err := repository.MarkOutboxMailSent(m.ID); err != nil {
+				return sent, err
+			}
+			sent++
+			continue
+		}
+
+		log.Printf("메일 발송 실패 (메일 %d, %d회째): %v", m.ID, attempts, sendErr)
+		final := attempts >= outbox.maxAttempts
+		if err := repository.MarkOutboxMailFailed(m.ID, truncate(sendErr.Error(), 1000), now.Add(retryDelay(attempts)), final); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// retryDelay는 시도 횟수에 따른 재시도 간격을 반환합니다 (1분부터 두 배씩, 최대 1시간).
+func retryDelay(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// truncate는 문자열을 UTF-8 문자가 잘리지 않도록 최대 n바이트로 자릅니다.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
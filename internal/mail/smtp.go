@@ -0,0 +1,121 @@
+package mail
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// Message는 발송할 메일 한 통을 나타냅니다.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Transport는 메일을 실제로 전달하는 구현이 제공해야 하는 인터페이스입니다.
+type Transport interface {
+	Send(msg Message) error
+}
+
+// SMTPTransport는 SMTP 서버로 메일을 전달하는 Transport 구현입니다.
+// 서버가 STARTTLS를 지원하면 암호화된 연결로 전환합니다.
+type SMTPTransport struct {
+	host     string
+	addr     string
+	from     string
+	username string
+	password string
+	timeout  time.Duration
+}
+
+// NewSMTPTransport는 설정으로 SMTPTransport를 생성합니다.
+func NewSMTPTransport(cfg *config.Config) *SMTPTransport {
+	return &SMTPTransport{
+		host:     cfg.SMTPHost,
+		addr:     net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		from:     cfg.MailFrom,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		timeout:  cfg.SMTPTimeout,
+	}
+}
+
+// Send는 SMTP 서버로 메일을 발송합니다.
+func (t *SMTPTransport) Send(msg Message) error {
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return errors.New("메일 헤더에 줄바꿈 문자를 사용할 수 없습니다")
+	}
+
+	conn, err := net.DialTimeout("tcp", t.addr, t.timeout)
+	if err != nil {
+		return err
+	}
+	// 응답하지 않는 서버 때문에 발송기가 멈추지 않도록 전체 대화에 기한 설정
+	conn.SetDeadline(time.Now().Add(t.timeout))
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: t.host}); err != nil {
+			return err
+		}
+	}
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(t.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMessage(t.from, msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage는 헤더와 base64로 인코딩된 UTF-8 본문으로 메일 원문을 만듭니다.
+func buildMessage(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return []byte(b.String())
+}
@@ -2,27 +2,50 @@ package middleware
 
 import (
 	"net/http"
-	"strconv"
 	"strings"
+	"time"
 
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/pkg/signing"
 	"github.com/gin-gonic/gin"
 )
 
 // AuthUser는 인증된 사용자 정보를 저장하는 키입니다.
 const AuthUser = "auth_user"
 
+// AuthClaims는 검증된 액세스 토큰 클레임을 저장하는 키입니다.
+const AuthClaims = "auth_claims"
+
+// AuthToken은 개인 액세스 토큰으로 인증한 경우 해당 토큰 정보를 저장하는 키입니다.
+const AuthToken = "auth_token"
+
+// 개인 액세스 토큰과 세션의 마지막 사용 시각을 갱신하는 최소 간격
+const tokenTouchInterval = time.Minute
+
 // RequireAuth는 인증이 필요한 엔드포인트에 대한 미들웨어입니다.
 func RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 헤더에서 토큰 추출
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
+			// mTLS 리스너에서 검증된 클라이언트 인증서는 서비스 계정으로 인증
+			if c.Request.TLS != nil && len(c.Request.TLS.VerifiedChains) > 0 {
+				authenticateServiceAccount(c)
+				return
+			}
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "인증이 필요합니다"})
 			c.Abort()
 			return
 		}
 
+		// API 키로 서명한 요청은 서명 확인
+		if signing.IsSigned(authHeader) {
+			authenticateSignedRequest(c, authHeader)
+			return
+		}
+
 		// Bearer 토큰 형식 확인
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
@@ -31,70 +54,120 @@ func RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		token := parts[1]
-		
-		// 실제 구현에서는 JWT 토큰 검증 필요
-		// 여기서는 간단한 예시로 "admin-token"과 "user-token-{id}" 형식만 검증
-		
-		var user models.User
-		
-		if token == "admin-token" {
-			// 관리자 토큰인 경우
-			user = models.User{
-				ID:       1,
-				Username: "admin",
-				Email:    "admin@example.com",
-				Role:     "ADMIN",
-			}
-		} else if strings.HasPrefix(token, "user-token-") {
-			// 사용자 토큰인 경우
-			idStr := strings.TrimPrefix(token, "user-token-")
-			id, err := strconv.ParseInt(idStr, 10, 64)
-			if err != nil {
-				c.JSON(http.StatusUnauthorized, gin.H{"error": "잘못된 토큰입니다"})
-				c.Abort()
-				return
-			}
-			
-			user = models.User{
-				ID:       id,
-				Username: "user" + idStr,
-				Email:    "user" + idStr + "@example.com",
-				Role:     "USER",
-			}
-		} else {
+		// 개인 액세스 토큰은 데이터베이스에서 확인
+		if auth.IsPersonalAccessToken(parts[1]) {
+			authenticatePersonalAccessToken(c, parts[1])
+			return
+		}
+
+		// 토큰 서명 및 만료 검증
+		claims, err := auth.ParseAccessToken(parts[1])
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 토큰입니다"})
 			c.Abort()
 			return
 		}
-		
+
+		// 서버 측 폐기 여부 확인
+		if auth.IsRevoked(claims) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "폐기된 토큰입니다"})
+			c.Abort()
+			return
+		}
+
+		userID, err := claims.UserID()
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "잘못된 토큰입니다"})
+			c.Abort()
+			return
+		}
+
+		// 데이터베이스에서 실제 사용자 조회
+		user, ok := loadUser(userID)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 토큰입니다"})
+			c.Abort()
+			return
+		}
+
+		// 가장 토큰이면 실제로 요청한 관리자도 확인
+		if claims.IsImpersonation() {
+			actor, ok := loadImpersonator(claims)
+			if !ok {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 토큰입니다"})
+				c.Abort()
+				return
+			}
+			user.ImpersonatedBy = &actor
+		}
+
+		// 세션 목록에 표시할 마지막 사용 시각 갱신 (실패해도 요청은 처리)
+		if claims.SessionID != "" {
+			now := time.Now()
+			repository.TouchSession(claims.SessionID, now, now.Add(-tokenTouchInterval))
+		}
+
 		// 사용자 정보를 컨텍스트에 저장
 		c.Set(AuthUser, user)
+		c.Set(AuthClaims, claims)
 		c.Next()
 	}
 }
 
-// RequireAdmin은 관리자 권한이 필요한 엔드포인트에 대한 미들웨어입니다.
-func RequireAdmin() gin.HandlerFunc {
+// authenticatePersonalAccessToken은 개인 액세스 토큰을 확인하고 사용자와 토큰 정보를 컨텍스트에 저장합니다.
+func authenticatePersonalAccessToken(c *gin.Context, raw string) {
+	token, err := repository.GetPersonalAccessTokenByHash(auth.HashToken(raw))
+	if err != nil || token.RevokedAt != nil || time.Now().After(token.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 토큰입니다"})
+		c.Abort()
+		return
+	}
+
+	user, ok := loadUser(token.UserID)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 토큰입니다"})
+		c.Abort()
+		return
+	}
+
+	// 요청마다 기록하지 않도록 일정 간격 이상 지났을 때만 갱신
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenTouchInterval {
+		repository.TouchPersonalAccessToken(token.ID, now)
+	}
+
+	c.Set(AuthUser, user)
+	c.Set(AuthToken, token)
+	c.Next()
+}
+
+// loadUser는 활성 상태인 사용자를 소속 그룹과 함께 조회합니다.
+func loadUser(userID int64) (models.User, bool) {
+	user, err := repository.GetUserByID(userID)
+	if err != nil || !user.IsActive() {
+		return user, false
+	}
+
+	groups, err := repository.GetUserGroups(user.ID)
+	if err != nil {
+		return user, false
+	}
+	user.Groups = groups
+	return user, true
+}
+
+// RequirePermission은 사용자의 역할에 permission 권한이 필요한 엔드포인트에 대한 미들웨어입니다.
+func RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 인증된 사용자 정보 가져오기
-		userInterface, exists := c.Get(AuthUser)
+		user, exists := GetAuthUser(c)
 		if !exists {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "인증이 필요합니다"})
 			c.Abort()
 			return
 		}
 		
-		user, ok := userInterface.(models.User)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "사용자 정보 처리 중 오류가 발생했습니다"})
-			c.Abort()
-			return
-		}
-		
-		// 관리자 권한 확인
-		if user.Role != "ADMIN" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "관리자 권한이 필요합니다"})
+		if !auth.HasPermission(user, permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "권한이 필요합니다: " + permission})
 			c.Abort()
 			return
 		}
@@ -104,6 +177,7 @@ func RequireAdmin() gin.HandlerFunc {
 }
 
 // GetAuthUser는 컨텍스트에서 인증된 사용자 정보를 가져옵니다.
+// 관리자가 가장한 요청이면 가장 대상 사용자를 반환하며, 실제로 요청한 관리자는 ImpersonatedBy에 담깁니다.
 func GetAuthUser(c *gin.Context) (models.User, bool) {
 	userInterface, exists := c.Get(AuthUser)
 	if !exists {
@@ -112,4 +186,26 @@ func GetAuthUser(c *gin.Context) (models.User, bool) {
 	
 	user, ok := userInterface.(models.User)
 	return user, ok
-}
\ No newline at end of file
+}
+
+// GetAuthClaims는 컨텍스트에서 검증된 액세스 토큰 클레임을 가져옵니다.
+func GetAuthClaims(c *gin.Context) (*auth.AccessClaims, bool) {
+	claimsInterface, exists := c.Get(AuthClaims)
+	if !exists {
+		return nil, false
+	}
+
+	claims, ok := claimsInterface.(*auth.AccessClaims)
+	return claims, ok
+}
+
+// GetAuthToken은 개인 액세스 토큰으로 인증한 경우 컨텍스트에서 토큰 정보를 가져옵니다.
+func GetAuthToken(c *gin.Context) (models.PersonalAccessToken, bool) {
+	tokenInterface, exists := c.Get(AuthToken)
+	if !exists {
+		return models.PersonalAccessToken{}, false
+	}
+
+	token, ok := tokenInterface.(models.PersonalAccessToken)
+	return token, ok
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupAuthTest는 인증 미들웨어 테스트를 위한 라우터를 설정합니다.
+func setupAuthTest(t *testing.T, users map[int64]models.User) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	if err := auth.InitJWT(config.NewConfig()); err != nil {
+		t.Fatalf("JWT 초기화 실패: %v", err)
+	}
+
+	// 사용자 조회를 모의 함수로 대체
+	originalGetUserByID := repository.GetUserByID
+	repository.GetUserByID = func(id int64) (models.User, error) {
+		if user, ok := users[id]; ok {
+			return user, nil
+		}
+		return models.User{}, gorm.ErrRecordNotFound
+	}
+	originalGetUserGroups := repository.GetUserGroups
+	repository.GetUserGroups = func(userID int64) ([]models.Group, error) {
+		return nil, nil
+	}
+	t.Cleanup(func() {
+		repository.GetUserByID = originalGetUserByID
+		repository.GetUserGroups = originalGetUserGroups
+	})
+
+	router := gin.New()
+	router.Use(RequireAuth())
+	router.GET("/me", func(c *gin.Context) {
+		user, _ := GetAuthUser(c)
+		c.JSON(http.StatusOK, gin.H{"username": user.Username})
+	})
+	return router
+}
+
+// TestRequireAuth는 RequireAuth 미들웨어를 테스트합니다.
+func TestRequireAuth(t *testing.T) {
+	user := models.User{ID: 7, Username: "realuser", Role: "USER"}
+	router := setupAuthTest(t, map[int64]models.User{user.ID: user})
+
+	validToken, _, _ := auth.GenerateAccessToken(user, "")
+	unknownToken, _, _ := auth.GenerateAccessToken(models.User{ID: 99}, "")
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"유효한 토큰", "Bearer " + validToken, http.StatusOK, `"username":"realuser"`},
+		{"헤더 없음", "", http.StatusUnauthorized, "인증이 필요합니다"},
+		{"잘못된 형식", "Token " + validToken, http.StatusUnauthorized, "잘못된 인증 형식입니다"},
+		{"기존 관리자 토큰", "Bearer admin-token", http.StatusUnauthorized, "유효하지 않은 토큰입니다"},
+		{"존재하지 않는 사용자", "Bearer " + unknownToken, http.StatusUnauthorized, "유효하지 않은 토큰입니다"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+// TestRequirePermission은 역할별 권한 확인 미들웨어를 테스트합니다.
+func TestRequirePermission(t *testing.T) {
+	users := map[int64]models.User{
+		1: {ID: 1, Username: "admin", Role: auth.RoleAdmin},
+		2: {ID: 2, Username: "support", Role: "SUPPORT"},
+		3: {ID: 3, Username: "user", Role: auth.RoleUser},
+	}
+	router := setupAuthTest(t, users)
+	router.POST("/unlock", RequirePermission(auth.PermUsersUnlock), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.DELETE("/user", RequirePermission(auth.PermUsersDelete), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name           string
+		userID         int64
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"관리자 잠금 해제", 1, "POST", "/unlock", http.StatusOK},
+		{"관리자 삭제", 1, "DELETE", "/user", http.StatusOK},
+		{"지원 담당자 잠금 해제", 2, "POST", "/unlock", http.StatusOK},
+		{"지원 담당자 삭제", 2, "DELETE", "/user", http.StatusForbidden},
+		{"일반 사용자 잠금 해제", 3, "POST", "/unlock", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, _ := auth.GenerateAccessToken(users[tt.userID], "")
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
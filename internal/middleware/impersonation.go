@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// loadImpersonator는 가장 토큰을 발급받은 관리자를 조회합니다.
+// 관리자가 비활성화되었거나 가장 권한을 잃었으면 토큰을 더 이상 사용할 수 없습니다.
+func loadImpersonator(claims *auth.AccessClaims) (models.User, bool) {
+	actorID, err := claims.ActorID()
+	if err != nil {
+		return models.User{}, false
+	}
+
+	actor, ok := loadUser(actorID)
+	if !ok || !auth.HasPermission(actor, auth.PermUsersImpersonate) {
+		return models.User{}, false
+	}
+	return actor, true
+}
+
+// GetActor는 실제로 요청한 사용자를 가져옵니다. 관리자가 가장한 요청이면 가장한 관리자입니다.
+func GetActor(c *gin.Context) (models.User, bool) {
+	user, ok := GetAuthUser(c)
+	if !ok {
+		return models.User{}, false
+	}
+	return user.Actor(), true
+}
+
+// IsImpersonated는 관리자가 다른 사용자로 가장한 요청인지 확인합니다.
+func IsImpersonated(c *gin.Context) bool {
+	user, ok := GetAuthUser(c)
+	return ok && user.ImpersonatedBy != nil
+}
+
+// DenyImpersonation은 관리자가 다른 사용자로 가장한 토큰으로는 사용할 수 없는 엔드포인트에 대한 미들웨어입니다.
+// 역할 관리나 인증 수단 변경처럼 가장 대상 계정의 권한이나 자격 증명을 바꾸는 작업을 막습니다.
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsImpersonated(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "다른 사용자로 가장한 상태에서는 사용할 수 없는 기능입니다",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImpersonation은 가장 토큰으로 인증한 경우 대상 사용자와 관리자를 모두 확인하는지 테스트합니다.
+func TestImpersonation(t *testing.T) {
+	admin := models.User{ID: 1, Username: "admin", Role: auth.RoleAdmin}
+	user := models.User{ID: 7, Username: "realuser", Role: auth.RoleUser}
+	other := models.User{ID: 8, Username: "other", Role: auth.RoleUser}
+	router := setupAuthTest(t, map[int64]models.User{admin.ID: admin, user.ID: user, other.ID: other})
+	router.GET("/actor", func(c *gin.Context) {
+		actor, _ := GetActor(c)
+		c.JSON(http.StatusOK, gin.H{"username": actor.Username})
+	})
+	router.GET("/deny", DenyImpersonation(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	impersonation, _, _ := auth.GenerateImpersonationToken(user, admin, "")
+	withoutPermission, _, _ := auth.GenerateImpersonationToken(user, other, "")
+	unknownActor, _, _ := auth.GenerateImpersonationToken(user, models.User{ID: 99}, "")
+	sessionToken, _, _ := auth.GenerateAccessToken(user, "")
+
+	tests := []struct {
+		name           string
+		path           string
+		token          string
+		expectedStatus int
+		expectedUser   string
+	}{
+		{name: "가장 대상 사용자", path: "/me", token: impersonation, expectedStatus: http.StatusOK, expectedUser: "realuser"},
+		{name: "실제로 요청한 관리자", path: "/actor", token: impersonation, expectedStatus: http.StatusOK, expectedUser: "admin"},
+		{name: "가장하지 않은 요청의 실제 사용자", path: "/actor", token: sessionToken, expectedStatus: http.StatusOK, expectedUser: "realuser"},
+		{name: "가장 상태에서 금지된 기능", path: "/deny", token: impersonation, expectedStatus: http.StatusForbidden},
+		{name: "가장하지 않은 요청", path: "/deny", token: sessionToken, expectedStatus: http.StatusOK},
+		{name: "가장 권한이 없는 관리자", path: "/me", token: withoutPermission, expectedStatus: http.StatusUnauthorized},
+		{name: "존재하지 않는 관리자", path: "/me", token: unknownActor, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUser != "" {
+				assert.Contains(t, w.Body.String(), `"username":"`+tt.expectedUser+`"`)
+			}
+		})
+	}
+}
+
+// TestRequester는 요청 로그에 가장한 관리자가 함께 기록되는지 테스트합니다.
+func TestRequester(t *testing.T) {
+	admin := models.User{ID: 1, Username: "admin"}
+	user := models.User{ID: 7, Username: "realuser"}
+	impersonated := user
+	impersonated.ImpersonatedBy = &admin
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.Equal(t, "", requester(c))
+
+	c.Set(AuthUser, user)
+	assert.Equal(t, " user=7", requester(c))
+
+	c.Set(AuthUser, impersonated)
+	assert.Equal(t, " user=7 actor=1", requester(c))
+}
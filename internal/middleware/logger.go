@@ -21,12 +21,25 @@ func Logger() gin.HandlerFunc {
 		latency := endTime.Sub(startTime)
 
 		// 요청 정보 로깅
-		fmt.Printf("[%s] %s %s %d %s\n",
+		fmt.Printf("[%s] %s %s %d %s%s\n",
 			endTime.Format("2006-01-02 15:04:05"),
 			c.Request.Method,
 			c.Request.URL.Path,
 			c.Writer.Status(),
 			latency,
+			requester(c),
 		)
 	}
+}
+
+// requester는 인증된 요청이면 사용자 ID를, 관리자가 가장한 요청이면 실제로 요청한 관리자 ID도 함께 반환합니다.
+func requester(c *gin.Context) string {
+	user, ok := GetAuthUser(c)
+	if !ok {
+		return ""
+	}
+	if user.ImpersonatedBy != nil {
+		return fmt.Sprintf(" user=%d actor=%d", user.ID, user.ImpersonatedBy.ID)
+	}
+	return fmt.Sprintf(" user=%d", user.ID)
 }
\ No newline at end of file
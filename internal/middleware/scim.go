@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/scim"
+	"github.com/gin-gonic/gin"
+)
+
+// SCIMToken은 SCIM 토큰으로 인증한 경우 토큰 정보를 저장하는 키입니다.
+const SCIMToken = "scim_token"
+
+// RequireSCIMToken은 SCIM 프로비저닝 엔드포인트에 대한 미들웨어입니다.
+// 사용자 토큰이 아닌 SCIM 전용 토큰만 허용하며, 오류는 SCIM 오류 형식으로 응답합니다.
+func RequireSCIMToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || !auth.IsSCIMToken(raw) {
+			abortSCIM(c, "SCIM 토큰이 필요합니다")
+			return
+		}
+
+		token, err := repository.GetSCIMTokenByHash(auth.HashToken(raw))
+		if err != nil || token.RevokedAt != nil {
+			abortSCIM(c, "유효하지 않은 토큰입니다")
+			return
+		}
+
+		// 요청마다 기록하지 않도록 일정 간격 이상 지났을 때만 갱신
+		now := time.Now()
+		if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenTouchInterval {
+			repository.TouchSCIMToken(token.ID, now)
+		}
+
+		c.Set(SCIMToken, token)
+		c.Next()
+	}
+}
+
+// abortSCIM은 SCIM 오류 형식의 인증 실패 응답으로 요청을 중단합니다.
+func abortSCIM(c *gin.Context, detail string) {
+	c.Header("WWW-Authenticate", `Bearer realm="scim"`)
+	c.Header("Content-Type", scim.ContentType)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, scim.NewError(http.StatusUnauthorized, "", "%s", detail))
+}
+
+// GetSCIMToken은 컨텍스트에서 인증된 SCIM 토큰 정보를 가져옵니다.
+func GetSCIMToken(c *gin.Context) (models.SCIMToken, bool) {
+	tokenInterface, exists := c.Get(SCIMToken)
+	if !exists {
+		return models.SCIMToken{}, false
+	}
+
+	token, ok := tokenInterface.(models.SCIMToken)
+	return token, ok
+}
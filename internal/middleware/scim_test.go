@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/scim"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestRequireSCIMToken은 SCIM 토큰 인증과 SCIM 오류 응답을 테스트합니다.
+func TestRequireSCIMToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validToken, validHash, _ := auth.GenerateSCIMToken()
+	revokedToken, revokedHash, _ := auth.GenerateSCIMToken()
+	patToken, _, _ := auth.GeneratePersonalAccessToken()
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tokens := map[string]models.SCIMToken{
+		validHash:   {ID: 1, OrganizationID: 3},
+		revokedHash: {ID: 2, OrganizationID: 3, RevokedAt: &revokedAt},
+	}
+	touched := map[int64]bool{}
+
+	originalGet, originalTouch := repository.GetSCIMTokenByHash, repository.TouchSCIMToken
+	repository.GetSCIMTokenByHash = func(hash string) (models.SCIMToken, error) {
+		if token, ok := tokens[hash]; ok {
+			return token, nil
+		}
+		return models.SCIMToken{}, gorm.ErrRecordNotFound
+	}
+	repository.TouchSCIMToken = func(id int64, at time.Time) error {
+		touched[id] = true
+		return nil
+	}
+	t.Cleanup(func() {
+		repository.GetSCIMTokenByHash = originalGet
+		repository.TouchSCIMToken = originalTouch
+	})
+
+	router := gin.New()
+	router.GET("/scim/v2/Users", RequireSCIMToken(), func(c *gin.Context) {
+		token, _ := GetSCIMToken(c)
+		c.JSON(http.StatusOK, gin.H{"organization_id": token.OrganizationID})
+	})
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{"유효한 토큰", "Bearer " + validToken, http.StatusOK},
+		{"헤더 없음", "", http.StatusUnauthorized},
+		{"폐기된 토큰", "Bearer " + revokedToken, http.StatusUnauthorized},
+		{"알 수 없는 토큰", "Bearer " + auth.SCIMTokenPrefix + "unknown", http.StatusUnauthorized},
+		{"개인 액세스 토큰", "Bearer " + patToken, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/scim/v2/Users", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusUnauthorized {
+				return
+			}
+
+			// SCIM 오류 형식 (status는 문자열)
+			assert.Equal(t, scim.ContentType, w.Header().Get("Content-Type"))
+			var body map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &body)
+			assert.Equal(t, "401", body["status"])
+			assert.Equal(t, []interface{}{scim.SchemaError}, body["schemas"])
+		})
+	}
+
+	assert.True(t, touched[1])
+	assert.False(t, touched[2])
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope는 개인 액세스 토큰이나 API 키로 요청한 경우 토큰에 scope 권한 범위가 있는지 확인합니다.
+// 로그인으로 발급된 액세스 토큰은 사용자 역할에 따른 권한을 그대로 가집니다.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := GetAuthToken(c)
+		if ok && !token.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "토큰에 필요한 권한 범위가 없습니다: " + scope,
+			})
+			c.Abort()
+			return
+		}
+		key, ok := GetAPIKey(c)
+		if ok && !key.HasScope(scope) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "API 키에 필요한 권한 범위가 없습니다: " + scope,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireSession은 로그인으로 발급된 액세스 토큰으로만 사용할 수 있는 엔드포인트에 대한 미들웨어입니다.
+// 토큰 관리나 2단계 인증 설정처럼 계정 자체를 변경하는 작업은 개인 액세스 토큰, API 키나 서비스 계정으로 할 수 없습니다.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetAuthToken(c); ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "개인 액세스 토큰으로는 사용할 수 없는 기능입니다",
+			})
+			c.Abort()
+			return
+		}
+		if _, ok := GetAPIKey(c); ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "API 키로는 사용할 수 없는 기능입니다",
+			})
+			c.Abort()
+			return
+		}
+		if _, ok := GetServiceAccount(c); ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "서비스 계정으로는 사용할 수 없는 기능입니다",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
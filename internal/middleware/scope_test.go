@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestPersonalAccessTokenScopes는 개인 액세스 토큰 인증과 권한 범위 확인을 테스트합니다.
+func TestPersonalAccessTokenScopes(t *testing.T) {
+	user := models.User{ID: 7, Username: "scriptuser", Role: "USER"}
+	router := setupAuthTest(t, map[int64]models.User{user.ID: user})
+	router.GET("/read", RequireScope(auth.ScopeUsersRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/write", RequireScope(auth.ScopeUsersWrite), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/session", RequireSession(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	readToken, readHash, _ := auth.GeneratePersonalAccessToken()
+	expiredToken, expiredHash, _ := auth.GeneratePersonalAccessToken()
+	revokedToken, revokedHash, _ := auth.GeneratePersonalAccessToken()
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tokens := map[string]models.PersonalAccessToken{
+		readHash:    {ID: 1, UserID: user.ID, Scopes: []string{auth.ScopeUsersRead}, ExpiresAt: time.Now().Add(time.Hour)},
+		expiredHash: {ID: 2, UserID: user.ID, Scopes: []string{auth.ScopeUsersRead}, ExpiresAt: time.Now().Add(-time.Hour)},
+		revokedHash: {ID: 3, UserID: user.ID, Scopes: []string{auth.ScopeUsersRead}, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+	}
+	touched := map[int64]bool{}
+
+	originalGet, originalTouch := repository.GetPersonalAccessTokenByHash, repository.TouchPersonalAccessToken
+	repository.GetPersonalAccessTokenByHash = func(hash string) (models.PersonalAccessToken, error) {
+		if token, ok := tokens[hash]; ok {
+			return token, nil
+		}
+		return models.PersonalAccessToken{}, gorm.ErrRecordNotFound
+	}
+	repository.TouchPersonalAccessToken = func(id int64, at time.Time) error {
+		touched[id] = true
+		return nil
+	}
+	t.Cleanup(func() {
+		repository.GetPersonalAccessTokenByHash = originalGet
+		repository.TouchPersonalAccessToken = originalTouch
+	})
+
+	sessionToken, _, _ := auth.GenerateAccessToken(user, "")
+
+	tests := []struct {
+		name           string
+		path           string
+		token          string
+		expectedStatus int
+	}{
+		{"권한 범위가 있는 토큰", "/read", readToken, http.StatusOK},
+		{"권한 범위가 없는 토큰", "/write", readToken, http.StatusForbidden},
+		{"세션 전용 기능", "/session", readToken, http.StatusForbidden},
+		{"만료된 토큰", "/read", expiredToken, http.StatusUnauthorized},
+		{"폐기된 토큰", "/read", revokedToken, http.StatusUnauthorized},
+		{"알 수 없는 토큰", "/read", auth.PersonalAccessTokenPrefix + "unknown", http.StatusUnauthorized},
+		{"로그인 토큰은 권한 범위 제한 없음", "/write", sessionToken, http.StatusOK},
+		{"로그인 토큰은 세션 전용 기능 사용 가능", "/session", sessionToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	assert.True(t, touched[1])
+	assert.False(t, touched[2])
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthServiceAccount는 클라이언트 인증서로 인증한 경우 서비스 계정 정보를 저장하는 키입니다.
+const AuthServiceAccount = "auth_service_account"
+
+// authenticateServiceAccount는 검증된 클라이언트 인증서에 매핑된 서비스 계정을 인증된 사용자로 컨텍스트에 저장합니다.
+func authenticateServiceAccount(c *gin.Context) {
+	account, ok := mtls.Authenticate(c.Request.TLS)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "인증서에 연결된 서비스 계정이 없습니다"})
+		c.Abort()
+		return
+	}
+
+	c.Set(AuthUser, account.Principal())
+	c.Set(AuthServiceAccount, account)
+	c.Next()
+}
+
+// GetServiceAccount는 클라이언트 인증서로 인증한 경우 컨텍스트에서 서비스 계정 정보를 가져옵니다.
+func GetServiceAccount(c *gin.Context) (*mtls.Account, bool) {
+	accountInterface, exists := c.Get(AuthServiceAccount)
+	if !exists {
+		return nil, false
+	}
+
+	account, ok := accountInterface.(*mtls.Account)
+	return account, ok
+}
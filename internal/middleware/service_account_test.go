@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls/mtlstest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServiceAccountAuth는 클라이언트 인증서로 서비스 계정을 인증하는 경우를 테스트합니다.
+func TestServiceAccountAuth(t *testing.T) {
+	user := models.User{ID: 7, Username: "realuser", Role: "USER"}
+	router := setupAuthTest(t, map[int64]models.User{user.ID: user})
+	router.GET("/users", RequirePermission(auth.PermUsersRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/delete", RequirePermission(auth.PermUsersDelete), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/session", RequireSession(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	account, err := mtls.NewAccount(config.ServiceAccountConfig{
+		Name:        "billing",
+		Subjects:    []string{"CN:billing"},
+		Permissions: []string{auth.PermUsersRead},
+	}, 1)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	mtls.SetAccounts(account)
+	t.Cleanup(func() { mtls.SetAccounts() })
+
+	ca := mtlstest.NewCA("test-ca")
+	connection := func(commonName string, verify bool) *tls.ConnectionState {
+		cert := ca.IssueClient(mtlstest.Subject{CommonName: commonName})
+		state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert.Leaf}}
+		if verify {
+			state.VerifiedChains = [][]*x509.Certificate{{cert.Leaf, ca.Certificate}}
+		}
+		return state
+	}
+	sessionToken, _, _ := auth.GenerateAccessToken(user, "")
+
+	tests := []struct {
+		name           string
+		path           string
+		tls            *tls.ConnectionState
+		token          string
+		expectedStatus int
+		expectedUser   string
+	}{
+		{name: "서비스 계정 인증", path: "/me", tls: connection("billing", true), expectedStatus: http.StatusOK, expectedUser: "billing"},
+		{name: "설정한 권한", path: "/users", tls: connection("billing", true), expectedStatus: http.StatusOK},
+		{name: "설정하지 않은 권한", path: "/delete", tls: connection("billing", true), expectedStatus: http.StatusForbidden},
+		{name: "세션 전용 기능", path: "/session", tls: connection("billing", true), expectedStatus: http.StatusForbidden},
+		{name: "연결된 서비스 계정 없음", path: "/me", tls: connection("unknown", true), expectedStatus: http.StatusUnauthorized},
+		{name: "검증되지 않은 인증서", path: "/me", tls: connection("billing", false), expectedStatus: http.StatusUnauthorized},
+		{name: "토큰이 있으면 토큰으로 인증", path: "/me", tls: connection("billing", true), token: sessionToken, expectedStatus: http.StatusOK, expectedUser: "realuser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.TLS = tt.tls
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUser != "" {
+				assert.Contains(t, w.Body.String(), `"username":"`+tt.expectedUser+`"`)
+			}
+		})
+	}
+}
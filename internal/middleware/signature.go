@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/pkg/signing"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthAPIKey는 HMAC 서명 요청으로 인증한 경우 API 키 정보를 저장하는 키입니다.
+const AuthAPIKey = "auth_api_key"
+
+// 서명을 확인하기 위해 읽는 요청 본문의 최대 크기
+const maxSignedBodySize = 10 << 20
+
+// authenticateSignedRequest는 API 키로 서명한 요청을 확인하고 사용자와 API 키 정보를 컨텍스트에 저장합니다.
+// 서명 시각이 허용 범위를 벗어났거나 같은 nonce를 다시 사용한 요청은 거부합니다.
+func authenticateSignedRequest(c *gin.Context, header string) {
+	authorization, err := signing.ParseAuthorization(header)
+	if err != nil || len(authorization.Nonce) > auth.MaxNonceLength {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "잘못된 서명 형식입니다"})
+		c.Abort()
+		return
+	}
+	if !auth.WithinSignatureSkew(authorization.Time()) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "요청 서명 시각이 허용 범위를 벗어났습니다"})
+		c.Abort()
+		return
+	}
+
+	key, err := repository.GetAPIKeyByKeyID(authorization.KeyID)
+	if err != nil || key.RevokedAt != nil || time.Now().After(key.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 API 키입니다"})
+		c.Abort()
+		return
+	}
+
+	// 서명 확인 후 핸들러가 다시 읽을 수 있도록 본문을 되돌려 놓음
+	var body []byte
+	if c.Request.Body != nil {
+		body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodySize))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "요청 본문을 읽을 수 없습니다"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	stringToSign, err := signing.StringToSign(c.Request.Method, c.Request.URL.EscapedPath(), c.Request.URL.RawQuery,
+		authorization.Timestamp, authorization.Nonce, signing.HashBody(body))
+	if err != nil || !signing.Verify(key.Secret, stringToSign, authorization.Signature) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "요청 서명이 일치하지 않습니다"})
+		c.Abort()
+		return
+	}
+
+	// 서명이 맞는 요청만 nonce를 기록하여 다른 사람이 nonce를 미리 소진할 수 없게 함
+	first, err := auth.UseNonce(key.KeyID, authorization.Nonce, authorization.Time())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "요청 처리 중 오류가 발생했습니다"})
+		c.Abort()
+		return
+	}
+	if !first {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "이미 처리된 요청입니다"})
+		c.Abort()
+		return
+	}
+
+	user, ok := loadUser(key.UserID)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "유효하지 않은 API 키입니다"})
+		c.Abort()
+		return
+	}
+
+	// 요청마다 기록하지 않도록 일정 간격 이상 지났을 때만 갱신
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= tokenTouchInterval {
+		repository.TouchAPIKey(key.ID, now)
+	}
+
+	c.Set(AuthUser, user)
+	c.Set(AuthAPIKey, key)
+	c.Next()
+}
+
+// GetAPIKey는 HMAC 서명 요청으로 인증한 경우 컨텍스트에서 API 키 정보를 가져옵니다.
+func GetAPIKey(c *gin.Context) (models.APIKey, bool) {
+	keyInterface, exists := c.Get(AuthAPIKey)
+	if !exists {
+		return models.APIKey{}, false
+	}
+
+	key, ok := keyInterface.(models.APIKey)
+	return key, ok
+}
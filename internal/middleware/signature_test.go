@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/pkg/signing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestSignedRequestAuth는 API 키로 서명한 요청의 인증을 테스트합니다.
+func TestSignedRequestAuth(t *testing.T) {
+	user := models.User{ID: 7, Username: "partner", Role: "USER"}
+	router := setupAuthTest(t, map[int64]models.User{user.ID: user})
+	router.POST("/echo", RequireScope(auth.ScopeUsersWrite), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+	router.GET("/read", RequireScope(auth.ScopeUsersRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/session", RequireSession(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	revokedAt := time.Now().Add(-time.Minute)
+	keys := map[string]models.APIKey{
+		"gqk_valid":   {ID: 1, UserID: user.ID, KeyID: "gqk_valid", Secret: "s3cret", Scopes: []string{auth.ScopeUsersWrite}, ExpiresAt: time.Now().Add(time.Hour)},
+		"gqk_expired": {ID: 2, UserID: user.ID, KeyID: "gqk_expired", Secret: "s3cret", ExpiresAt: time.Now().Add(-time.Hour)},
+		"gqk_revoked": {ID: 3, UserID: user.ID, KeyID: "gqk_revoked", Secret: "s3cret", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+	}
+	originalGet, originalTouch := repository.GetAPIKeyByKeyID, repository.TouchAPIKey
+	originalRecord, originalDelete := repository.RecordAPINonce, repository.DeleteExpiredAPINonces
+	repository.GetAPIKeyByKeyID = func(keyID string) (models.APIKey, error) {
+		if key, ok := keys[keyID]; ok {
+			return key, nil
+		}
+		return models.APIKey{}, gorm.ErrRecordNotFound
+	}
+	repository.TouchAPIKey = func(id int64, at time.Time) error { return nil }
+	recorded := map[string]bool{}
+	repository.RecordAPINonce = func(keyID, nonce string, expiresAt time.Time) (bool, error) {
+		if recorded[keyID+nonce] {
+			return false, nil
+		}
+		recorded[keyID+nonce] = true
+		return true, nil
+	}
+	repository.DeleteExpiredAPINonces = func(now time.Time) error { return nil }
+	t.Cleanup(func() {
+		repository.GetAPIKeyByKeyID = originalGet
+		repository.TouchAPIKey = originalTouch
+		repository.RecordAPINonce = originalRecord
+		repository.DeleteExpiredAPINonces = originalDelete
+	})
+
+	// signed는 서명한 요청을 만듭니다.
+	signed := func(signer signing.Signer, method, path, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if err := signer.Sign(req); err != nil {
+			t.Fatalf("예상하지 못한 오류: %v", err)
+		}
+		return req
+	}
+	valid := signing.Signer{KeyID: "gqk_valid", Secret: "s3cret"}
+
+	// 1. 서명이 맞으면 인증되고 핸들러는 본문을 그대로 읽음
+	w := httptest.NewRecorder()
+	req := signed(valid, "POST", "/echo?b=2&a=1", `{"hello":"world"}`)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"hello":"world"}`, w.Body.String())
+
+	// 2. 같은 요청을 다시 보내면 거부
+	replay := httptest.NewRequest("POST", "/echo?b=2&a=1", strings.NewReader(`{"hello":"world"}`))
+	replay.Header.Set("Authorization", req.Header.Get("Authorization"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, replay)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 3. 서명 후 본문, 경로, 쿼리를 바꾸면 거부
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"본문 변조", "POST", "/echo?b=2&a=1", `{"hello":"admin"}`},
+		{"쿼리 변조", "POST", "/echo?b=2&a=2", `{"hello":"world"}`},
+		{"메서드 변조", "PUT", "/echo?b=2&a=1", `{"hello":"world"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := signed(valid, "POST", "/echo?b=2&a=1", `{"hello":"world"}`)
+			tampered := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			tampered.Header.Set("Authorization", original.Header.Get("Authorization"))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tampered)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+
+	// 4. 키와 시각 확인
+	cases := []struct {
+		name           string
+		signer         signing.Signer
+		path           string
+		expectedStatus int
+	}{
+		{"다른 비밀 값", signing.Signer{KeyID: "gqk_valid", Secret: "wrong"}, "/echo", http.StatusUnauthorized},
+		{"알 수 없는 키", signing.Signer{KeyID: "gqk_unknown", Secret: "s3cret"}, "/echo", http.StatusUnauthorized},
+		{"만료된 키", signing.Signer{KeyID: "gqk_expired", Secret: "s3cret"}, "/echo", http.StatusUnauthorized},
+		{"폐기된 키", signing.Signer{KeyID: "gqk_revoked", Secret: "s3cret"}, "/echo", http.StatusUnauthorized},
+		{"허용 범위를 벗어난 과거 시각", signing.Signer{KeyID: "gqk_valid", Secret: "s3cret", Now: func() time.Time { return time.Now().Add(-time.Hour) }}, "/echo", http.StatusUnauthorized},
+		{"허용 범위를 벗어난 미래 시각", signing.Signer{KeyID: "gqk_valid", Secret: "s3cret", Now: func() time.Time { return time.Now().Add(time.Hour) }}, "/echo", http.StatusUnauthorized},
+		{"허용 범위 안의 시각 차이", signing.Signer{KeyID: "gqk_valid", Secret: "s3cret", Now: func() time.Time { return time.Now().Add(-time.Minute) }}, "/echo", http.StatusOK},
+		{"권한 범위가 없는 키", valid, "/read", http.StatusForbidden},
+		{"세션 전용 기능", valid, "/session", http.StatusForbidden},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			method := "POST"
+			if tt.path != "/echo" {
+				method = "GET"
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, signed(tt.signer, method, tt.path, ""))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	// 5. 잘못된 서명 헤더
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/read", nil)
+	req.Header.Set("Authorization", signing.Algorithm+" KeyId=gqk_valid")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 6. 너무 긴 nonce는 거부
+	w = httptest.NewRecorder()
+	req = signed(valid, "POST", "/echo", "")
+	req.Header.Set("Authorization", strings.Replace(req.Header.Get("Authorization"), "Nonce=", "Nonce="+strings.Repeat("n", auth.MaxNonceLength), 1))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// 7. nonce를 기록하지 못하면 요청을 처리하지 않음
+	repository.RecordAPINonce = func(keyID, nonce string, expiresAt time.Time) (bool, error) {
+		return false, errors.New("데이터베이스 오류")
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, signed(valid, "POST", "/echo", ""))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// APIKey는 파트너 시스템 등이 HMAC 서명 요청에 사용하는 API 키를 나타냅니다.
+// 서버도 서명을 계산해야 하므로 비밀 값은 해시가 아닌 원문으로 저장하며, 생성 시 한 번만 보여줍니다.
+type APIKey struct {
+	ID         int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt  *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID     int64      `json:"user_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	KeyID      string     `json:"key_id" gorm:"size:32;not null;uniqueIndex"`
+	Secret     string     `json:"-" gorm:"size:64;not null"`
+	Scopes     []string   `json:"scopes" gorm:"type:text;serializer:json"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	User       User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName은 APIKey의 테이블 이름을 지정합니다.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// APINonce는 서명 요청에 사용된 nonce입니다. 같은 요청을 다시 보내는 것을 막기 위해
+// 서명 시각이 허용 범위를 벗어나는 시각(ExpiresAt)까지 보관합니다.
+type APINonce struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	KeyID     string     `json:"key_id" gorm:"size:32;not null;uniqueIndex:idx_api_nonce_key_nonce"`
+	Nonce     string     `json:"nonce" gorm:"size:64;not null;uniqueIndex:idx_api_nonce_key_nonce"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName은 APINonce의 테이블 이름을 지정합니다.
+func (APINonce) TableName() string {
+	return "api_nonces"
+}
+
+// HasScope는 API 키에 권한 범위가 부여되어 있는지 확인합니다.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateAPIKeyRequest는 API 키 생성 요청을 나타냅니다.
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"required,min=1"`
+}
+
+// CreateAPIKeyResponse는 API 키 생성 응답을 나타냅니다.
+// Secret은 이 응답에서만 제공됩니다.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// EmailVerificationToken은 회원 가입 이메일 인증 토큰을 나타냅니다.
+// 토큰 원문은 메일로만 전달하고 SHA-256 해시만 저장합니다.
+type EmailVerificationToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	User      User       `json:"-" gorm:"foreignKey:UserID"`
+}
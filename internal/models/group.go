@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Group은 조직 안의 사용자 그룹(부서, 팀)입니다.
+// 다른 그룹을 하위 그룹으로 포함할 수 있으며, 하위 그룹의 구성원은 상위 그룹의 구성원으로도 간주됩니다.
+type Group struct {
+	ID             int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt      *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	OrganizationID int64      `json:"organization_id" gorm:"not null;uniqueIndex:idx_group_organization_name"`
+	Name           string     `json:"name" gorm:"size:100;not null;uniqueIndex:idx_group_organization_name"`
+	Description    string     `json:"description" gorm:"size:255"`
+	// ExternalID는 SCIM으로 프로비저닝한 ID 공급자가 지정한 식별자입니다.
+	ExternalID string `json:"external_id,omitempty" gorm:"size:255;index"`
+	// Role이 지정되면 그룹의 모든 구성원이 이 역할의 권한을 추가로 가집니다.
+	Role      string  `json:"role,omitempty" gorm:"size:50"`
+	Users     []User  `json:"users,omitempty" gorm:"many2many:group_users"`
+	Subgroups []Group `json:"subgroups,omitempty" gorm:"many2many:group_subgroups;joinForeignKey:ParentID;joinReferences:ChildID"`
+}
+
+// CreateGroupRequest는 그룹 생성 요청을 나타냅니다.
+type CreateGroupRequest struct {
+	Name        string `json:"name" binding:"required,min=2,max=100"`
+	Description string `json:"description" binding:"max=255"`
+	// OrganizationID를 지정하지 않으면 요청한 관리자의 조직에 생성됩니다.
+	OrganizationID int64 `json:"organization_id"`
+}
+
+// UpdateGroupRequest는 그룹 정보 수정 요청을 나타냅니다.
+type UpdateGroupRequest struct {
+	Name        string `json:"name" binding:"omitempty,min=2,max=100"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+// AddGroupMemberRequest는 그룹 구성원 추가 요청을 나타냅니다. UserID와 GroupID 중 하나만 지정합니다.
+type AddGroupMemberRequest struct {
+	UserID  int64 `json:"user_id"`
+	GroupID int64 `json:"group_id"`
+}
+
+// GroupRoleRequest는 그룹 역할 지정 요청을 나타냅니다. Role이 비어 있으면 역할을 해제합니다.
+type GroupRoleRequest struct {
+	Role string `json:"role" binding:"max=50"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Identity는 외부 인증 공급자의 계정(공급자와 subject)을 사용자와 연결합니다.
+type Identity struct {
+	ID          int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID      int64      `json:"user_id" gorm:"not null;index"`
+	Provider    string     `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_identity_provider_subject"`
+	Subject     string     `json:"subject" gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject"`
+	Email       string     `json:"email" gorm:"size:100"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+}
+
+// OIDCLoginState는 외부 공급자로 보낸 로그인 요청의 상태입니다.
+// 콜백에서 한 번만 사용되며, state 원문은 브라우저에만 전달하고 SHA-256 해시만 저장합니다.
+type OIDCLoginState struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt    *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Provider     string     `json:"provider" gorm:"size:50;not null"`
+	StateHash    string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Nonce        string     `json:"-" gorm:"size:64;not null"`
+	CodeVerifier string     `json:"-" gorm:"size:128;not null"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName은 OIDCLoginState의 테이블 이름을 지정합니다.
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+// SAMLLoginRequest는 IdP로 보낸 SAML 인증 요청입니다.
+// 응답의 InResponseTo로 한 번만 사용되며, 요청 ID는 IdP로 보내는 주소에 포함되므로 원문으로 저장합니다.
+type SAMLLoginRequest struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Provider  string     `json:"provider" gorm:"size:50;not null"`
+	RequestID string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName은 SAMLLoginRequest의 테이블 이름을 지정합니다.
+func (SAMLLoginRequest) TableName() string {
+	return "saml_login_requests"
+}
+
+// SAMLAssertion은 로그인에 사용한 SAML 어설션 ID입니다. 같은 어설션을 다시 제출하는 것을 막기 위해
+// 어설션을 더 이상 받을 수 없는 시각(ExpiresAt)까지 보관합니다.
+type SAMLAssertion struct {
+	ID          int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Provider    string     `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_saml_assertion_provider_id"`
+	AssertionID string     `json:"assertion_id" gorm:"size:255;not null;uniqueIndex:idx_saml_assertion_provider_id"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName은 SAMLAssertion의 테이블 이름을 지정합니다.
+func (SAMLAssertion) TableName() string {
+	return "saml_assertions"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// ImpersonateRequest는 사용자 가장 요청을 나타냅니다.
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"` // 가장하는 이유 (예: 문의 번호)
+}
+
+// ImpersonationAudit는 관리자가 사용자로 가장한 기록을 나타냅니다.
+// 감사 기록이므로 관리자나 대상 사용자가 삭제되어도 남겨 둡니다.
+type ImpersonationAudit struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ActorID   int64      `json:"actor_id" gorm:"not null;index"`
+	TargetID  int64      `json:"target_id" gorm:"not null;index"`
+	// SessionID는 가장 토큰의 세션(sid)입니다.
+	SessionID string    `json:"session_id" gorm:"size:64;not null;index"`
+	Reason    string    `json:"reason" gorm:"size:255;not null"`
+	IPAddress string    `json:"ip_address" gorm:"size:50"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Invitation은 관리자가 이메일로 보낸 가입 초대를 나타냅니다.
+// 초대받은 사람은 메일의 링크로 사용자명과 비밀번호를 정해 가입하며, 토큰 원문은 메일로만 전달하고 SHA-256 해시만 저장합니다.
+type Invitation struct {
+	ID             int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt      *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	OrganizationID int64      `json:"organization_id" gorm:"not null;index"`
+	Email          string     `json:"email" gorm:"size:100;not null;index"`
+	Role           string     `json:"role" gorm:"size:50;not null"`
+	InvitedBy      int64      `json:"invited_by" gorm:"not null"`
+	TokenHash      string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	UserID         *int64     `json:"user_id,omitempty"` // 초대를 수락해 생성된 사용자
+}
+
+// CreateInvitationRequest는 초대 요청을 나타냅니다. Role을 지정하지 않으면 USER 역할로 초대합니다.
+type CreateInvitationRequest struct {
+	Email          string `json:"email" binding:"required,email,max=100"`
+	Role           string `json:"role" binding:"max=50"`
+	OrganizationID int64  `json:"organization_id"` // 다른 조직에 초대하려면 organizations:manage 권한 필요
+}
+
+// AcceptInvitationRequest는 초대 수락 요청을 나타냅니다.
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Password string `json:"password" binding:"required,max=255"` // 세부 규칙은 비밀번호 정책에서 검사
+}
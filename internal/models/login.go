@@ -10,21 +10,51 @@ type LoginRequest struct {
 
 // LoginResponse는 로그인 응답을 나타냅니다.
 type LoginResponse struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
-	Token    string `json:"token,omitempty"`
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	Token        string     `json:"token,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+}
+
+// ImpersonationResponse는 가장 토큰 발급 응답을 나타냅니다. 가장 토큰에는 리프레시 토큰이 없습니다.
+type ImpersonationResponse struct {
+	LoginResponse
+	ActorID int64 `json:"actor_id"` // 가장한 관리자 ID
 }
 
 // LoginHistory는 로그인 시도 기록을 나타냅니다.
+// 존재하지 않는 사용자명으로 로그인을 시도한 경우에도 기록하며, 이때 UserID는 nil입니다.
 type LoginHistory struct {
 	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
 	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
 	IPAddress string     `json:"ip_address" gorm:"size:50"`
-	LoginTime *time.Time `json:"login_time" gorm:"not null"`
+	LoginTime *time.Time `json:"login_time" gorm:"not null;index:IDX_login_history_user_time,priority:2"`
 	Success   bool       `json:"success" gorm:"not null"`
 	UserAgent string     `json:"user_agent" gorm:"size:255"`
-	UserID    int64      `json:"user_id" gorm:"not null"`
+	UserID    *int64     `json:"user_id" gorm:"index:IDX_login_history_user_time,priority:1"`
 	User      User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName은 초기화 스크립트와 같은 테이블 이름을 사용하도록 지정합니다.
+func (LoginHistory) TableName() string {
+	return "login_history"
+}
+
+// LoginHistoryFilter는 로그인 기록 조회 조건을 나타냅니다. 비어 있는 조건은 적용하지 않습니다.
+type LoginHistoryFilter struct {
+	Success   *bool
+	From      *time.Time // 이 시각 이후 (포함)
+	To        *time.Time // 이 시각 이전 (미포함)
+	IPAddress string
+}
+
+// LoginHistoryPage는 로그인 기록 조회 결과의 한 페이지를 나타냅니다.
+type LoginHistoryPage struct {
+	Items    []LoginHistory `json:"items"`
+	Total    int64          `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
 }
\ No newline at end of file
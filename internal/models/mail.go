@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// 발송 대기 메일 상태
+const (
+	MailStatusPending = "PENDING"
+	MailStatusSent    = "SENT"
+	MailStatusFailed  = "FAILED"
+)
+
+// OutboxMail은 발송 대기열(outbox)에 저장된 메일을 나타냅니다.
+// 요청 처리와 분리하여 저장한 뒤 발송기가 SMTP로 발송하며, 실패하면 재시도합니다.
+type OutboxMail struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt     *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Recipient     string     `json:"recipient" gorm:"size:255;not null"`
+	Subject       string     `json:"subject" gorm:"size:255;not null"`
+	Body          string     `json:"-" gorm:"type:text;not null"`
+	Status        string     `json:"status" gorm:"size:20;not null;index"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error" gorm:"size:1000"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+	SentAt        *time.Time `json:"sent_at"`
+}
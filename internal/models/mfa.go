@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// RecoveryCode는 TOTP 기기를 사용할 수 없을 때 사용하는 일회용 복구 코드입니다.
+// 코드 원문은 저장하지 않고 SHA-256 해시만 저장합니다.
+type RecoveryCode struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null;index"`
+	UsedAt    *time.Time `json:"used_at"`
+	User      User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TOTPEnrollResponse는 TOTP 등록 시작 응답을 나타냅니다.
+type TOTPEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TOTPVerifyRequest는 TOTP 등록 확인 요청을 나타냅니다.
+type TOTPVerifyRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// TOTPVerifyResponse는 TOTP 활성화 응답을 나타냅니다. 복구 코드는 이 응답에서 한 번만 제공됩니다.
+type TOTPVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TOTPDisableRequest는 TOTP 해제 요청을 나타냅니다. Code에는 TOTP 코드나 복구 코드를 사용할 수 있습니다.
+type TOTPDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFAChallengeResponse는 2단계 인증이 필요한 사용자의 로그인 응답을 나타냅니다.
+type MFAChallengeResponse struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// MFALoginRequest는 2단계 인증 완료 요청을 나타냅니다. Code에는 TOTP 코드나 복구 코드를 사용할 수 있습니다.
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -0,0 +1,114 @@
+package models
+
+import "time"
+
+// OAuth2 그랜트 유형
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+// OAuthClient는 이 서비스에 로그인을 위임하는 애플리케이션(OAuth2 클라이언트)입니다.
+// 클라이언트 비밀 키는 등록할 때 한 번만 보여주고 SHA-256 해시만 저장합니다.
+// 비밀 키가 없는 공개 클라이언트는 인가 코드 그랜트만 사용할 수 있습니다.
+type OAuthClient struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt    *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ClientID     string     `json:"client_id" gorm:"size:64;not null;uniqueIndex"`
+	SecretHash   string     `json:"-" gorm:"size:64"`
+	Name         string     `json:"name" gorm:"size:100;not null"`
+	RedirectURIs []string   `json:"redirect_uris" gorm:"type:text;serializer:json"`
+	GrantTypes   []string   `json:"grant_types" gorm:"type:text;serializer:json"`
+	Scopes       []string   `json:"scopes" gorm:"type:text;serializer:json"`
+	Public       bool       `json:"public" gorm:"not null;default:false"`
+	CreatedBy    int64      `json:"created_by" gorm:"not null"`
+}
+
+// TableName은 OAuthClient의 테이블 이름을 지정합니다.
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// AllowsGrant는 클라이언트가 그랜트 유형을 사용할 수 있는지 확인합니다.
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return containsString(c.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI는 리다이렉트 URI가 등록된 값과 정확히 일치하는지 확인합니다.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return containsString(c.RedirectURIs, uri)
+}
+
+// AllowsScope는 클라이언트가 권한 범위를 요청할 수 있는지 확인합니다.
+func (c *OAuthClient) AllowsScope(scope string) bool {
+	return containsString(c.Scopes, scope)
+}
+
+// containsString은 목록에 값이 있는지 확인합니다.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthAuthorizationCode는 사용자가 클라이언트에 발급한 인가 코드입니다.
+// 토큰 엔드포인트에서 한 번만 교환할 수 있으며, 코드 원문은 저장하지 않습니다.
+type OAuthAuthorizationCode struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt     *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	CodeHash      string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ClientID      string     `json:"client_id" gorm:"size:64;not null;index"`
+	UserID        int64      `json:"user_id" gorm:"not null"`
+	RedirectURI   string     `json:"redirect_uri" gorm:"size:500;not null"`
+	Scope         string     `json:"scope" gorm:"size:500;not null"`
+	Nonce         string     `json:"-" gorm:"size:255"`
+	CodeChallenge string     `json:"-" gorm:"size:128;not null"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName은 OAuthAuthorizationCode의 테이블 이름을 지정합니다.
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+// CreateOAuthClientRequest는 OAuth2 클라이언트 등록 요청을 나타냅니다.
+// Scopes를 지정하지 않으면 openid, profile, email 범위를 사용할 수 있습니다.
+type CreateOAuthClientRequest struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" binding:"dive,required,max=500"`
+	GrantTypes   []string `json:"grant_types" binding:"required,min=1,dive,required"`
+	Scopes       []string `json:"scopes" binding:"dive,required,max=100"`
+	Public       bool     `json:"public"`
+}
+
+// CreateOAuthClientResponse는 OAuth2 클라이언트 등록 응답을 나타냅니다.
+// ClientSecret은 이 응답에서만 제공되며 공개 클라이언트에는 없습니다.
+type CreateOAuthClientResponse struct {
+	OAuthClient
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// AuthorizeRequest는 로그인한 사용자가 클라이언트에 인가 코드를 발급하는 요청입니다.
+// 항목은 OAuth2 인가 요청의 쿼리 파라미터와 같습니다.
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" form:"response_type"`
+	ClientID            string `json:"client_id" form:"client_id"`
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri"`
+	Scope               string `json:"scope" form:"scope"`
+	State               string `json:"state" form:"state"`
+	Nonce               string `json:"nonce" form:"nonce"`
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method"`
+}
+
+// OAuthTokenResponse는 토큰 엔드포인트의 응답입니다.
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+	IDToken     string `json:"id_token,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Organization은 사용자가 속한 고객사(테넌트)입니다.
+type Organization struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt *time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Name      string     `json:"name" gorm:"size:100;not null;uniqueIndex"`
+}
+
+// OrganizationRequest는 조직 생성 및 수정 요청을 나타냅니다.
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required,min=2,max=100"`
+}
+
+// AssignOrganizationRequest는 사용자의 조직 변경 요청을 나타냅니다.
+type AssignOrganizationRequest struct {
+	OrganizationID int64 `json:"organization_id" binding:"required"`
+}
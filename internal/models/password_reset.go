@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PasswordResetToken은 비밀번호 재설정 토큰을 나타냅니다.
+// 토큰 원문은 메일로만 전달하고 SHA-256 해시만 저장합니다.
+type PasswordResetToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	User      User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// ForgotPasswordRequest는 비밀번호 재설정 메일 요청을 나타냅니다.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+// ResetPasswordRequest는 비밀번호 재설정 요청을 나타냅니다.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=255"` // 세부 규칙은 비밀번호 정책에서 검사
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// PersonalAccessToken은 자동화 스크립트 등에서 사용하는 개인 액세스 토큰을 나타냅니다.
+// 토큰 원문은 생성 시 한 번만 보여주고 SHA-256 해시만 저장합니다.
+type PersonalAccessToken struct {
+	ID          int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID      int64      `json:"user_id" gorm:"not null;index"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	TokenHash   string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	TokenPrefix string     `json:"token_prefix" gorm:"size:16;not null"` // 목록에서 토큰을 구분하기 위한 앞부분
+	Scopes      []string   `json:"scopes" gorm:"type:text;serializer:json"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	User        User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// HasScope는 토큰에 권한 범위가 부여되어 있는지 확인합니다.
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatePersonalAccessTokenRequest는 개인 액세스 토큰 생성 요청을 나타냅니다.
+type CreatePersonalAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"required,min=1"`
+}
+
+// CreatePersonalAccessTokenResponse는 개인 액세스 토큰 생성 응답을 나타냅니다.
+// Token은 이 응답에서만 제공됩니다.
+type CreatePersonalAccessTokenResponse struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Role은 권한 묶음인 역할을 나타냅니다. 사용자의 Role 필드에 역할 이름이 저장됩니다.
+type Role struct {
+	ID          int64        `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   *time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	Name        string       `json:"name" gorm:"size:50;not null;uniqueIndex"`
+	Description string       `json:"description" gorm:"size:255"`
+	Builtin     bool         `json:"builtin" gorm:"not null;default:false"` // 기본 역할은 삭제할 수 없음
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions"`
+}
+
+// Permission은 API 접근에 필요한 이름 있는 권한을 나타냅니다.
+// 권한 목록은 코드에서 정의되며 서버 시작 시 데이터베이스에 등록됩니다.
+type Permission struct {
+	ID          int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string `json:"description" gorm:"size:255"`
+}
+
+// CreateRoleRequest는 역할 생성 요청을 나타냅니다.
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRolePermissionsRequest는 역할의 권한 목록 변경 요청을 나타냅니다.
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// AssignRoleRequest는 사용자 역할 지정 요청을 나타냅니다.
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required,max=50"`
+}
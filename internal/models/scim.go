@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// SCIMToken은 ID 공급자가 SCIM 프로비저닝 API를 호출할 때 사용하는 조직 단위의 토큰입니다.
+// 토큰 원문은 생성 시 한 번만 보여주고 SHA-256 해시만 저장합니다.
+type SCIMToken struct {
+	ID          int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Name        string     `json:"name" gorm:"size:100;not null"`
+	TokenHash   string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	TokenPrefix string     `json:"token_prefix" gorm:"size:16;not null"` // 목록에서 토큰을 구분하기 위한 앞부분
+	// OrganizationID는 토큰으로 프로비저닝하는 조직입니다. 토큰은 이 조직의 사용자와 그룹만 다룹니다.
+	OrganizationID int64      `json:"organization_id" gorm:"not null;index"`
+	CreatedBy      int64      `json:"created_by" gorm:"not null"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+}
+
+// TableName은 SCIMToken의 테이블 이름을 지정합니다.
+func (SCIMToken) TableName() string {
+	return "scim_tokens"
+}
+
+// CreateSCIMTokenRequest는 SCIM 토큰 발급 요청을 나타냅니다.
+type CreateSCIMTokenRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// OrganizationID를 지정하지 않으면 요청한 관리자의 조직의 토큰을 발급합니다.
+	OrganizationID int64 `json:"organization_id"`
+}
+
+// CreateSCIMTokenResponse는 SCIM 토큰 발급 응답을 나타냅니다.
+// Token은 이 응답에서만 제공됩니다.
+type CreateSCIMTokenResponse struct {
+	SCIMToken
+	Token string `json:"token"`
+}
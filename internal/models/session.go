@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/pkg/useragent"
+)
+
+// Session은 로그인 세션을 나타냅니다. 로그인할 때 만들어지며, 세션에서 발급된 액세스 토큰의 sid 클레임과
+// 리프레시 토큰의 FamilyID가 세션의 FamilyID와 같습니다.
+type Session struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	FamilyID  string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	IPAddress string     `json:"ip_address" gorm:"size:50"`
+	UserAgent string     `json:"user_agent" gorm:"size:255"`
+	// ImpersonatorID는 관리자가 사용자로 가장하여 만든 세션이면 가장한 관리자 ID입니다.
+	ImpersonatorID int64 `json:"impersonator_id,omitempty" gorm:"not null;default:0"`
+	// LastUsedAt은 세션의 토큰으로 마지막으로 요청한 시각이며, ExpiresAt은 토큰을 갱신할 때마다 연장됩니다.
+	LastUsedAt time.Time  `json:"last_used_at" gorm:"not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"-"`
+	User       User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// SessionResponse는 세션 목록의 항목을 나타냅니다.
+type SessionResponse struct {
+	Session
+	Device useragent.Device `json:"device"`
+	// Current는 요청에 사용한 토큰이 이 세션에서 발급되었는지 나타냅니다.
+	Current bool `json:"current"`
+}
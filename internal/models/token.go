@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// RefreshToken은 발급된 리프레시 토큰을 나타냅니다.
+// 토큰 원문은 저장하지 않고 SHA-256 해시만 저장합니다.
+type RefreshToken struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	FamilyID  string     `json:"family_id" gorm:"size:64;not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	User      User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// RefreshTokenRequest는 토큰 갱신 요청을 나타냅니다.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenRevocation은 서버 측에서 폐기된 액세스 토큰을 나타냅니다.
+// SessionID가 있으면 해당 세션에서 발급된 모든 토큰이, JTI와 SessionID가 모두 비어 있으면
+// RevokedAt 이전에 해당 사용자에게 발급된 모든 토큰이 폐기된 것입니다.
+type TokenRevocation struct {
+	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt *time.Time `json:"created_at" gorm:"autoCreateTime"`
+	JTI       string     `json:"jti" gorm:"column:jti;size:64;index"`
+	SessionID string     `json:"session_id,omitempty" gorm:"size:64;index"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+	RevokedAt time.Time  `json:"revoked_at" gorm:"not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+}
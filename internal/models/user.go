@@ -10,21 +10,81 @@ type User struct {
 	Username  string     `json:"username" gorm:"size:50;unique;not null"`
 	Email     string     `json:"email" gorm:"size:100;not null"`
 	Password  string     `json:"-" gorm:"size:255;not null"` // JSON 응답에서 제외
-	Role      string     `json:"role" gorm:"size:20;not null"`
+	Role      string     `json:"role" gorm:"size:50;not null"`
+	// OrganizationID는 사용자가 속한 조직입니다. 사용자 관리 API는 같은 조직의 사용자만 조회합니다.
+	OrganizationID int64 `json:"organization_id" gorm:"not null;default:0;index"`
+	// LockoutResetAt 이전의 로그인 실패는 계정 잠금 계산에서 제외됩니다 (관리자 잠금 해제).
+	LockoutResetAt *time.Time `json:"-"`
+	// TOTP 2단계 인증 정보. TOTPSecret은 등록 중이거나 활성화된 경우에만 설정됩니다.
+	MFAEnabled   bool   `json:"mfa_enabled" gorm:"not null;default:false"`
+	TOTPSecret   string `json:"-" gorm:"size:64"`
+	TOTPLastStep int64  `json:"-" gorm:"not null;default:0"`
+	// Status는 계정 상태입니다. 회원 가입 후 이메일 인증 전까지 PENDING이며, SCIM으로 비활성화하면 DISABLED입니다.
+	Status string `json:"status" gorm:"size:20;not null;default:'ACTIVE'"`
+	// ExternalID는 SCIM으로 프로비저닝한 ID 공급자가 지정한 식별자입니다.
+	ExternalID string `json:"external_id,omitempty" gorm:"size:255;index"`
+	// Groups는 사용자가 직접 또는 하위 그룹을 통해 속한 그룹 목록입니다.
+	Groups []Group `json:"groups,omitempty" gorm:"many2many:group_users"`
+	// Identities는 사용자와 연결된 외부 인증 공급자 계정 목록입니다.
+	Identities []Identity `json:"identities,omitempty" gorm:"foreignKey:UserID"`
+	// ImpersonatedBy는 관리자가 이 사용자로 가장하여 요청한 경우 실제로 요청한 관리자입니다. 저장되지 않습니다.
+	ImpersonatedBy *User `json:"-" gorm:"-"`
+}
+
+// 사용자 계정 상태
+const (
+	UserStatusActive   = "ACTIVE"
+	UserStatusPending  = "PENDING"
+	UserStatusDisabled = "DISABLED"
+)
+
+// Actor는 실제로 요청한 사용자를 반환합니다. 가장한 요청이면 가장한 관리자, 아니면 자기 자신입니다.
+func (u *User) Actor() User {
+	if u.ImpersonatedBy != nil {
+		return *u.ImpersonatedBy
+	}
+	return *u
+}
+
+// IsActive는 사용자가 로그인하고 토큰을 사용할 수 있는 상태인지 확인합니다.
+func (u *User) IsActive() bool {
+	return u.Status != UserStatusPending && u.Status != UserStatusDisabled
 }
 
 // CreateUserRequest는 사용자 생성 요청을 나타냅니다.
 type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=50"`
 	Email    string `json:"email" binding:"required,email,max=100"`
-	Password string `json:"password" binding:"required,min=6,max=255"`
-	Role     string `json:"role" binding:"required,oneof=USER ADMIN"`
+	Password string `json:"password" binding:"required,max=255"` // 세부 규칙은 비밀번호 정책에서 검사
+	Role     string `json:"role" binding:"required,max=50"`      // 등록된 역할인지는 핸들러에서 검사
+	// OrganizationID를 지정하지 않으면 요청한 관리자의 조직에 생성됩니다.
+	OrganizationID int64 `json:"organization_id"`
+}
+
+// RegisterRequest는 회원 가입 요청을 나타냅니다.
+// 사용자명, 이메일, 비밀번호 규칙은 CreateUserRequest와 같으며 역할은 항상 USER입니다.
+type RegisterRequest struct {
+	Username     string `json:"username" binding:"required,min=3,max=50"`
+	Email        string `json:"email" binding:"required,email,max=100"`
+	Password     string `json:"password" binding:"required,max=255"` // 세부 규칙은 비밀번호 정책에서 검사
+	InviteCode   string `json:"invite_code"`
+	CaptchaToken string `json:"captcha_token"`
+}
+
+// VerifyEmailRequest는 이메일 인증 요청을 나타냅니다.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationRequest는 인증 메일 재발송 요청을 나타냅니다.
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email,max=100"`
 }
 
 // UpdateUserRequest는 사용자 업데이트 요청을 나타냅니다.
 type UpdateUserRequest struct {
 	Username string `json:"username" binding:"omitempty,min=3,max=50"`
 	Email    string `json:"email" binding:"omitempty,email,max=100"`
-	Password string `json:"password" binding:"omitempty,min=6,max=255"`
-	Role     string `json:"role" binding:"omitempty,oneof=USER ADMIN"`
+	Password string `json:"password" binding:"omitempty,max=255"` // 세부 규칙은 비밀번호 정책에서 검사
+	Role     string `json:"role" binding:"omitempty,max=50"`      // 등록된 역할인지는 핸들러에서 검사
 }
\ No newline at end of file
@@ -0,0 +1,191 @@
+// Package mtls는 클라이언트 인증서(mTLS)로 다른 서비스를 인증합니다.
+// 검증된 인증서의 주체(CN)나 주체 대체 이름(SAN)을 설정된 서비스 계정에 매핑합니다.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+	"github.com/choi-jiwoong/go-quickstart/internal/tenant"
+)
+
+// 인증서 주체 종류
+const (
+	SubjectCommonName = "CN"
+	SubjectDNS        = "DNS"
+	SubjectURI        = "URI"
+	SubjectEmail      = "EMAIL"
+)
+
+// Account는 클라이언트 인증서로 인증하는 서비스 계정입니다.
+type Account struct {
+	Name           string
+	Permissions    []string
+	OrganizationID int64
+
+	subjects []subject
+}
+
+// subject는 서비스 계정으로 인정할 인증서 주체 하나입니다.
+type subject struct {
+	kind  string
+	value string
+}
+
+// accounts는 설정 순서대로 나열된 서비스 계정입니다.
+var accounts []*Account
+
+// Init은 설정에서 서비스 계정을 구성합니다. 주체나 권한 형식이 잘못되었거나 조직이 없으면 오류를 반환합니다.
+func Init(cfg *config.Config) error {
+	var list []*Account
+	for _, ac := range cfg.MTLSServiceAccounts {
+		organizationID := tenant.DefaultOrganizationID()
+		if ac.Organization != "" {
+			org, err := repository.GetOrganizationByName(ac.Organization)
+			if err != nil {
+				return fmt.Errorf("서비스 계정 %s의 조직을 찾을 수 없습니다: %w", ac.Name, err)
+			}
+			organizationID = org.ID
+		}
+
+		account, err := NewAccount(ac, organizationID)
+		if err != nil {
+			return fmt.Errorf("서비스 계정 %s 설정 오류: %w", ac.Name, err)
+		}
+		list = append(list, account)
+	}
+	SetAccounts(list...)
+	return nil
+}
+
+// NewAccount는 설정으로 서비스 계정을 생성합니다.
+func NewAccount(cfg config.ServiceAccountConfig, organizationID int64) (*Account, error) {
+	if len(cfg.Subjects) == 0 {
+		return nil, errors.New("인증서 주체가 설정되지 않았습니다")
+	}
+	for _, p := range cfg.Permissions {
+		if !auth.ValidPermission(p) {
+			return nil, fmt.Errorf("존재하지 않는 권한입니다: %s", p)
+		}
+	}
+
+	account := &Account{Name: cfg.Name, Permissions: cfg.Permissions, OrganizationID: organizationID}
+	for _, s := range cfg.Subjects {
+		kind, value, ok := strings.Cut(s, ":")
+		kind = strings.ToUpper(strings.TrimSpace(kind))
+		if !ok || value == "" {
+			return nil, fmt.Errorf("잘못된 인증서 주체 형식입니다: %s", s)
+		}
+		switch kind {
+		case SubjectCommonName, SubjectDNS, SubjectURI, SubjectEmail:
+		default:
+			return nil, fmt.Errorf("지원하지 않는 인증서 주체 종류입니다: %s", kind)
+		}
+		account.subjects = append(account.subjects, subject{kind: kind, value: value})
+	}
+	return account, nil
+}
+
+// SetAccounts는 사용할 서비스 계정을 교체하고 서비스 계정 역할의 권한을 등록합니다.
+func SetAccounts(list ...*Account) {
+	permissions := make(map[string][]string, len(list))
+	for _, a := range list {
+		permissions[a.Name] = a.Permissions
+	}
+	accounts = list
+	auth.SetServiceRoles(permissions)
+}
+
+// Authenticate는 검증된 클라이언트 인증서에 매핑된 서비스 계정을 찾습니다.
+// 여러 서비스 계정이 일치하면 설정에서 먼저 나열한 계정을 사용합니다.
+func Authenticate(state *tls.ConnectionState) (*Account, bool) {
+	// 서버가 신뢰하는 CA로 검증한 인증서만 사용
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	cert := state.VerifiedChains[0][0]
+
+	for _, a := range accounts {
+		if a.Matches(cert) {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// Matches는 인증서의 주체나 주체 대체 이름이 서비스 계정의 주체 중 하나와 일치하는지 확인합니다.
+func (a *Account) Matches(cert *x509.Certificate) bool {
+	for _, s := range a.subjects {
+		switch s.kind {
+		case SubjectCommonName:
+			if cert.Subject.CommonName == s.value {
+				return true
+			}
+		case SubjectDNS:
+			for _, name := range cert.DNSNames {
+				if strings.EqualFold(name, s.value) {
+					return true
+				}
+			}
+		case SubjectURI:
+			for _, uri := range cert.URIs {
+				if uri.String() == s.value {
+					return true
+				}
+			}
+		case SubjectEmail:
+			for _, email := range cert.EmailAddresses {
+				if strings.EqualFold(email, s.value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Principal은 서비스 계정을 인증된 사용자로 나타냅니다.
+// 데이터베이스에 저장된 사용자가 아니므로 ID는 0이며, 권한은 서비스 계정 역할로 확인합니다.
+func (a *Account) Principal() models.User {
+	return models.User{
+		Username:       a.Name,
+		Role:           auth.ServiceRole(a.Name),
+		Status:         models.UserStatusActive,
+		OrganizationID: a.OrganizationID,
+	}
+}
+
+// ServerTLSConfig는 클라이언트 인증서를 요구하고 검증하는 리스너의 TLS 설정을 만듭니다.
+func ServerTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if cfg.MTLSCertFile == "" || cfg.MTLSKeyFile == "" || cfg.MTLSClientCAFile == "" {
+		return nil, errors.New("MTLS_CERT_FILE, MTLS_KEY_FILE, MTLS_CLIENT_CA_FILE을 모두 설정해야 합니다")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.MTLSCertFile, cfg.MTLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("서버 인증서를 읽을 수 없습니다: %w", err)
+	}
+	data, err := os.ReadFile(cfg.MTLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("클라이언트 CA 인증서를 읽을 수 없습니다: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("클라이언트 CA 파일에 인증서가 없습니다")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
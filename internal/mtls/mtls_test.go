@@ -0,0 +1,155 @@
+package mtls_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls"
+	"github.com/choi-jiwoong/go-quickstart/internal/mtls/mtlstest"
+	"github.com/stretchr/testify/assert"
+)
+
+// verified는 인증서를 CA로 검증한 연결 상태를 만듭니다.
+func verified(t *testing.T, ca *mtlstest.CA, cert tls.Certificate) *tls.ConnectionState {
+	chains, err := cert.Leaf.Verify(x509.VerifyOptions{Roots: ca.Pool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert.Leaf}, VerifiedChains: chains}
+}
+
+func TestNewAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.ServiceAccountConfig
+		wantErr bool
+	}{
+		{name: "정상", cfg: config.ServiceAccountConfig{Name: "billing", Subjects: []string{"CN:billing", "dns:billing.internal"}, Permissions: []string{auth.PermUsersRead}}},
+		{name: "주체 없음", cfg: config.ServiceAccountConfig{Name: "billing"}, wantErr: true},
+		{name: "잘못된 주체 형식", cfg: config.ServiceAccountConfig{Name: "billing", Subjects: []string{"billing"}}, wantErr: true},
+		{name: "지원하지 않는 주체 종류", cfg: config.ServiceAccountConfig{Name: "billing", Subjects: []string{"OU:billing"}}, wantErr: true},
+		{name: "존재하지 않는 권한", cfg: config.ServiceAccountConfig{Name: "billing", Subjects: []string{"CN:billing"}, Permissions: []string{"users:everything"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mtls.NewAccount(tt.cfg, 1)
+			assert.Equal(t, tt.wantErr, err != nil, "오류: %v", err)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ca := mtlstest.NewCA("test-ca")
+	billing, err := mtls.NewAccount(config.ServiceAccountConfig{
+		Name:        "billing",
+		Subjects:    []string{"CN:billing", "DNS:billing.internal"},
+		Permissions: []string{auth.PermUsersRead},
+	}, 3)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	reporting, err := mtls.NewAccount(config.ServiceAccountConfig{
+		Name:     "reporting",
+		Subjects: []string{"URI:spiffe://corp.example/reporting", "EMAIL:reporting@corp.example"},
+	}, 3)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	mtls.SetAccounts(billing, reporting)
+	t.Cleanup(func() { mtls.SetAccounts() })
+
+	tests := []struct {
+		name     string
+		subject  mtlstest.Subject
+		expected string
+	}{
+		{name: "CN", subject: mtlstest.Subject{CommonName: "billing"}, expected: "billing"},
+		{name: "DNS SAN은 대소문자 구분 안 함", subject: mtlstest.Subject{CommonName: "x", DNSNames: []string{"Billing.Internal"}}, expected: "billing"},
+		{name: "URI SAN", subject: mtlstest.Subject{URIs: []string{"spiffe://corp.example/reporting"}}, expected: "reporting"},
+		{name: "이메일 SAN", subject: mtlstest.Subject{Emails: []string{"reporting@corp.example"}}, expected: "reporting"},
+		{name: "일치하는 계정 없음", subject: mtlstest.Subject{CommonName: "unknown", DNSNames: []string{"billing.internal.evil"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, ok := mtls.Authenticate(verified(t, ca, ca.IssueClient(tt.subject)))
+			assert.Equal(t, tt.expected != "", ok)
+			if ok {
+				assert.Equal(t, tt.expected, account.Name)
+			}
+		})
+	}
+
+	// 검증되지 않은 인증서는 주체가 일치해도 사용하지 않음
+	cert := ca.IssueClient(mtlstest.Subject{CommonName: "billing"})
+	_, ok := mtls.Authenticate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert.Leaf}})
+	assert.False(t, ok)
+	_, ok = mtls.Authenticate(nil)
+	assert.False(t, ok)
+
+	// 서비스 계정 역할은 설정한 권한만 가짐
+	principal := billing.Principal()
+	assert.Equal(t, int64(0), principal.ID)
+	assert.Equal(t, int64(3), principal.OrganizationID)
+	assert.True(t, auth.HasPermission(principal, auth.PermUsersRead))
+	assert.False(t, auth.HasPermission(principal, auth.PermUsersDelete))
+	assert.False(t, auth.HasPermission(reporting.Principal(), auth.PermUsersRead))
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	ca := mtlstest.NewCA("test-ca")
+	dir := t.TempDir()
+	cfg := &config.Config{
+		MTLSCertFile:     filepath.Join(dir, "server.pem"),
+		MTLSKeyFile:      filepath.Join(dir, "server-key.pem"),
+		MTLSClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+	server := ca.IssueServer(mtlstest.Subject{CommonName: "localhost", IPs: []net.IP{net.ParseIP("127.0.0.1")}})
+	if err := mtlstest.WriteKeyPair(server, cfg.MTLSCertFile, cfg.MTLSKeyFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if err := ca.WriteCertificate(cfg.MTLSClientCAFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	tlsConfig, err := mtls.ServerTLSConfig(cfg)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.VerifiedChains[0][0].Subject.CommonName))
+	}))
+	ts.TLS = tlsConfig
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+
+	client := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: ca.Pool(), Certificates: certs}}}
+	}
+
+	// 같은 CA가 발급한 클라이언트 인증서
+	resp, err := client(ca.IssueClient(mtlstest.Subject{CommonName: "billing"})).Get(ts.URL)
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// 인증서가 없거나 다른 CA가 발급한 인증서는 연결 거부
+	_, err = client().Get(ts.URL)
+	assert.Error(t, err)
+	other := mtlstest.NewCA("other-ca")
+	_, err = client(other.IssueClient(mtlstest.Subject{CommonName: "billing"})).Get(ts.URL)
+	assert.Error(t, err)
+
+	// 파일이 설정되지 않으면 오류
+	_, err = mtls.ServerTLSConfig(&config.Config{})
+	assert.Error(t, err)
+}
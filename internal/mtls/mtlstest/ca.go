@@ -0,0 +1,141 @@
+// Package mtlstest는 테스트에서 사용하는 CA와 인증서를 실행 중에 생성합니다.
+package mtlstest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// serial은 발급한 인증서의 일련번호입니다.
+var serial atomic.Int64
+
+// CA는 테스트용 자체 서명 인증 기관입니다.
+type CA struct {
+	Certificate *x509.Certificate
+
+	key *ecdsa.PrivateKey
+}
+
+// Subject는 발급할 인증서의 주체와 주체 대체 이름입니다.
+type Subject struct {
+	CommonName string
+	DNSNames   []string
+	URIs       []string
+	Emails     []string
+	IPs        []net.IP
+}
+
+// NewCA는 새 키 쌍으로 자체 서명한 테스트 CA를 생성합니다.
+func NewCA(name string) *CA {
+	key := newKey()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial.Add(1)),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return &CA{Certificate: cert, key: key}
+}
+
+// CertificatePEM은 CA 인증서를 PEM 형식으로 반환합니다.
+func (ca *CA) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate.Raw})
+}
+
+// WriteCertificate는 CA 인증서를 PEM 파일로 저장합니다.
+func (ca *CA) WriteCertificate(path string) error {
+	return os.WriteFile(path, ca.CertificatePEM(), 0o600)
+}
+
+// Pool은 CA 인증서만 들어 있는 인증서 풀을 반환합니다.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Certificate)
+	return pool
+}
+
+// IssueClient는 클라이언트 인증에 사용할 인증서를 발급합니다.
+func (ca *CA) IssueClient(s Subject) tls.Certificate {
+	return ca.issue(s, x509.ExtKeyUsageClientAuth)
+}
+
+// IssueServer는 서버 인증에 사용할 인증서를 발급합니다.
+func (ca *CA) IssueServer(s Subject) tls.Certificate {
+	return ca.issue(s, x509.ExtKeyUsageServerAuth)
+}
+
+// issue는 CA 키로 서명한 인증서를 발급합니다.
+func (ca *CA) issue(s Subject, usage x509.ExtKeyUsage) tls.Certificate {
+	key := newKey()
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(serial.Add(1)),
+		Subject:        pkix.Name{CommonName: s.CommonName},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(24 * time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{usage},
+		DNSNames:       s.DNSNames,
+		EmailAddresses: s.Emails,
+		IPAddresses:    s.IPs,
+	}
+	for _, raw := range s.URIs {
+		uri, err := url.Parse(raw)
+		if err != nil {
+			panic(err)
+		}
+		template.URIs = append(template.URIs, uri)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Certificate, &key.PublicKey, ca.key)
+	if err != nil {
+		panic(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// WriteKeyPair는 인증서와 개인 키를 PEM 파일로 저장합니다.
+func WriteKeyPair(cert tls.Certificate, certFile, keyFile string) error {
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600); err != nil {
+		return err
+	}
+	return os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600)
+}
+
+// newKey는 P-256 키를 생성합니다.
+func newKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
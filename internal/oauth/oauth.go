@@ -0,0 +1,151 @@
+// Package oauth는 다른 애플리케이션에 로그인을 제공하는 OAuth2/OpenID Connect 인가 서버의 토큰 발급을 담당합니다.
+// ID 토큰과 액세스 토큰은 RSA 키로 서명하며, 클라이언트는 JWKS 엔드포인트의 공개 키로 검증합니다.
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OpenID Connect 표준 권한 범위
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// DefaultScopes는 권한 범위를 지정하지 않고 등록한 클라이언트가 요청할 수 있는 범위입니다.
+var DefaultScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+// ErrNotConfigured는 서명 키가 초기화되지 않았을 때 반환됩니다.
+var ErrNotConfigured = errors.New("OAuth 서명 키가 초기화되지 않았습니다")
+
+// server는 토큰 서명에 사용하는 키와 설정을 보관합니다.
+type server struct {
+	issuer    string
+	keyID     string
+	key       *rsa.PrivateKey
+	accessTTL time.Duration
+}
+
+var current *server
+
+// Init은 설정에 지정된 RSA 키로 인가 서버의 토큰 서명기를 초기화합니다.
+func Init(cfg *config.Config) error {
+	var key *rsa.PrivateKey
+	if cfg.OAuthSigningKeyFile != "" {
+		pem, err := os.ReadFile(cfg.OAuthSigningKeyFile)
+		if err != nil {
+			return fmt.Errorf("OAuth 서명 키 파일 읽기 실패: %w", err)
+		}
+		if key, err = jwt.ParseRSAPrivateKeyFromPEM(pem); err != nil {
+			return fmt.Errorf("RSA 개인 키 파싱 실패: %w", err)
+		}
+	} else {
+		log.Println("경고: OAUTH_SIGNING_KEY_FILE이 설정되지 않아 임시 RSA 키를 생성합니다")
+		var err error
+		if key, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			return err
+		}
+	}
+
+	current = &server{
+		issuer:    cfg.OAuthIssuer,
+		keyID:     cfg.OAuthKeyID,
+		key:       key,
+		accessTTL: cfg.OAuthAccessTokenTTL,
+	}
+	return nil
+}
+
+// Issuer는 발급자 주소를 반환합니다.
+func Issuer() string {
+	if current == nil {
+		return ""
+	}
+	return current.issuer
+}
+
+// Discovery는 /.well-known/openid-configuration 문서를 반환합니다.
+func Discovery() map[string]interface{} {
+	issuer := Issuer()
+	return map[string]interface{}{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"userinfo_endpoint":                     issuer + "/oauth/userinfo",
+		"jwks_uri":                              issuer + "/oauth/jwks",
+		"scopes_supported":                      DefaultScopes,
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code", "client_credentials"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported":                      []string{"sub", "iss", "aud", "exp", "iat", "nonce", "preferred_username", "email", "role", "organization_id"},
+	}
+}
+
+// JWKS는 토큰 검증에 사용하는 공개 키 목록을 JWK Set 형식으로 반환합니다.
+func JWKS() map[string]interface{} {
+	keys := []map[string]string{}
+	if current != nil {
+		pub := current.key.PublicKey
+		keys = append(keys, map[string]string{
+			"kty": "RSA",
+			"kid": current.keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		})
+	}
+	return map[string]interface{}{"keys": keys}
+}
+
+// ParseScope는 공백으로 구분된 권한 범위 문자열을 중복 없는 목록으로 바꿉니다.
+func ParseScope(scope string) []string {
+	var scopes []string
+	seen := map[string]bool{}
+	for _, s := range strings.Fields(scope) {
+		if !seen[s] {
+			seen[s] = true
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// HasScope는 권한 범위 목록에 scope가 있는지 확인합니다.
+func HasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyCodeChallenge는 PKCE 코드 검증값이 S256 코드 챌린지와 일치하는지 확인합니다.
+func VerifyCodeChallenge(verifier, challenge string) bool {
+	// RFC 7636: 코드 검증값은 43~128자
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
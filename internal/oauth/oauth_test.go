@@ -0,0 +1,132 @@
+package oauth_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/oauth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// initOAuth는 임시 서명 키로 인가 서버를 초기화합니다.
+func initOAuth(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.OAuthIssuer = "https://id.example.com"
+	cfg.OAuthSigningKeyFile = ""
+	if err := oauth.Init(cfg); err != nil {
+		t.Fatalf("OAuth 초기화 실패: %v", err)
+	}
+}
+
+// TestAccessToken은 사용자 토큰과 클라이언트 토큰의 발급과 검증을 테스트합니다.
+func TestAccessToken(t *testing.T) {
+	initOAuth(t)
+
+	token, expiresAt, err := oauth.IssueAccessToken("42", "client-a", []string{"openid", "email"})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	assert.True(t, expiresAt.After(time.Now()))
+
+	claims, err := oauth.ParseAccessToken(token)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	userID, ok := claims.UserID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), userID)
+	assert.Equal(t, "client-a", claims.ClientID)
+	assert.Equal(t, []string{"openid", "email"}, claims.Scopes())
+
+	// client_credentials 토큰에는 사용자가 없음
+	token, _, err = oauth.IssueAccessToken("client-b", "client-b", []string{"reports:read"})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	claims, err = oauth.ParseAccessToken(token)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	_, ok = claims.UserID()
+	assert.False(t, ok)
+}
+
+// TestIDTokenIsNotAccessToken은 ID 토큰을 액세스 토큰으로 사용할 수 없는지 테스트합니다.
+func TestIDTokenIsNotAccessToken(t *testing.T) {
+	initOAuth(t)
+
+	user := models.User{ID: 7, Username: "jdoe", Email: "jdoe@example.com", Role: "USER"}
+	idToken, err := oauth.IssueIDToken(user, "client-a", "nonce-1", []string{"openid", "email"})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	_, err = oauth.ParseAccessToken(idToken)
+	assert.ErrorIs(t, err, oauth.ErrInvalidToken)
+
+	// 발급한 ID 토큰의 클레임은 요청한 범위만 포함
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(idToken, claims)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	assert.Equal(t, "7", claims["sub"])
+	assert.Equal(t, "https://id.example.com", claims["iss"])
+	assert.Equal(t, "client-a", claims["aud"])
+	assert.Equal(t, "nonce-1", claims["nonce"])
+	assert.Equal(t, "jdoe@example.com", claims["email"])
+	assert.NotContains(t, claims, "preferred_username")
+}
+
+// TestAccessTokenFromOtherKeyRejected는 다른 키로 서명한 토큰이 거부되는지 테스트합니다.
+func TestAccessTokenFromOtherKeyRejected(t *testing.T) {
+	initOAuth(t)
+	token, _, err := oauth.IssueAccessToken("42", "client-a", []string{"openid"})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	// 서버가 다시 시작되어 새 임시 키가 생성된 경우
+	initOAuth(t)
+	_, err = oauth.ParseAccessToken(token)
+	assert.ErrorIs(t, err, oauth.ErrInvalidToken)
+}
+
+// TestVerifyCodeChallenge는 PKCE S256 코드 챌린지 확인을 테스트합니다.
+func TestVerifyCodeChallenge(t *testing.T) {
+	verifier := strings.Repeat("a", 43)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	assert.True(t, oauth.VerifyCodeChallenge(verifier, challenge))
+	assert.False(t, oauth.VerifyCodeChallenge(strings.Repeat("b", 43), challenge))
+	assert.False(t, oauth.VerifyCodeChallenge("", challenge))
+}
+
+// TestDiscoveryAndJWKS는 디스커버리 문서와 공개 키 목록을 테스트합니다.
+func TestDiscoveryAndJWKS(t *testing.T) {
+	initOAuth(t)
+
+	discovery := oauth.Discovery()
+	assert.Equal(t, "https://id.example.com", discovery["issuer"])
+	assert.Equal(t, "https://id.example.com/oauth/token", discovery["token_endpoint"])
+	assert.Equal(t, "https://id.example.com/oauth/jwks", discovery["jwks_uri"])
+
+	keys := oauth.JWKS()["keys"].([]map[string]string)
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, "RSA", keys[0]["kty"])
+		assert.Equal(t, "oauth-1", keys[0]["kid"])
+	}
+}
+
+// TestParseScope는 권한 범위 문자열 파싱을 테스트합니다.
+func TestParseScope(t *testing.T) {
+	assert.Equal(t, []string{"openid", "email"}, oauth.ParseScope(" openid  email openid "))
+	assert.Empty(t, oauth.ParseScope(""))
+}
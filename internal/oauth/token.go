@@ -0,0 +1,148 @@
+package oauth
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken은 인가 서버가 발급한 액세스 토큰이 아니거나 만료된 경우 반환됩니다.
+var ErrInvalidToken = errors.New("유효하지 않은 액세스 토큰입니다")
+
+// 액세스 토큰 헤더의 typ 값 (RFC 9068). ID 토큰을 액세스 토큰으로 사용할 수 없게 구분합니다.
+const accessTokenType = "at+jwt"
+
+// AccessClaims는 클라이언트에 발급하는 액세스 토큰의 클레임입니다.
+// 사용자 대신 발급한 토큰의 sub는 사용자 ID이고, client_credentials 토큰의 sub는 클라이언트 ID입니다.
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+// Scopes는 토큰에 부여된 권한 범위 목록을 반환합니다.
+func (c *AccessClaims) Scopes() []string {
+	return ParseScope(c.Scope)
+}
+
+// UserID는 사용자 대신 발급한 토큰이면 sub의 사용자 ID를 반환합니다.
+func (c *AccessClaims) UserID() (int64, bool) {
+	if c.Subject == c.ClientID {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	return id, err == nil
+}
+
+// UserClaims는 권한 범위에 따라 클라이언트에 제공하는 사용자 클레임을 반환합니다.
+// ID 토큰과 userinfo 응답에 같은 클레임이 사용됩니다.
+func UserClaims(user models.User, scopes []string) map[string]interface{} {
+	claims := map[string]interface{}{
+		"sub": strconv.FormatInt(user.ID, 10),
+	}
+	if HasScope(scopes, ScopeProfile) {
+		claims["preferred_username"] = user.Username
+		claims["role"] = user.Role
+		claims["organization_id"] = user.OrganizationID
+		if user.UpdatedAt != nil {
+			claims["updated_at"] = user.UpdatedAt.Unix()
+		}
+	}
+	if HasScope(scopes, ScopeEmail) {
+		claims["email"] = user.Email
+	}
+	return claims
+}
+
+// IssueIDToken은 사용자가 로그인한 클라이언트에 전달할 ID 토큰을 발급합니다.
+func IssueIDToken(user models.User, clientID, nonce string, scopes []string) (string, error) {
+	if current == nil {
+		return "", ErrNotConfigured
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{}
+	for k, v := range UserClaims(user, scopes) {
+		claims[k] = v
+	}
+	claims["iss"] = current.issuer
+	claims["aud"] = clientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(current.accessTTL).Unix()
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = current.keyID
+	return token.SignedString(current.key)
+}
+
+// IssueAccessToken은 클라이언트에 액세스 토큰을 발급하고 토큰과 만료 시각을 반환합니다.
+func IssueAccessToken(subject, clientID string, scopes []string) (string, time.Time, error) {
+	if current == nil {
+		return "", time.Time{}, ErrNotConfigured
+	}
+
+	jti, err := auth.RandomHex(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(current.accessTTL)
+	claims := AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    current.issuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{clientID},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+		ClientID: clientID,
+		Scope:    strings.Join(scopes, " "),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = current.keyID
+	token.Header["typ"] = accessTokenType
+
+	signed, err := token.SignedString(current.key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken은 인가 서버가 발급한 액세스 토큰의 서명, 유형, 발급자와 만료 시각을 검증합니다.
+func ParseAccessToken(tokenString string) (*AccessClaims, error) {
+	if current == nil {
+		return nil, ErrNotConfigured
+	}
+
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, _ := token.Header["kid"].(string); kid != current.keyID {
+			return nil, ErrInvalidToken
+		}
+		if typ, _ := token.Header["typ"].(string); typ != accessTokenType {
+			return nil, ErrInvalidToken
+		}
+		return &current.key.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(current.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil || claims.ClientID == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,119 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// providers는 이름별로 설정된 외부 공급자입니다.
+var providers = map[string]*Provider{}
+
+// Init은 설정에서 외부 공급자를 구성합니다.
+// 디스커버리 문서는 처음 로그인할 때 가져오므로 공급자에 연결할 수 없어도 서버는 시작됩니다.
+func Init(cfg *config.Config) error {
+	configured := map[string]*Provider{}
+	for _, pc := range cfg.OIDCProviders {
+		if pc.Issuer == "" || pc.ClientID == "" {
+			return fmt.Errorf("OIDC 공급자 %s에는 발급자 주소와 클라이언트 ID가 필요합니다", pc.Name)
+		}
+		configured[pc.Name] = NewProvider(pc)
+	}
+	providers = configured
+	return nil
+}
+
+// SetProviders는 사용할 외부 공급자를 교체합니다.
+func SetProviders(list ...*Provider) {
+	configured := map[string]*Provider{}
+	for _, p := range list {
+		configured[p.Name()] = p
+	}
+	providers = configured
+}
+
+// Get은 이름으로 외부 공급자를 찾습니다.
+func Get(name string) (*Provider, bool) {
+	p, ok := providers[name]
+	return p, ok
+}
+
+// Names는 설정된 외부 공급자 이름 목록을 이름순으로 반환합니다.
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewCodeVerifier는 PKCE 코드 검증값과 S256 방식의 코드 챌린지를 생성합니다.
+func NewCodeVerifier() (verifier, challenge string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(b)
+	return verifier, CodeChallenge(verifier), nil
+}
+
+// CodeChallenge는 코드 검증값의 S256 코드 챌린지를 반환합니다.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Claims는 검증된 ID 토큰의 클레임입니다.
+type Claims struct {
+	raw jwt.MapClaims
+}
+
+// Subject는 공급자 안에서 사용자를 식별하는 sub 클레임을 반환합니다.
+func (c Claims) Subject() string {
+	return c.String("sub")
+}
+
+// Email은 email 클레임을 반환합니다.
+func (c Claims) Email() string {
+	return c.String("email")
+}
+
+// EmailVerified는 공급자가 이메일 주소를 확인했는지 반환합니다.
+func (c Claims) EmailVerified() bool {
+	verified, _ := c.raw["email_verified"].(bool)
+	return verified
+}
+
+// PreferredUsername은 preferred_username 클레임을 반환합니다.
+func (c Claims) PreferredUsername() string {
+	return c.String("preferred_username")
+}
+
+// String은 문자열 클레임 값을 반환합니다. 없거나 문자열이 아니면 빈 문자열입니다.
+func (c Claims) String(name string) string {
+	value, _ := c.raw[name].(string)
+	return value
+}
+
+// Strings는 문자열 또는 문자열 배열 클레임 값을 목록으로 반환합니다.
+func (c Claims) Strings(name string) []string {
+	switch value := c.raw[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package oidc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc"
+	"github.com/choi-jiwoong/go-quickstart/internal/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestProvider는 모의 공급자와 그 공급자를 사용하는 Provider를 생성합니다.
+func newTestProvider(t *testing.T) (*oidctest.Server, *oidc.Provider) {
+	server := oidctest.NewServer("quickstart", "client-secret")
+	t.Cleanup(server.Close)
+
+	provider := oidc.NewProvider(config.OIDCProviderConfig{
+		Name:         "corp",
+		Issuer:       server.Issuer(),
+		ClientID:     "quickstart",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:8080/oidc/corp/callback",
+		Scopes:       []string{"openid", "email"},
+		RoleClaim:    "groups",
+		RoleMapping:  map[string]string{"admins": "ADMIN", "support": "SUPPORT"},
+		DefaultRole:  "USER",
+	})
+	return server, provider
+}
+
+// login은 모의 공급자에서 인가 코드를 받아 ID 토큰으로 교환합니다.
+func login(t *testing.T, server *oidctest.Server, provider *oidc.Provider, nonce string) (string, error) {
+	verifier, challenge, err := oidc.NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	authURL, err := provider.AuthCodeURL("state-1", nonce, challenge)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	callback, err := server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	assert.Equal(t, "state-1", callback.Query().Get("state"))
+
+	return provider.Exchange(callback.Query().Get("code"), verifier)
+}
+
+// TestAuthorizationCodeFlow는 PKCE 인가 코드 흐름과 ID 토큰 검증을 테스트합니다.
+func TestAuthorizationCodeFlow(t *testing.T) {
+	server, provider := newTestProvider(t)
+	server.SetUser(map[string]interface{}{
+		"sub":            "employee-1",
+		"email":          "employee@corp.example",
+		"email_verified": true,
+		"groups":         []string{"staff", "support"},
+	})
+
+	idToken, err := login(t, server, provider, "nonce-1")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	claims, err := provider.VerifyIDToken(idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	assert.Equal(t, "employee-1", claims.Subject())
+	assert.Equal(t, "employee@corp.example", claims.Email())
+	assert.True(t, claims.EmailVerified())
+	assert.Equal(t, "SUPPORT", provider.MapRole(claims))
+
+	// nonce가 다르면 거부
+	_, err = provider.VerifyIDToken(idToken, "other-nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+// TestExchangeRejectsWrongVerifier는 코드 검증값이 다르면 토큰 교환이 실패하는지 테스트합니다.
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	server, provider := newTestProvider(t)
+	server.SetUser(map[string]interface{}{"sub": "employee-1"})
+
+	_, challenge, err := oidc.NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	authURL, err := provider.AuthCodeURL("state-1", "nonce-1", challenge)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	callback, err := server.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	otherVerifier, _, err := oidc.NewCodeVerifier()
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	_, err = provider.Exchange(callback.Query().Get("code"), otherVerifier)
+	assert.Error(t, err)
+}
+
+// TestVerifyIDTokenRejects는 잘못된 ID 토큰이 거부되는지 테스트합니다.
+func TestVerifyIDTokenRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(claims jwt.MapClaims)
+	}{
+		{"다른 발급자", func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }},
+		{"다른 대상", func(c jwt.MapClaims) { c["aud"] = "other-client" }},
+		{"만료됨", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"여러 대상에 azp 불일치", func(c jwt.MapClaims) { c["aud"] = []string{"quickstart", "other-client"} }},
+		{"sub 없음", func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, provider := newTestProvider(t)
+			server.SetUser(map[string]interface{}{"sub": "employee-1"})
+			server.Tamper(tt.tamper)
+
+			idToken, err := login(t, server, provider, "nonce-1")
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+
+			_, err = provider.VerifyIDToken(idToken, "nonce-1")
+			assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+		})
+	}
+}
+
+// TestMapRoleDefault는 일치하는 클레임 값이 없으면 기본 역할을 사용하는지 테스트합니다.
+func TestMapRoleDefault(t *testing.T) {
+	server, provider := newTestProvider(t)
+	server.SetUser(map[string]interface{}{"sub": "employee-1", "groups": "staff"})
+
+	idToken, err := login(t, server, provider, "nonce-1")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	claims, err := provider.VerifyIDToken(idToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	assert.Equal(t, "USER", provider.MapRole(claims))
+}
@@ -0,0 +1,262 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 외부 로그인 관련 오류
+var (
+	ErrInvalidIDToken = errors.New("유효하지 않은 ID 토큰입니다")
+	ErrUnknownKey     = errors.New("ID 토큰 서명 키를 찾을 수 없습니다")
+)
+
+// 서명 키 목록을 다시 가져오는 최소 간격 (알 수 없는 키 ID로 반복 요청하는 것을 방지)
+const jwksRefreshInterval = time.Minute
+
+// metadata는 공급자의 디스커버리 문서 중 사용하는 항목입니다.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider는 외부 OpenID Connect 공급자 하나에 대한 클라이언트입니다.
+// 디스커버리 문서와 서명 키는 처음 사용할 때 가져와 캐시합니다.
+type Provider struct {
+	config config.OIDCProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	meta          *metadata
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewProvider는 설정으로 Provider를 생성합니다.
+func NewProvider(cfg config.OIDCProviderConfig) *Provider {
+	return &Provider{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name은 공급자 이름을 반환합니다.
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// Config는 공급자 설정을 반환합니다.
+func (p *Provider) Config() config.OIDCProviderConfig {
+	return p.config
+}
+
+// AuthCodeURL은 사용자를 보낼 공급자의 인가 요청 주소를 반환합니다.
+// codeChallenge는 PKCE S256 방식으로 만든 값이어야 합니다.
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	link, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// Exchange는 인가 코드를 토큰 엔드포인트에서 ID 토큰으로 교환합니다.
+func (p *Provider) Exchange(code, codeVerifier string) (string, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("토큰 응답 파싱 실패: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("토큰 엔드포인트 응답 오류: %s %s %s", resp.Status, result.Error, result.ErrorDescription)
+	}
+	if result.IDToken == "" {
+		return "", errors.New("토큰 응답에 id_token이 없습니다")
+	}
+	return result.IDToken, nil
+}
+
+// VerifyIDToken은 ID 토큰의 서명, 발급자, 대상, 만료 시각과 nonce를 확인하고 클레임을 반환합니다.
+func (p *Provider) VerifyIDToken(rawIDToken, nonce string) (Claims, error) {
+	meta, err := p.discover()
+	if err != nil {
+		return Claims{}, err
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+
+	mapClaims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(rawIDToken, mapClaims, p.keyFunc); err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return Claims{}, err
+		}
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	claims := Claims{raw: mapClaims}
+	if claims.String("nonce") != nonce {
+		return Claims{}, fmt.Errorf("%w: nonce가 일치하지 않습니다", ErrInvalidIDToken)
+	}
+
+	// 대상이 여러 개이면 azp가 이 클라이언트여야 함
+	audience, _ := mapClaims.GetAudience()
+	if len(audience) > 1 && claims.String("azp") != p.config.ClientID {
+		return Claims{}, fmt.Errorf("%w: azp가 일치하지 않습니다", ErrInvalidIDToken)
+	}
+
+	if claims.Subject() == "" {
+		return Claims{}, fmt.Errorf("%w: sub가 없습니다", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// MapRole은 역할 클레임 값을 설정된 매핑으로 역할로 바꿉니다.
+// 클레임 값 순서대로 처음 일치하는 역할을 사용하며, 일치하는 값이 없으면 기본 역할을 반환합니다.
+func (p *Provider) MapRole(claims Claims) string {
+	for _, value := range claims.Strings(p.config.RoleClaim) {
+		if role, ok := p.config.RoleMapping[value]; ok {
+			return role
+		}
+	}
+	return p.config.DefaultRole
+}
+
+// discover는 디스커버리 문서를 가져와 캐시합니다.
+func (p *Provider) discover() (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	var meta metadata
+	if err := p.getJSON(issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("OIDC 디스커버리 실패 (%s): %w", p.config.Name, err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC 디스커버리 발급자가 일치하지 않습니다 (%s): %s", p.config.Name, meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC 디스커버리 문서에 필요한 엔드포인트가 없습니다 (%s)", p.config.Name)
+	}
+
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// keyFunc는 ID 토큰 헤더의 키 ID로 서명 키를 찾습니다. 알 수 없는 키 ID이면 서명 키 목록을 다시 가져옵니다.
+func (p *Provider) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, ErrUnknownKey
+	}
+
+	var set jwkSet
+	if err := p.getJSON(p.meta.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("서명 키 목록 조회 실패: %w", err)
+	}
+	p.keys = set.publicKeys()
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// lookupKey는 캐시된 서명 키를 찾습니다. 키 ID가 없는 토큰은 키가 하나뿐일 때만 허용합니다.
+func (p *Provider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// getJSON은 주소에서 JSON 문서를 가져옵니다.
+func (p *Provider) getJSON(target string, v interface{}) error {
+	resp, err := p.client.Get(target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("응답 오류: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
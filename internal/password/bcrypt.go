@@ -0,0 +1,62 @@
+package password
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptMaxBytes는 bcrypt가 해싱할 수 있는 비밀번호의 최대 바이트 수입니다.
+const BcryptMaxBytes = 72
+
+// BcryptHasher는 bcrypt 알고리즘을 사용하는 Hasher 구현입니다.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher는 주어진 비용으로 BcryptHasher를 생성합니다.
+// 비용이 유효 범위를 벗어나면 bcrypt.DefaultCost를 사용합니다.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+// Hash는 비밀번호를 bcrypt로 해싱합니다.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Verify는 비밀번호가 bcrypt 해시와 일치하는지 확인합니다.
+func (h *BcryptHasher) Verify(encoded, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Supports는 해시가 bcrypt 형식인지 확인합니다.
+func (h *BcryptHasher) Supports(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+// NeedsRehash는 해시의 비용이 현재 비용과 다른지 확인합니다.
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+	return cost != h.cost
+}
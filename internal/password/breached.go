@@ -0,0 +1,69 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// BreachedList는 유출된 비밀번호의 SHA-1 해시를 정렬된 상태로 보관합니다.
+// 원문 비밀번호는 메모리에 두지 않으며 이진 탐색으로 포함 여부를 확인합니다.
+type BreachedList struct {
+	hashes [][sha1.Size]byte
+}
+
+// LoadBreachedList는 유출 비밀번호 해시 파일을 읽습니다.
+// 각 줄은 SHA-1 해시(16진수 40자)이며, Have I Been Pwned 형식의 ":횟수" 접미사와
+// '#'으로 시작하는 주석 줄은 무시됩니다. 파일이 정렬되어 있지 않아도 됩니다.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("유출 비밀번호 파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	list := &BreachedList{}
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hashHex, _, _ := strings.Cut(line, ":")
+
+		var hash [sha1.Size]byte
+		if n, err := hex.Decode(hash[:], []byte(hashHex)); err != nil || n != sha1.Size {
+			return nil, fmt.Errorf("유출 비밀번호 파일 %d번째 줄 형식 오류", lineNo)
+		}
+		list.hashes = append(list.hashes, hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("유출 비밀번호 파일 읽기 실패: %w", err)
+	}
+
+	sort.Slice(list.hashes, func(i, j int) bool {
+		return bytes.Compare(list.hashes[i][:], list.hashes[j][:]) < 0
+	})
+	return list, nil
+}
+
+// Len은 목록에 포함된 해시 수를 반환합니다.
+func (l *BreachedList) Len() int {
+	return len(l.hashes)
+}
+
+// Contains는 비밀번호가 유출 목록에 포함되어 있는지 확인합니다.
+func (l *BreachedList) Contains(password string) bool {
+	hash := sha1.Sum([]byte(password))
+	i := sort.Search(len(l.hashes), func(i int) bool {
+		return bytes.Compare(l.hashes[i][:], hash[:]) >= 0
+	})
+	return i < len(l.hashes) && l.hashes[i] == hash
+}
@@ -0,0 +1,94 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// ErrUnknownHashFormat은 저장된 해시의 형식을 알 수 없을 때 반환됩니다.
+var ErrUnknownHashFormat = errors.New("알 수 없는 비밀번호 해시 형식입니다")
+
+// Hasher는 비밀번호 해싱 알고리즘 구현이 제공해야 하는 인터페이스입니다.
+type Hasher interface {
+	// Hash는 비밀번호를 해싱하여 알고리즘과 매개변수가 포함된 문자열로 반환합니다.
+	Hash(password string) (string, error)
+	// Verify는 비밀번호가 해시와 일치하는지 확인합니다.
+	Verify(encoded, password string) (bool, error)
+	// Supports는 이 구현이 해당 해시 형식을 처리할 수 있는지 확인합니다.
+	Supports(encoded string) bool
+	// NeedsRehash는 해시가 현재 매개변수보다 오래된 매개변수로 만들어졌는지 확인합니다.
+	NeedsRehash(encoded string) bool
+}
+
+// 현재 사용하는 해싱 구현과 검증에 사용할 수 있는 모든 구현
+var (
+	current Hasher = NewBcryptHasher(0)
+	hashers        = []Hasher{current}
+)
+
+// 존재하지 않는 사용자의 로그인도 같은 시간이 걸리도록 검증에 사용하는 해시
+var (
+	dummyHash     string
+	dummyHashOnce sync.Once
+)
+
+// Init은 설정에 지정된 알고리즘과 매개변수로 비밀번호 해싱을 초기화합니다.
+func Init(cfg *config.Config) error {
+	bcryptHasher := NewBcryptHasher(cfg.BcryptCost)
+	argon2Hasher := NewArgon2idHasher(Argon2idParams{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+		SaltLength:  16,
+		KeyLength:   32,
+	})
+
+	switch strings.ToLower(cfg.PasswordHashAlgorithm) {
+	case "bcrypt":
+		current = bcryptHasher
+	case "argon2id":
+		current = argon2Hasher
+	default:
+		return fmt.Errorf("지원하지 않는 비밀번호 해싱 알고리즘입니다: %s", cfg.PasswordHashAlgorithm)
+	}
+
+	hashers = []Hasher{bcryptHasher, argon2Hasher}
+	dummyHashOnce = sync.Once{}
+	return nil
+}
+
+// Hash는 현재 설정된 알고리즘으로 비밀번호를 해싱합니다.
+func Hash(password string) (string, error) {
+	return current.Hash(password)
+}
+
+// Verify는 저장된 해시의 형식에 맞는 알고리즘으로 비밀번호를 검증합니다.
+func Verify(encoded, password string) (bool, error) {
+	for _, h := range hashers {
+		if h.Supports(encoded) {
+			return h.Verify(encoded, password)
+		}
+	}
+	return false, ErrUnknownHashFormat
+}
+
+// NeedsRehash는 저장된 해시를 현재 알고리즘과 매개변수로 다시 해싱해야 하는지 확인합니다.
+func NeedsRehash(encoded string) bool {
+	if !current.Supports(encoded) {
+		return true
+	}
+	return current.NeedsRehash(encoded)
+}
+
+// VerifyDummy는 현재 설정으로 만든 임의 해시에 대해 검증을 수행합니다.
+// 사용자가 존재하지 않거나 검증 결과를 사용하지 않는 경우에도 응답 시간을 일정하게 유지하기 위해 사용합니다.
+func VerifyDummy(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = current.Hash("dummy-password-for-timing")
+	})
+	current.Verify(dummyHash, password)
+}
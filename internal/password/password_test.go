@@ -0,0 +1,102 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testConfig는 테스트용 비밀번호 해싱 설정을 반환합니다.
+func testConfig(algorithm string) *config.Config {
+	return &config.Config{
+		PasswordHashAlgorithm: algorithm,
+		BcryptCost:            bcrypt.MinCost,
+		Argon2Memory:          1024,
+		Argon2Iterations:      1,
+		Argon2Parallelism:     1,
+	}
+}
+
+// TestHashAndVerify는 알고리즘별 해싱과 검증을 테스트합니다.
+func TestHashAndVerify(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		prefix    string
+	}{
+		{"bcrypt", "$2a$"},
+		{"argon2id", "$argon2id$v=19$m=1024,t=1,p=1$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			assert.NoError(t, Init(testConfig(tt.algorithm)))
+
+			hashed, err := Hash("password123")
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hashed, tt.prefix), hashed)
+
+			ok, err := Verify(hashed, "password123")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = Verify(hashed, "wrongpassword")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.False(t, NeedsRehash(hashed))
+		})
+	}
+}
+
+// TestVerifyOtherAlgorithm은 현재 알고리즘과 다른 형식의 해시도 검증되는지 테스트합니다.
+func TestVerifyOtherAlgorithm(t *testing.T) {
+	assert.NoError(t, Init(testConfig("bcrypt")))
+	bcryptHash, _ := Hash("password123")
+
+	assert.NoError(t, Init(testConfig("argon2id")))
+	ok, err := Verify(bcryptHash, "password123")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, NeedsRehash(bcryptHash))
+}
+
+// TestNeedsRehashOnParameterChange는 매개변수 변경 시 재해싱이 필요한지 테스트합니다.
+func TestNeedsRehashOnParameterChange(t *testing.T) {
+	cfg := testConfig("argon2id")
+	assert.NoError(t, Init(cfg))
+	argonHash, _ := Hash("password123")
+
+	cfg.Argon2Iterations = 2
+	assert.NoError(t, Init(cfg))
+	assert.True(t, NeedsRehash(argonHash))
+
+	cfg = testConfig("bcrypt")
+	assert.NoError(t, Init(cfg))
+	bcryptHash, _ := Hash("password123")
+
+	cfg.BcryptCost = bcrypt.MinCost + 1
+	assert.NoError(t, Init(cfg))
+	assert.True(t, NeedsRehash(bcryptHash))
+}
+
+// TestVerifyUnknownFormat은 평문이나 알 수 없는 형식의 저장 값을 거부하는지 테스트합니다.
+func TestVerifyUnknownFormat(t *testing.T) {
+	assert.NoError(t, Init(testConfig("bcrypt")))
+
+	ok, err := Verify("password123", "password123")
+	assert.ErrorIs(t, err, ErrUnknownHashFormat)
+	assert.False(t, ok)
+	assert.True(t, NeedsRehash("password123"))
+
+	ok, err = Verify("$argon2id$v=19$broken", "password123")
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+// TestInitUnsupportedAlgorithm은 지원하지 않는 알고리즘 설정을 테스트합니다.
+func TestInitUnsupportedAlgorithm(t *testing.T) {
+	assert.Error(t, Init(testConfig("md5")))
+}
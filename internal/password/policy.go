@@ -0,0 +1,149 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// 비밀번호 정책 규칙 이름
+const (
+	RuleMinLength   = "min_length"
+	RuleMaxLength   = "max_length"
+	RuleUppercase   = "uppercase"
+	RuleLowercase   = "lowercase"
+	RuleDigit       = "digit"
+	RuleSymbol      = "symbol"
+	RuleNoUsername  = "no_username"
+	RuleNoEmail     = "no_email"
+	RuleNotBreached = "not_breached"
+)
+
+// Violation은 비밀번호가 만족하지 못한 정책 규칙을 나타냅니다.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Policy는 비밀번호가 만족해야 하는 규칙 모음입니다.
+type Policy struct {
+	MinLength      int
+	MaxLength      int
+	MaxBytes       int // UTF-8 바이트 수 제한 (bcrypt는 72바이트까지만 해싱 가능)
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSymbol  bool
+	ForbidUserInfo bool
+	Breached       *BreachedList
+}
+
+// 현재 적용 중인 비밀번호 정책
+var policy = &Policy{
+	MinLength:      8,
+	MaxLength:      255,
+	MaxBytes:       BcryptMaxBytes,
+	ForbidUserInfo: true,
+}
+
+// InitPolicy는 설정에서 비밀번호 정책을 구성하고 유출 비밀번호 목록을 로드합니다.
+func InitPolicy(cfg *config.Config) error {
+	p := &Policy{
+		MinLength:      cfg.PasswordMinLength,
+		MaxLength:      255,
+		RequireUpper:   cfg.PasswordRequireUpper,
+		RequireLower:   cfg.PasswordRequireLower,
+		RequireDigit:   cfg.PasswordRequireDigit,
+		RequireSymbol:  cfg.PasswordRequireSymbol,
+		ForbidUserInfo: cfg.PasswordForbidUserInfo,
+	}
+	if strings.EqualFold(cfg.PasswordHashAlgorithm, "bcrypt") {
+		p.MaxBytes = BcryptMaxBytes
+	}
+
+	if cfg.BreachedPasswordFile != "" {
+		list, err := LoadBreachedList(cfg.BreachedPasswordFile)
+		if err != nil {
+			return err
+		}
+		p.Breached = list
+	}
+
+	policy = p
+	return nil
+}
+
+// CheckPolicy는 현재 정책으로 비밀번호를 검사하고 위반한 규칙 목록을 반환합니다.
+func CheckPolicy(password, username, email string) []Violation {
+	return policy.Check(password, username, email)
+}
+
+// Check는 비밀번호를 검사하고 위반한 규칙 목록을 반환합니다.
+// 모든 규칙을 만족하면 빈 목록을 반환합니다.
+func (p *Policy) Check(password, username, email string) []Violation {
+	violations := []Violation{}
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violations = append(violations, Violation{RuleMinLength, fmt.Sprintf("비밀번호는 %d자 이상이어야 합니다", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, Violation{RuleMaxLength, fmt.Sprintf("비밀번호는 %d자 이하여야 합니다", p.MaxLength)})
+	} else if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		violations = append(violations, Violation{RuleMaxLength, fmt.Sprintf("비밀번호는 %d바이트 이하여야 합니다 (영문은 글자당 1바이트, 한글은 3바이트)", p.MaxBytes)})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{RuleUppercase, "비밀번호에 대문자가 포함되어야 합니다"})
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, Violation{RuleLowercase, "비밀번호에 소문자가 포함되어야 합니다"})
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{RuleDigit, "비밀번호에 숫자가 포함되어야 합니다"})
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{RuleSymbol, "비밀번호에 특수문자가 포함되어야 합니다"})
+	}
+
+	if p.ForbidUserInfo {
+		lower := strings.ToLower(password)
+		if containsFold(lower, username) {
+			violations = append(violations, Violation{RuleNoUsername, "비밀번호에 사용자명을 포함할 수 없습니다"})
+		}
+		localPart, _, _ := strings.Cut(email, "@")
+		if containsFold(lower, email) || containsFold(lower, localPart) {
+			violations = append(violations, Violation{RuleNoEmail, "비밀번호에 이메일 주소를 포함할 수 없습니다"})
+		}
+	}
+
+	if p.Breached != nil && p.Breached.Contains(password) {
+		violations = append(violations, Violation{RuleNotBreached, "유출된 적이 있는 비밀번호는 사용할 수 없습니다"})
+	}
+
+	return violations
+}
+
+// containsFold는 대소문자를 구분하지 않고 value가 lowerPassword에 포함되는지 확인합니다.
+// 너무 짧은 값은 우연히 일치할 가능성이 높아 검사하지 않습니다.
+func containsFold(lowerPassword, value string) bool {
+	if len([]rune(value)) < 4 {
+		return false
+	}
+	return strings.Contains(lowerPassword, strings.ToLower(value))
+}
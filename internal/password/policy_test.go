@@ -0,0 +1,131 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rules는 위반 목록에서 규칙 이름만 추출합니다.
+func rules(violations []Violation) []string {
+	names := []string{}
+	for _, v := range violations {
+		names = append(names, v.Rule)
+	}
+	return names
+}
+
+// sha1Hex는 HIBP 형식의 대문자 SHA-1 해시를 반환합니다.
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// TestPolicyCheck는 비밀번호 정책 규칙을 테스트합니다.
+func TestPolicyCheck(t *testing.T) {
+	strict := &Policy{
+		MinLength:      10,
+		MaxLength:      20,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSymbol:  true,
+		ForbidUserInfo: true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		expected []string
+	}{
+		{"모든 규칙 만족", "Correct-Horse9", []string{}},
+		{"짧고 단순한 비밀번호", "abc", []string{RuleMinLength, RuleUppercase, RuleDigit, RuleSymbol}},
+		{"너무 긴 비밀번호", "Aa1!" + strings.Repeat("x", 20), []string{RuleMaxLength}},
+		{"사용자명 포함", "My-Alice-Pass1", []string{RuleNoUsername}},
+		{"이메일 로컬 파트 포함", "Wonderland-1x", []string{RuleNoEmail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := strict.Check(tt.password, "alice", "wonderland@example.com")
+			assert.Equal(t, tt.expected, rules(violations))
+		})
+	}
+}
+
+// TestPolicyMaxBytes는 bcrypt가 해싱할 수 있는 바이트 수를 넘는 비밀번호가 거부되는지 테스트합니다.
+func TestPolicyMaxBytes(t *testing.T) {
+	p := &Policy{MinLength: 8, MaxLength: 255, MaxBytes: BcryptMaxBytes}
+
+	tests := []struct {
+		name     string
+		password string
+		expected []string
+	}{
+		{"영문 72바이트", strings.Repeat("a", 72), []string{}},
+		{"영문 73바이트", strings.Repeat("a", 73), []string{RuleMaxLength}},
+		{"한글 24자 (72바이트)", strings.Repeat("가", 24), []string{}},
+		{"한글 25자 (75바이트)", strings.Repeat("가", 25), []string{RuleMaxLength}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rules(p.Check(tt.password, "", "")))
+		})
+	}
+
+	// 제한 안의 비밀번호는 bcrypt로 해싱할 수 있음
+	_, err := NewBcryptHasher(4).Hash(strings.Repeat("가", 24))
+	assert.NoError(t, err)
+
+	// 바이트 제한이 없는 정책 (Argon2id)
+	assert.Empty(t, (&Policy{MinLength: 8, MaxLength: 255}).Check(strings.Repeat("a", 80), "", ""))
+}
+
+// TestPolicyShortUserInfoIgnored는 너무 짧은 사용자 정보는 포함 검사에서 제외되는지 테스트합니다.
+func TestPolicyShortUserInfoIgnored(t *testing.T) {
+	p := &Policy{MinLength: 8, ForbidUserInfo: true}
+	assert.Empty(t, p.Check("newpassword", "bob", "new@example.com"))
+}
+
+// TestBreachedList는 유출 비밀번호 목록 로드와 조회를 테스트합니다.
+func TestBreachedList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	content := strings.Join([]string{
+		"# 테스트용 유출 비밀번호 목록",
+		sha1Hex("password123") + ":2254650",
+		sha1Hex("qwerty2024"),
+		"",
+		strings.ToLower(sha1Hex("letmein!")) + ":12",
+	}, "\n")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	list, err := LoadBreachedList(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, list.Len())
+
+	assert.True(t, list.Contains("password123"))
+	assert.True(t, list.Contains("qwerty2024"))
+	assert.True(t, list.Contains("letmein!"))
+	assert.False(t, list.Contains("Correct-Horse9"))
+
+	p := &Policy{MinLength: 8, Breached: list}
+	assert.Equal(t, []string{RuleNotBreached}, rules(p.Check("password123", "", "")))
+}
+
+// TestLoadBreachedListInvalid는 형식이 잘못된 유출 비밀번호 파일을 테스트합니다.
+func TestLoadBreachedListInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("not-a-hash\n"), 0600))
+
+	_, err := LoadBreachedList(path)
+	assert.Error(t, err)
+
+	_, err = LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
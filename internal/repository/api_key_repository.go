@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateAPIKey     = createAPIKey
+	GetAPIKeyByKeyID = getAPIKeyByKeyID
+	GetAPIKeysByUser = getAPIKeysByUser
+	RevokeAPIKey     = revokeAPIKey
+	TouchAPIKey      = touchAPIKey
+
+	RecordAPINonce         = recordAPINonce
+	DeleteExpiredAPINonces = deleteExpiredAPINonces
+)
+
+// createAPIKey는 새 API 키를 저장합니다.
+func createAPIKey(key *models.APIKey) error {
+	return database.DB.Create(key).Error
+}
+
+// getAPIKeyByKeyID는 키 ID로 API 키를 조회합니다.
+func getAPIKeyByKeyID(keyID string) (models.APIKey, error) {
+	var key models.APIKey
+	result := database.DB.Where("key_id = ?", keyID).First(&key)
+	return key, result.Error
+}
+
+// getAPIKeysByUser는 사용자의 API 키를 최근 생성 순으로 조회합니다.
+func getAPIKeysByUser(userID int64) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	result := database.DB.Where("user_id = ?", userID).Order("id DESC").Find(&keys)
+	return keys, result.Error
+}
+
+// revokeAPIKey는 사용자의 API 키를 폐기합니다.
+// 해당 사용자의 키가 아니거나 이미 폐기된 키이면 false를 반환합니다.
+func revokeAPIKey(id, userID int64) (bool, error) {
+	result := database.DB.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// touchAPIKey는 API 키의 마지막 사용 시각을 기록합니다.
+func touchAPIKey(id int64, at time.Time) error {
+	return database.DB.Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
+
+// recordAPINonce는 API 키의 nonce를 사용한 것으로 기록합니다.
+// 이미 사용한 nonce이면 false를 반환합니다.
+func recordAPINonce(keyID, nonce string, expiresAt time.Time) (bool, error) {
+	record := models.APINonce{KeyID: keyID, Nonce: nonce, ExpiresAt: expiresAt}
+	if err := database.DB.Create(&record).Error; err != nil {
+		// 같은 요청을 동시에 보내거나 다른 인스턴스에서 이미 처리했으면 고유 인덱스 위반으로 실패
+		var count int64
+		if countErr := database.DB.Model(&models.APINonce{}).
+			Where("key_id = ? AND nonce = ?", keyID, nonce).Count(&count).Error; countErr != nil || count == 0 {
+			return false, err
+		}
+		// 아직 정리되지 않은 만료된 기록이면 기한을 갱신하여 다시 사용
+		result := database.DB.Model(&models.APINonce{}).
+			Where("key_id = ? AND nonce = ? AND expires_at <= ?", keyID, nonce, time.Now()).
+			Update("expires_at", expiresAt)
+		return result.RowsAffected == 1, result.Error
+	}
+	return true, nil
+}
+
+// deleteExpiredAPINonces는 더 이상 필요 없는 만료된 nonce 기록을 삭제합니다.
+func deleteExpiredAPINonces(now time.Time) error {
+	return database.DB.Where("expires_at <= ?", now).Delete(&models.APINonce{}).Error
+}
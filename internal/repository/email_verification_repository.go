@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateEmailVerificationToken    = createEmailVerificationToken
+	GetEmailVerificationTokenByHash = getEmailVerificationTokenByHash
+	ActivateUserByVerificationToken = activateUserByVerificationToken
+	GetPendingUsersByEmail          = getPendingUsersByEmail
+	HasRecentVerificationToken      = hasRecentVerificationToken
+)
+
+// createEmailVerificationToken은 새 이메일 인증 토큰을 저장합니다.
+func createEmailVerificationToken(token *models.EmailVerificationToken) error {
+	return database.DB.Create(token).Error
+}
+
+// getEmailVerificationTokenByHash는 해시로 이메일 인증 토큰을 조회합니다.
+func getEmailVerificationTokenByHash(hash string) (models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+	result := database.DB.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+// activateUserByVerificationToken은 인증 토큰을 사용됨으로 표시하고 사용자를 활성화합니다.
+// 토큰이 이미 사용되었으면 false를 반환하며, 사용자의 다른 인증 토큰도 함께 무효화됩니다.
+func activateUserByVerificationToken(token models.EmailVerificationToken) (bool, error) {
+	activated := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.EmailVerificationToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		if err := tx.Model(&models.EmailVerificationToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		activated = true
+		return tx.Model(&models.User{}).
+			Where("id = ? AND status = ?", token.UserID, models.UserStatusPending).
+			Update("status", models.UserStatusActive).Error
+	})
+	return activated, err
+}
+
+// getPendingUsersByEmail은 이메일 인증을 기다리는 사용자를 이메일 주소로 조회합니다.
+func getPendingUsersByEmail(email string) ([]models.User, error) {
+	var users []models.User
+	result := database.DB.Where("email = ? AND status = ?", email, models.UserStatusPending).Find(&users)
+	return users, result.Error
+}
+
+// hasRecentVerificationToken은 since 이후에 발급되어 아직 사용하지 않았고 만료되지 않은 인증 토큰이 있는지 확인합니다.
+func hasRecentVerificationToken(userID int64, since, now time.Time) (bool, error) {
+	var count int64
+	result := database.DB.Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL AND created_at >= ? AND expires_at > ?", userID, since, now).
+		Count(&count)
+	return count > 0, result.Error
+}
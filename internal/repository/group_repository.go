@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	GetGroups           = getGroups
+	GetTenantGroupByID  = getTenantGroupByID
+	GetGroupByName      = getGroupByName
+	CreateGroup         = createGroup
+	UpdateGroup         = updateGroup
+	DeleteGroup         = deleteGroup
+	AddGroupUser        = addGroupUser
+	RemoveGroupUser     = removeGroupUser
+	AddSubgroup         = addSubgroup
+	RemoveSubgroup      = removeSubgroup
+	GetUserGroups       = getUserGroups
+	GetGroupAncestorIDs = getGroupAncestorIDs
+	CountGroupsWithRole = countGroupsWithRole
+	SearchGroups        = searchGroups
+)
+
+// getGroups는 조직 범위 안의 모든 그룹을 조회합니다.
+func getGroups(tenant Tenant) ([]models.Group, error) {
+	var groups []models.Group
+	result := database.DB.Scopes(tenant.Scope).Order("name").Find(&groups)
+	return groups, result.Error
+}
+
+// getTenantGroupByID는 조직 범위 안에서 ID로 그룹을 구성원과 함께 조회합니다.
+func getTenantGroupByID(tenant Tenant, id int64) (models.Group, error) {
+	var group models.Group
+	result := database.DB.Scopes(tenant.Scope).Preload("Users").Preload("Subgroups").First(&group, id)
+	return group, result.Error
+}
+
+// getGroupByName은 조직 안에서 이름으로 그룹을 조회합니다.
+func getGroupByName(organizationID int64, name string) (models.Group, error) {
+	var group models.Group
+	result := database.DB.Where("organization_id = ? AND name = ?", organizationID, name).First(&group)
+	return group, result.Error
+}
+
+// createGroup은 새 그룹을 생성합니다.
+func createGroup(group *models.Group) error {
+	return database.DB.Omit(clause.Associations).Create(group).Error
+}
+
+// updateGroup은 그룹 정보를 업데이트합니다. 구성원은 변경하지 않습니다.
+func updateGroup(group *models.Group) error {
+	return database.DB.Omit(clause.Associations).Save(group).Error
+}
+
+// deleteGroup은 그룹과 구성원 정보, 다른 그룹의 하위 그룹 정보를 함께 삭제합니다.
+func deleteGroup(id int64) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("group_subgroups").Where("child_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		return tx.Select(clause.Associations).Delete(&models.Group{ID: id}).Error
+	})
+}
+
+// addGroupUser는 사용자를 그룹에 추가합니다. 이미 구성원이면 아무것도 하지 않습니다.
+func addGroupUser(groupID, userID int64) error {
+	return database.DB.Model(&models.Group{ID: groupID}).Association("Users").Append(&models.User{ID: userID})
+}
+
+// removeGroupUser는 사용자를 그룹에서 제외합니다.
+func removeGroupUser(groupID, userID int64) error {
+	return database.DB.Model(&models.Group{ID: groupID}).Association("Users").Delete(&models.User{ID: userID})
+}
+
+// addSubgroup은 그룹을 다른 그룹의 하위 그룹으로 추가합니다.
+func addSubgroup(parentID, childID int64) error {
+	return database.DB.Model(&models.Group{ID: parentID}).Association("Subgroups").Append(&models.Group{ID: childID})
+}
+
+// removeSubgroup은 하위 그룹을 그룹에서 제외합니다.
+func removeSubgroup(parentID, childID int64) error {
+	return database.DB.Model(&models.Group{ID: parentID}).Association("Subgroups").Delete(&models.Group{ID: childID})
+}
+
+// getUserGroups는 사용자가 직접 속한 그룹과 그 상위 그룹을 모두 조회합니다.
+func getUserGroups(userID int64) ([]models.Group, error) {
+	var direct []int64
+	if err := database.DB.Table("group_users").Where("user_id = ?", userID).Pluck("group_id", &direct).Error; err != nil {
+		return nil, err
+	}
+	if len(direct) == 0 {
+		return nil, nil
+	}
+
+	ids, err := withAncestors(direct)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []models.Group
+	result := database.DB.Where("id IN ?", ids).Order("name").Find(&groups)
+	return groups, result.Error
+}
+
+// getGroupAncestorIDs는 그룹의 모든 상위 그룹 ID를 조회합니다.
+func getGroupAncestorIDs(groupID int64) ([]int64, error) {
+	ids, err := withAncestors([]int64{groupID})
+	if err != nil {
+		return nil, err
+	}
+	return ids[1:], nil
+}
+
+// withAncestors는 주어진 그룹 ID와 그 상위 그룹 ID를 중복 없이 반환합니다.
+func withAncestors(groupIDs []int64) ([]int64, error) {
+	seen := map[int64]bool{}
+	var result []int64
+	for _, id := range groupIDs {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	frontier := result
+	for len(frontier) > 0 {
+		var parents []int64
+		if err := database.DB.Table("group_subgroups").Where("child_id IN ?", frontier).Pluck("parent_id", &parents).Error; err != nil {
+			return nil, err
+		}
+
+		frontier = nil
+		for _, id := range parents {
+			if !seen[id] {
+				seen[id] = true
+				result = append(result, id)
+				frontier = append(frontier, id)
+			}
+		}
+	}
+	return result, nil
+}
+
+// countGroupsWithRole은 역할이 지정된 그룹 수를 반환합니다.
+func countGroupsWithRole(role string) (int64, error) {
+	var count int64
+	result := database.DB.Model(&models.Group{}).Where("role = ?", role).Count(&count)
+	return count, result.Error
+}
+
+// searchGroups는 조직 범위 안에서 조건에 맞는 그룹을 구성원과 함께 ID 순으로 한 페이지 조회하고 전체 개수를 함께 반환합니다.
+// where가 비어 있으면 모든 그룹을 조회합니다.
+func searchGroups(tenant Tenant, where string, args []interface{}, offset, limit int) ([]models.Group, int64, error) {
+	query := database.DB.Model(&models.Group{}).Scopes(tenant.Scope)
+	if where != "" {
+		query = query.Where(where, args...)
+	}
+	// 개수 조회와 목록 조회에서 같은 조건을 재사용
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var groups []models.Group
+	if limit == 0 {
+		return groups, total, nil
+	}
+	result := query.Preload("Users").Preload("Subgroups").Order("id").Offset(offset).Limit(limit).Find(&groups)
+	return groups, total, result.Error
+}
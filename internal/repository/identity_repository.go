@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	GetIdentity             = getIdentity
+	CreateUserWithIdentity  = createUserWithIdentity
+	RecordIdentityLogin     = recordIdentityLogin
+	CreateOIDCLoginState    = createOIDCLoginState
+	ConsumeOIDCLoginState   = consumeOIDCLoginState
+	CreateSAMLLoginRequest  = createSAMLLoginRequest
+	ConsumeSAMLLoginRequest = consumeSAMLLoginRequest
+	RecordSAMLAssertion     = recordSAMLAssertion
+)
+
+// getIdentity는 공급자와 subject로 연결된 외부 계정을 조회합니다.
+func getIdentity(provider, subject string) (models.Identity, error) {
+	var identity models.Identity
+	result := database.DB.Where("provider = ? AND subject = ?", provider, subject).First(&identity)
+	return identity, result.Error
+}
+
+// createUserWithIdentity는 사용자를 생성하고 외부 계정을 연결합니다.
+func createUserWithIdentity(user *models.User, identity *models.Identity) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+// recordIdentityLogin은 외부 계정의 마지막 로그인 시각과 공급자가 알려준 이메일 주소를 기록합니다.
+func recordIdentityLogin(id int64, email string) error {
+	return database.DB.Model(&models.Identity{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"last_login_at": time.Now(), "email": email}).Error
+}
+
+// createOIDCLoginState는 로그인 요청 상태를 저장하고 만료된 상태를 정리합니다.
+func createOIDCLoginState(state *models.OIDCLoginState) error {
+	if err := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.OIDCLoginState{}).Error; err != nil {
+		return err
+	}
+	return database.DB.Create(state).Error
+}
+
+// consumeOIDCLoginState는 해시로 로그인 요청 상태를 조회하고 다시 사용할 수 없도록 삭제합니다.
+// 상태가 없거나 다른 요청이 먼저 사용했으면 gorm.ErrRecordNotFound를 반환합니다.
+func consumeOIDCLoginState(hash string) (models.OIDCLoginState, error) {
+	var state models.OIDCLoginState
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ?", hash).First(&state).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.OIDCLoginState{}, state.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	return state, err
+}
+
+// createSAMLLoginRequest는 SAML 인증 요청을 저장하고 만료된 요청을 정리합니다.
+func createSAMLLoginRequest(request *models.SAMLLoginRequest) error {
+	if err := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.SAMLLoginRequest{}).Error; err != nil {
+		return err
+	}
+	return database.DB.Create(request).Error
+}
+
+// consumeSAMLLoginRequest는 요청 ID로 SAML 인증 요청을 조회하고 다시 사용할 수 없도록 삭제합니다.
+// 요청이 없거나 다른 응답이 먼저 사용했으면 gorm.ErrRecordNotFound를 반환합니다.
+func consumeSAMLLoginRequest(requestID string) (models.SAMLLoginRequest, error) {
+	var request models.SAMLLoginRequest
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("request_id = ?", requestID).First(&request).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.SAMLLoginRequest{}, request.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	return request, err
+}
+
+// recordSAMLAssertion은 어설션 ID를 사용한 것으로 기록하고 만료된 기록을 정리합니다.
+// 이미 사용한 어설션이면 false를 반환합니다.
+func recordSAMLAssertion(provider, assertionID string, expiresAt time.Time) (bool, error) {
+	if err := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.SAMLAssertion{}).Error; err != nil {
+		return false, err
+	}
+
+	record := models.SAMLAssertion{Provider: provider, AssertionID: assertionID, ExpiresAt: expiresAt}
+	if err := database.DB.Create(&record).Error; err != nil {
+		// 같은 어설션을 동시에 제출하면 고유 인덱스 위반으로 실패
+		var count int64
+		if countErr := database.DB.Model(&models.SAMLAssertion{}).
+			Where("provider = ? AND assertion_id = ?", provider, assertionID).Count(&count).Error; countErr == nil && count > 0 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateInvitation         = createInvitation
+	GetPendingInvitations    = getPendingInvitations
+	GetTenantInvitationByID  = getTenantInvitationByID
+	GetInvitationByHash      = getInvitationByHash
+	GetPendingInvitation     = getPendingInvitation
+	RenewInvitation          = renewInvitation
+	RevokeInvitation         = revokeInvitation
+	CreateUserFromInvitation = createUserFromInvitation
+)
+
+// createInvitation은 새 초대를 저장합니다.
+func createInvitation(invitation *models.Invitation) error {
+	return database.DB.Create(invitation).Error
+}
+
+// getPendingInvitations는 조직 범위 안에서 수락이나 취소되지 않은 초대를 조회합니다. 만료된 초대도 포함됩니다.
+func getPendingInvitations(tenant Tenant) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+	result := database.DB.Scopes(tenant.Scope).
+		Where("accepted_at IS NULL AND revoked_at IS NULL").
+		Order("created_at DESC").
+		Find(&invitations)
+	return invitations, result.Error
+}
+
+// getTenantInvitationByID는 조직 범위 안에서 ID로 초대를 조회합니다.
+func getTenantInvitationByID(tenant Tenant, id int64) (models.Invitation, error) {
+	var invitation models.Invitation
+	result := database.DB.Scopes(tenant.Scope).First(&invitation, id)
+	return invitation, result.Error
+}
+
+// getInvitationByHash는 해시로 초대를 조회합니다.
+func getInvitationByHash(hash string) (models.Invitation, error) {
+	var invitation models.Invitation
+	result := database.DB.Where("token_hash = ?", hash).First(&invitation)
+	return invitation, result.Error
+}
+
+// getPendingInvitation은 조직에 같은 이메일 주소로 보낸 대기 중인 초대를 조회합니다.
+func getPendingInvitation(organizationID int64, email string) (models.Invitation, error) {
+	var invitation models.Invitation
+	result := database.DB.
+		Where("organization_id = ? AND email = ? AND accepted_at IS NULL AND revoked_at IS NULL", organizationID, email).
+		First(&invitation)
+	return invitation, result.Error
+}
+
+// renewInvitation은 대기 중인 초대의 토큰과 만료 시각을 교체합니다. 이전 링크는 더 이상 사용할 수 없습니다.
+// 초대가 이미 수락되었거나 취소되었으면 false를 반환합니다.
+func renewInvitation(id int64, tokenHash string, expiresAt time.Time) (bool, error) {
+	result := database.DB.Model(&models.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"token_hash": tokenHash, "expires_at": expiresAt})
+	return result.RowsAffected == 1, result.Error
+}
+
+// revokeInvitation은 대기 중인 초대를 취소합니다. 초대가 이미 수락되었거나 취소되었으면 false를 반환합니다.
+func revokeInvitation(id int64) (bool, error) {
+	result := database.DB.Model(&models.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// createUserFromInvitation은 초대를 수락됨으로 표시하고 사용자를 생성합니다.
+// 초대가 이미 수락되었거나 취소되었으면 사용자를 생성하지 않고 false를 반환합니다.
+func createUserFromInvitation(invitation models.Invitation, user *models.User) (bool, error) {
+	accepted := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Update("accepted_at", time.Now())
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		if err := tx.Omit(clause.Associations).Create(user).Error; err != nil {
+			return err
+		}
+
+		accepted = true
+		return tx.Model(&models.Invitation{}).Where("id = ?", invitation.ID).Update("user_id", user.ID).Error
+	})
+	return accepted && err == nil, err
+}
@@ -5,12 +5,14 @@ import (
 
 	"github.com/choi-jiwoong/go-quickstart/internal/database"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
 )
 
 // 함수 변수 선언 - 테스트에서 모킹하기 위함
 var (
-	CreateLoginHistory = createLoginHistory
-	GetLoginHistories  = getLoginHistories
+	CreateLoginHistory    = createLoginHistory
+	GetLoginHistories     = getLoginHistories
+	GetLoginFailureStreak = getLoginFailureStreak
 )
 
 // createLoginHistory는 로그인 시도 기록을 저장합니다.
@@ -22,9 +24,63 @@ func createLoginHistory(history *models.LoginHistory) error {
 	return database.DB.Create(history).Error
 }
 
-// getLoginHistories는 특정 사용자의 로그인 기록을 조회합니다.
-func getLoginHistories(userID int64) ([]models.LoginHistory, error) {
-	var histories []models.LoginHistory
-	result := database.DB.Where("user_id = ?", userID).Find(&histories)
-	return histories, result.Error
-}
\ No newline at end of file
+// getLoginHistories는 특정 사용자의 로그인 기록 중 조건과 일치하는 기록을 최근 순서로 한 페이지 조회하고,
+// 조건과 일치하는 전체 기록 수를 함께 반환합니다.
+func getLoginHistories(userID int64, filter models.LoginHistoryFilter, offset, limit int) ([]models.LoginHistory, int64, error) {
+	query := database.DB.Model(&models.LoginHistory{}).Where("user_id = ?", userID)
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	if filter.From != nil {
+		query = query.Where("login_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("login_time < ?", *filter.To)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	histories := []models.LoginHistory{}
+	result := query.Order("login_time DESC").Order("id DESC").Offset(offset).Limit(limit).Find(&histories)
+	return histories, total, result.Error
+}
+
+// getLoginFailureStreak는 마지막 로그인 성공(또는 since) 이후 연속된 로그인 실패 횟수와
+// 마지막 실패 시각을 조회합니다. 실패가 없으면 마지막 실패 시각은 nil입니다.
+func getLoginFailureStreak(userID int64, since *time.Time) (int64, *time.Time, error) {
+	var lastSuccess []models.LoginHistory
+	err := database.DB.Where("user_id = ? AND success = ?", userID, true).
+		Order("login_time DESC").Limit(1).Find(&lastSuccess).Error
+	if err != nil {
+		return 0, nil, err
+	}
+
+	query := database.DB.Model(&models.LoginHistory{}).Where("user_id = ? AND success = ?", userID, false)
+	if len(lastSuccess) > 0 {
+		query = query.Where("login_time > ?", lastSuccess[0].LoginTime)
+	}
+	if since != nil {
+		query = query.Where("login_time > ?", *since)
+	}
+
+	// 조건을 공유하는 두 쿼리에서 재사용할 수 있도록 새 세션으로 분리
+	query = query.Session(&gorm.Session{})
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil || count == 0 {
+		return 0, nil, err
+	}
+
+	var lastFailure models.LoginHistory
+	if err := query.Order("login_time DESC").Limit(1).Find(&lastFailure).Error; err != nil {
+		return 0, nil, err
+	}
+	return count, lastFailure.LoginTime, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// createLoginAttempt는 지정한 시각의 로그인 기록을 생성합니다.
+func createLoginAttempt(userID int64, at time.Time, success bool) {
+	database.DB.Create(&models.LoginHistory{UserID: &userID, LoginTime: &at, Success: success})
+}
+
+// TestGetLoginFailureStreak는 마지막 성공 이후 연속 실패 계산을 테스트합니다.
+func TestGetLoginFailureStreak(t *testing.T) {
+	setupTestDB()
+	database.DB.AutoMigrate(&models.LoginHistory{})
+	testUsers := createTestUsers()
+	defer func() {
+		database.DB.Exec("DELETE FROM login_history")
+		cleanupTestData()
+	}()
+
+	userID := testUsers[0].ID
+	base := time.Now().Add(-time.Hour)
+
+	// 기록이 없으면 0
+	count, last, err := GetLoginFailureStreak(userID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Nil(t, last)
+
+	// 실패 2회, 성공, 실패 3회
+	createLoginAttempt(userID, base.Add(1*time.Minute), false)
+	createLoginAttempt(userID, base.Add(2*time.Minute), false)
+	createLoginAttempt(userID, base.Add(3*time.Minute), true)
+	createLoginAttempt(userID, base.Add(4*time.Minute), false)
+	createLoginAttempt(userID, base.Add(5*time.Minute), false)
+	createLoginAttempt(userID, base.Add(6*time.Minute), false)
+	createLoginAttempt(testUsers[1].ID, base.Add(7*time.Minute), false)
+
+	count, last, err = GetLoginFailureStreak(userID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	if assert.NotNil(t, last) {
+		assert.WithinDuration(t, base.Add(6*time.Minute), *last, time.Second)
+	}
+
+	// 잠금 해제 시각 이후의 실패만 계산
+	resetAt := base.Add(4*time.Minute + 30*time.Second)
+	count, _, err = GetLoginFailureStreak(userID, &resetAt)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+// TestGetLoginHistories는 로그인 기록의 조건 조회와 페이지 나누기를 테스트합니다.
+func TestGetLoginHistories(t *testing.T) {
+	setupTestDB()
+	database.DB.AutoMigrate(&models.LoginHistory{})
+	testUsers := createTestUsers()
+	defer func() {
+		database.DB.Exec("DELETE FROM login_history")
+		cleanupTestData()
+	}()
+
+	userID := testUsers[0].ID
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		at := base.Add(time.Duration(i) * time.Minute)
+		ip := "192.0.2.1"
+		if i%2 == 1 {
+			ip = "198.51.100.7"
+		}
+		database.DB.Create(&models.LoginHistory{UserID: &userID, LoginTime: &at, Success: i != 2, IPAddress: ip})
+	}
+	createLoginAttempt(testUsers[1].ID, base, true)
+
+	// 최근 기록부터 한 페이지씩 조회하며 전체 수는 페이지와 관계없음
+	histories, total, err := GetLoginHistories(userID, models.LoginHistoryFilter{}, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	if assert.Len(t, histories, 2) {
+		assert.WithinDuration(t, base.Add(4*time.Minute), *histories[0].LoginTime, time.Second)
+		assert.WithinDuration(t, base.Add(3*time.Minute), *histories[1].LoginTime, time.Second)
+	}
+
+	histories, _, err = GetLoginHistories(userID, models.LoginHistoryFilter{}, 4, 2)
+	assert.NoError(t, err)
+	assert.Len(t, histories, 1)
+
+	// 성공 여부
+	failed := false
+	histories, total, err = GetLoginHistories(userID, models.LoginHistoryFilter{Success: &failed}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, histories, 1) {
+		assert.False(t, histories[0].Success)
+	}
+
+	// 기간 (from 포함, to 미포함)과 IP
+	from, to := base.Add(time.Minute), base.Add(3*time.Minute)
+	_, total, err = GetLoginHistories(userID, models.LoginHistoryFilter{From: &from, To: &to}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	_, total, err = GetLoginHistories(userID, models.LoginHistoryFilter{IPAddress: "198.51.100.7"}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// 일치하는 기록이 없으면 빈 목록
+	histories, total, err = GetLoginHistories(userID, models.LoginHistoryFilter{IPAddress: "203.0.113.9"}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.NotNil(t, histories)
+	assert.Empty(t, histories)
+}
+
+// TestCreateLoginHistoryUnknownUser는 외래 키 제약이 적용된 데이터베이스에 존재하지 않는 사용자의 로그인 실패가 기록되는지 테스트합니다.
+func TestCreateLoginHistoryUnknownUser(t *testing.T) {
+	var err error
+	database.DB, err = gorm.Open(sqlite.Open("file:loginhistory?mode=memory&cache=shared&_foreign_keys=1"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if err := database.DB.AutoMigrate(&models.User{}, &models.LoginHistory{}); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	assert.NoError(t, CreateLoginHistory(&models.LoginHistory{IPAddress: "203.0.113.9", Success: false}))
+
+	var count int64
+	database.DB.Model(&models.LoginHistory{}).Where("user_id IS NULL AND ip_address = ?", "203.0.113.9").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	UpdateUserTOTP       = updateUserTOTP
+	AdvanceTOTPStep      = advanceTOTPStep
+	ReplaceRecoveryCodes = replaceRecoveryCodes
+	UseRecoveryCode      = useRecoveryCode
+)
+
+// updateUserTOTP는 사용자의 TOTP 비밀 키와 활성화 여부를 업데이트합니다.
+func updateUserTOTP(id int64, secret string, enabled bool) error {
+	return database.DB.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":    secret,
+		"mfa_enabled":    enabled,
+		"totp_last_step": 0,
+	}).Error
+}
+
+// advanceTOTPStep은 마지막으로 사용된 TOTP 시간 단계를 기록합니다.
+// 같거나 이전 단계가 다시 사용되면 false를 반환하여 코드 재사용을 막습니다.
+func advanceTOTPStep(id int64, step int64) (bool, error) {
+	result := database.DB.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// replaceRecoveryCodes는 사용자의 기존 복구 코드를 모두 삭제하고 새 코드로 교체합니다.
+// codes가 비어 있으면 복구 코드를 모두 삭제합니다.
+func replaceRecoveryCodes(userID int64, codes []models.RecoveryCode) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// useRecoveryCode는 사용되지 않은 복구 코드를 사용됨으로 표시합니다.
+// 일치하는 코드가 없거나 이미 사용된 코드면 false를 반환합니다.
+func useRecoveryCode(userID int64, codeHash string) (bool, error) {
+	result := database.DB.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
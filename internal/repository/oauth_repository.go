@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateOAuthClient             = createOAuthClient
+	GetOAuthClients               = getOAuthClients
+	GetOAuthClientByClientID      = getOAuthClientByClientID
+	DeleteOAuthClient             = deleteOAuthClient
+	CreateOAuthAuthorizationCode  = createOAuthAuthorizationCode
+	ConsumeOAuthAuthorizationCode = consumeOAuthAuthorizationCode
+)
+
+// createOAuthClient는 새 OAuth2 클라이언트를 저장합니다.
+func createOAuthClient(client *models.OAuthClient) error {
+	return database.DB.Create(client).Error
+}
+
+// getOAuthClients는 등록된 OAuth2 클라이언트를 등록 순으로 조회합니다.
+func getOAuthClients() ([]models.OAuthClient, error) {
+	var clients []models.OAuthClient
+	result := database.DB.Order("id").Find(&clients)
+	return clients, result.Error
+}
+
+// getOAuthClientByClientID는 클라이언트 ID로 OAuth2 클라이언트를 조회합니다.
+func getOAuthClientByClientID(clientID string) (models.OAuthClient, error) {
+	var client models.OAuthClient
+	result := database.DB.Where("client_id = ?", clientID).First(&client)
+	return client, result.Error
+}
+
+// deleteOAuthClient는 OAuth2 클라이언트와 교환되지 않은 인가 코드를 삭제합니다.
+// 클라이언트가 없으면 false를 반환합니다. 이미 발급된 토큰은 만료될 때까지 유효합니다.
+func deleteOAuthClient(id int64) (bool, error) {
+	deleted := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var client models.OAuthClient
+		if err := tx.First(&client, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return err
+		}
+		if err := tx.Where("client_id = ?", client.ClientID).Delete(&models.OAuthAuthorizationCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&client).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+// createOAuthAuthorizationCode는 인가 코드를 저장하고 만료된 인가 코드를 정리합니다.
+func createOAuthAuthorizationCode(code *models.OAuthAuthorizationCode) error {
+	if err := database.DB.Where("expires_at < ?", time.Now()).Delete(&models.OAuthAuthorizationCode{}).Error; err != nil {
+		return err
+	}
+	return database.DB.Create(code).Error
+}
+
+// consumeOAuthAuthorizationCode는 해시로 인가 코드를 조회하고 다시 사용할 수 없도록 삭제합니다.
+// 코드가 없거나 다른 요청이 먼저 사용했으면 gorm.ErrRecordNotFound를 반환합니다.
+func consumeOAuthAuthorizationCode(hash string) (models.OAuthAuthorizationCode, error) {
+	var code models.OAuthAuthorizationCode
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("code_hash = ?", hash).First(&code).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.OAuthAuthorizationCode{}, code.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	return code, err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	EnsureOrganization       = ensureOrganization
+	AssignUnorganizedUsers   = assignUnorganizedUsers
+	GetOrganizations         = getOrganizations
+	GetOrganizationByID      = getOrganizationByID
+	GetOrganizationByName    = getOrganizationByName
+	CreateOrganization       = createOrganization
+	UpdateOrganization       = updateOrganization
+	DeleteOrganization       = deleteOrganization
+	CountUsersInOrganization = countUsersInOrganization
+	UpdateUserOrganization   = updateUserOrganization
+)
+
+// ensureOrganization은 이름으로 조직을 조회하고, 없으면 생성합니다.
+func ensureOrganization(name string) (models.Organization, error) {
+	org := models.Organization{Name: name}
+	result := database.DB.Where(models.Organization{Name: name}).FirstOrCreate(&org)
+	return org, result.Error
+}
+
+// assignUnorganizedUsers는 조직이 지정되지 않은 사용자를 조직에 배정합니다.
+func assignUnorganizedUsers(organizationID int64) error {
+	return database.DB.Model(&models.User{}).Where("organization_id = 0").
+		Update("organization_id", organizationID).Error
+}
+
+// getOrganizations는 모든 조직을 조회합니다.
+func getOrganizations() ([]models.Organization, error) {
+	var orgs []models.Organization
+	result := database.DB.Order("name").Find(&orgs)
+	return orgs, result.Error
+}
+
+// getOrganizationByID는 ID로 조직을 조회합니다.
+func getOrganizationByID(id int64) (models.Organization, error) {
+	var org models.Organization
+	result := database.DB.First(&org, id)
+	return org, result.Error
+}
+
+// getOrganizationByName은 이름으로 조직을 조회합니다.
+func getOrganizationByName(name string) (models.Organization, error) {
+	var org models.Organization
+	result := database.DB.Where("name = ?", name).First(&org)
+	return org, result.Error
+}
+
+// createOrganization은 새 조직을 생성합니다.
+func createOrganization(org *models.Organization) error {
+	return database.DB.Create(org).Error
+}
+
+// updateOrganization은 조직 정보를 업데이트합니다.
+func updateOrganization(org *models.Organization) error {
+	return database.DB.Save(org).Error
+}
+
+// deleteOrganization은 조직을 삭제합니다.
+func deleteOrganization(id int64) error {
+	return database.DB.Delete(&models.Organization{}, id).Error
+}
+
+// countUsersInOrganization은 조직에 속한 사용자 수를 반환합니다.
+func countUsersInOrganization(organizationID int64) (int64, error) {
+	var count int64
+	result := database.DB.Model(&models.User{}).Where("organization_id = ?", organizationID).Count(&count)
+	return count, result.Error
+}
+
+// updateUserOrganization은 사용자의 조직을 변경합니다.
+func updateUserOrganization(userID, organizationID int64) error {
+	return database.DB.Model(&models.User{}).Where("id = ?", userID).
+		Update("organization_id", organizationID).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateOutboxMail     = createOutboxMail
+	GetDueOutboxMails    = getDueOutboxMails
+	ClaimOutboxMail      = claimOutboxMail
+	MarkOutboxMailSent   = markOutboxMailSent
+	MarkOutboxMailFailed = markOutboxMailFailed
+)
+
+// createOutboxMail은 메일을 발송 대기열에 저장합니다.
+func createOutboxMail(mail *models.OutboxMail) error {
+	return database.DB.Create(mail).Error
+}
+
+// getDueOutboxMails는 발송 시각이 된 대기 메일을 오래된 순으로 조회합니다.
+func getDueOutboxMails(now time.Time, limit int) ([]models.OutboxMail, error) {
+	var mails []models.OutboxMail
+	result := database.DB.
+		Where("status = ? AND next_attempt_at <= ?", models.MailStatusPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&mails)
+	return mails, result.Error
+}
+
+// claimOutboxMail은 메일 발송을 시작하기 전에 다음 시도 시각을 lease 이후로 미뤄 선점합니다.
+// 다른 발송기가 먼저 선점했으면 false를 반환합니다. 발송 도중 프로세스가 중단되면 lease 이후 다시 시도됩니다.
+func claimOutboxMail(id int64, now time.Time, lease time.Duration) (bool, error) {
+	result := database.DB.Model(&models.OutboxMail{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, models.MailStatusPending, now).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// markOutboxMailSent는 메일을 발송 완료로 표시합니다.
+func markOutboxMailSent(id int64) error {
+	return database.DB.Model(&models.OutboxMail{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.MailStatusSent,
+		"sent_at":    time.Now(),
+		"last_error": "",
+	}).Error
+}
+
+// markOutboxMailFailed는 발송 실패를 기록합니다.
+// final이 true이면 더 이상 재시도하지 않으며, 아니면 nextAttempt에 다시 발송합니다.
+func markOutboxMailFailed(id int64, lastError string, nextAttempt time.Time, final bool) error {
+	status := models.MailStatusPending
+	if final {
+		status = models.MailStatusFailed
+	}
+	return database.DB.Model(&models.OutboxMail{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttempt,
+	}).Error
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreatePasswordResetToken      = createPasswordResetToken
+	GetPasswordResetTokenByHash   = getPasswordResetTokenByHash
+	MarkPasswordResetTokenUsed    = markPasswordResetTokenUsed
+	InvalidatePasswordResetTokens = invalidatePasswordResetTokens
+	HasRecentPasswordResetToken   = hasRecentPasswordResetToken
+)
+
+// createPasswordResetToken은 새 비밀번호 재설정 토큰을 저장합니다.
+func createPasswordResetToken(token *models.PasswordResetToken) error {
+	return database.DB.Create(token).Error
+}
+
+// getPasswordResetTokenByHash는 해시로 비밀번호 재설정 토큰을 조회합니다.
+func getPasswordResetTokenByHash(hash string) (models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	result := database.DB.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+// markPasswordResetTokenUsed는 아직 사용되지 않은 재설정 토큰을 사용됨으로 표시합니다.
+// 이미 사용된 토큰이면 false를 반환합니다.
+func markPasswordResetTokenUsed(id int64) (bool, error) {
+	result := database.DB.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// invalidatePasswordResetTokens는 사용자의 사용되지 않은 재설정 토큰을 모두 무효화합니다.
+func invalidatePasswordResetTokens(userID int64) error {
+	return database.DB.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// hasRecentPasswordResetToken은 since 이후에 발급되어 아직 사용하지 않았고 만료되지 않은 재설정 토큰이 있는지 확인합니다.
+func hasRecentPasswordResetToken(userID int64, since, now time.Time) (bool, error) {
+	var count int64
+	result := database.DB.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL AND created_at >= ? AND expires_at > ?", userID, since, now).
+		Count(&count)
+	return count > 0, result.Error
+}
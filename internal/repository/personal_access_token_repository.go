@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreatePersonalAccessToken     = createPersonalAccessToken
+	GetPersonalAccessTokenByHash  = getPersonalAccessTokenByHash
+	GetPersonalAccessTokensByUser = getPersonalAccessTokensByUser
+	RevokePersonalAccessToken     = revokePersonalAccessToken
+	TouchPersonalAccessToken      = touchPersonalAccessToken
+)
+
+// createPersonalAccessToken은 새 개인 액세스 토큰을 저장합니다.
+func createPersonalAccessToken(token *models.PersonalAccessToken) error {
+	return database.DB.Create(token).Error
+}
+
+// getPersonalAccessTokenByHash는 해시로 개인 액세스 토큰을 조회합니다.
+func getPersonalAccessTokenByHash(hash string) (models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	result := database.DB.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+// getPersonalAccessTokensByUser는 사용자의 개인 액세스 토큰을 최근 생성 순으로 조회합니다.
+func getPersonalAccessTokensByUser(userID int64) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	result := database.DB.Where("user_id = ?", userID).Order("id DESC").Find(&tokens)
+	return tokens, result.Error
+}
+
+// revokePersonalAccessToken은 사용자의 개인 액세스 토큰을 폐기합니다.
+// 해당 사용자의 토큰이 아니거나 이미 폐기된 토큰이면 false를 반환합니다.
+func revokePersonalAccessToken(id, userID int64) (bool, error) {
+	result := database.DB.Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// touchPersonalAccessToken은 개인 액세스 토큰의 마지막 사용 시각을 기록합니다.
+func touchPersonalAccessToken(id int64, at time.Time) error {
+	return database.DB.Model(&models.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
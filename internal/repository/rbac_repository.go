@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	SeedRBAC               = seedRBAC
+	GetRoles               = getRoles
+	GetRoleByName          = getRoleByName
+	GetPermissions         = getPermissions
+	CreateRole             = createRole
+	ReplaceRolePermissions = replaceRolePermissions
+	DeleteRole             = deleteRole
+	CountUsersWithRole     = countUsersWithRole
+	UpdateUserRole         = updateUserRole
+)
+
+// seedRBAC는 권한과 기본 역할을 등록합니다.
+// 이미 있는 역할의 권한은 변경하지 않으며, Builtin 역할의 권한은 주어진 목록으로 교체합니다.
+func seedRBAC(permissions []models.Permission, roles []models.Role) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, p := range permissions {
+			if err := tx.Where(models.Permission{Name: p.Name}).
+				Assign(models.Permission{Description: p.Description}).
+				FirstOrCreate(&p).Error; err != nil {
+				return err
+			}
+		}
+
+		var all []models.Permission
+		if err := tx.Find(&all).Error; err != nil {
+			return err
+		}
+		byName := make(map[string]models.Permission, len(all))
+		for _, p := range all {
+			byName[p.Name] = p
+		}
+
+		for _, r := range roles {
+			var perms []models.Permission
+			for _, p := range r.Permissions {
+				perms = append(perms, byName[p.Name])
+			}
+
+			var existing models.Role
+			err := tx.Where("name = ?", r.Name).First(&existing).Error
+			if err == gorm.ErrRecordNotFound {
+				role := models.Role{Name: r.Name, Description: r.Description, Builtin: r.Builtin, Permissions: perms}
+				if err := tx.Create(&role).Error; err != nil {
+					return err
+				}
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			if r.Builtin {
+				if err := tx.Model(&existing).Update("builtin", true).Error; err != nil {
+					return err
+				}
+				association := tx.Model(&existing).Association("Permissions")
+				if len(perms) == 0 {
+					err = association.Clear()
+				} else {
+					err = association.Replace(perms)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// getRoles는 모든 역할을 권한과 함께 조회합니다.
+func getRoles() ([]models.Role, error) {
+	var roles []models.Role
+	result := database.DB.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	}).Order("name").Find(&roles)
+	return roles, result.Error
+}
+
+// getRoleByName은 이름으로 역할을 권한과 함께 조회합니다.
+func getRoleByName(name string) (models.Role, error) {
+	var role models.Role
+	result := database.DB.Preload("Permissions").Where("name = ?", name).First(&role)
+	return role, result.Error
+}
+
+// getPermissions는 등록된 모든 권한을 조회합니다.
+func getPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	result := database.DB.Order("name").Find(&permissions)
+	return permissions, result.Error
+}
+
+// createRole은 주어진 이름의 권한을 가진 새 역할을 생성합니다.
+func createRole(role *models.Role, permissionNames []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		permissions, err := findPermissions(tx, permissionNames)
+		if err != nil {
+			return err
+		}
+		role.Permissions = permissions
+		return tx.Create(role).Error
+	})
+}
+
+// replaceRolePermissions는 역할의 권한 목록을 교체합니다.
+func replaceRolePermissions(role *models.Role, permissionNames []string) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		permissions, err := findPermissions(tx, permissionNames)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(role).Association("Permissions").Replace(permissions); err != nil {
+			return err
+		}
+		role.Permissions = permissions
+		return nil
+	})
+}
+
+// deleteRole은 역할과 역할-권한 연결을 삭제합니다.
+func deleteRole(role *models.Role) error {
+	return database.DB.Select(clause.Associations).Delete(role).Error
+}
+
+// countUsersWithRole은 역할이 지정된 사용자 수를 반환합니다.
+func countUsersWithRole(name string) (int64, error) {
+	var count int64
+	result := database.DB.Model(&models.User{}).Where("role = ?", name).Count(&count)
+	return count, result.Error
+}
+
+// updateUserRole은 사용자의 역할을 변경합니다.
+func updateUserRole(id int64, role string) error {
+	return database.DB.Model(&models.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// findPermissions는 이름 목록에 해당하는 권한을 조회합니다.
+func findPermissions(tx *gorm.DB, names []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	result := tx.Where("name IN ?", names).Find(&permissions)
+	return permissions, result.Error
+}
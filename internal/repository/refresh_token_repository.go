@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateRefreshToken       = createRefreshToken
+	GetRefreshTokenByHash    = getRefreshTokenByHash
+	MarkRefreshTokenUsed     = markRefreshTokenUsed
+	RevokeRefreshTokenFamily = revokeRefreshTokenFamily
+)
+
+// createRefreshToken은 새 리프레시 토큰을 저장합니다.
+func createRefreshToken(token *models.RefreshToken) error {
+	return database.DB.Create(token).Error
+}
+
+// getRefreshTokenByHash는 해시로 리프레시 토큰을 조회합니다.
+func getRefreshTokenByHash(hash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	result := database.DB.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+// markRefreshTokenUsed는 아직 사용되지 않은 리프레시 토큰을 사용됨으로 표시합니다.
+// 이미 사용된 토큰이면 false를 반환합니다.
+func markRefreshTokenUsed(id int64) (bool, error) {
+	result := database.DB.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// revokeRefreshTokenFamily는 같은 계열의 모든 리프레시 토큰을 폐기하고 해당 세션을 종료합니다.
+func revokeRefreshTokenFamily(familyID string) error {
+	now := time.Now()
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.RefreshToken{}).
+			Where("family_id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Session{}).
+			Where("family_id = ? AND revoked_at IS NULL", familyID).
+			Update("revoked_at", now).Error
+	})
+}
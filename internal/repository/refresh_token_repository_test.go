@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRefreshTokenLifecycle은 리프레시 토큰 저장, 사용 표시, 계열 폐기를 테스트합니다.
+func TestRefreshTokenLifecycle(t *testing.T) {
+	setupTestDB()
+	database.DB.AutoMigrate(&models.RefreshToken{}, &models.Session{})
+	testUsers := createTestUsers()
+	defer func() {
+		database.DB.Exec("DELETE FROM refresh_tokens")
+		cleanupTestData()
+	}()
+
+	first := models.RefreshToken{UserID: testUsers[0].ID, FamilyID: "family-a", TokenHash: "hash-1", ExpiresAt: time.Now().Add(time.Hour)}
+	second := models.RefreshToken{UserID: testUsers[0].ID, FamilyID: "family-a", TokenHash: "hash-2", ExpiresAt: time.Now().Add(time.Hour)}
+	other := models.RefreshToken{UserID: testUsers[0].ID, FamilyID: "family-b", TokenHash: "hash-3", ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, CreateRefreshToken(&first))
+	assert.NoError(t, CreateRefreshToken(&second))
+	assert.NoError(t, CreateRefreshToken(&other))
+
+	// 해시로 조회
+	found, err := GetRefreshTokenByHash("hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, found.ID)
+	assert.Nil(t, found.UsedAt)
+
+	// 사용 표시는 한 번만 성공
+	marked, err := MarkRefreshTokenUsed(first.ID)
+	assert.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = MarkRefreshTokenUsed(first.ID)
+	assert.NoError(t, err)
+	assert.False(t, marked)
+
+	// 계열 폐기는 같은 계열에만 적용
+	assert.NoError(t, RevokeRefreshTokenFamily("family-a"))
+
+	found, _ = GetRefreshTokenByHash("hash-2")
+	assert.NotNil(t, found.RevokedAt)
+
+	found, _ = GetRefreshTokenByHash("hash-3")
+	assert.Nil(t, found.RevokedAt)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateSCIMToken    = createSCIMToken
+	GetSCIMTokens      = getSCIMTokens
+	GetSCIMTokenByHash = getSCIMTokenByHash
+	RevokeSCIMToken    = revokeSCIMToken
+	TouchSCIMToken     = touchSCIMToken
+)
+
+// createSCIMToken은 새 SCIM 토큰을 저장합니다.
+func createSCIMToken(token *models.SCIMToken) error {
+	return database.DB.Create(token).Error
+}
+
+// getSCIMTokens는 조직 범위 안의 SCIM 토큰을 최근 생성 순으로 조회합니다.
+func getSCIMTokens(tenant Tenant) ([]models.SCIMToken, error) {
+	var tokens []models.SCIMToken
+	result := database.DB.Scopes(tenant.Scope).Order("id DESC").Find(&tokens)
+	return tokens, result.Error
+}
+
+// getSCIMTokenByHash는 해시로 SCIM 토큰을 조회합니다.
+func getSCIMTokenByHash(hash string) (models.SCIMToken, error) {
+	var token models.SCIMToken
+	result := database.DB.Where("token_hash = ?", hash).First(&token)
+	return token, result.Error
+}
+
+// revokeSCIMToken은 조직 범위 안의 SCIM 토큰을 폐기합니다.
+// 범위 밖의 토큰이거나 이미 폐기된 토큰이면 false를 반환합니다.
+func revokeSCIMToken(tenant Tenant, id int64) (bool, error) {
+	result := database.DB.Model(&models.SCIMToken{}).Scopes(tenant.Scope).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// touchSCIMToken은 SCIM 토큰의 마지막 사용 시각을 기록합니다.
+func touchSCIMToken(id int64, at time.Time) error {
+	return database.DB.Model(&models.SCIMToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateSession     = createSession
+	GetActiveSessions = getActiveSessions
+	GetSessionByID    = getSessionByID
+	TouchSession      = touchSession
+	RenewSession      = renewSession
+
+	CreateImpersonationSession = createImpersonationSession
+)
+
+// createSession은 새 로그인 세션을 저장합니다.
+func createSession(session *models.Session) error {
+	return database.DB.Create(session).Error
+}
+
+// createImpersonationSession은 가장 세션과 감사 기록을 함께 저장합니다.
+func createImpersonationSession(session *models.Session, audit *models.ImpersonationAudit) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(audit).Error
+	})
+}
+
+// getActiveSessions는 사용자의 종료되지 않은 세션을 최근에 만든 순서로 조회합니다.
+func getActiveSessions(userID int64, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	result := database.DB.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("id DESC").
+		Find(&sessions)
+	return sessions, result.Error
+}
+
+// getSessionByID는 ID로 세션을 조회합니다.
+func getSessionByID(id int64) (models.Session, error) {
+	var session models.Session
+	result := database.DB.First(&session, id)
+	return session, result.Error
+}
+
+// touchSession은 세션의 마지막 사용 시각을 갱신합니다.
+// 요청마다 기록하지 않도록 마지막 사용 시각이 before보다 이전인 경우에만 갱신합니다.
+func touchSession(familyID string, at, before time.Time) error {
+	return database.DB.Model(&models.Session{}).
+		Where("family_id = ? AND last_used_at < ?", familyID, before).
+		Update("last_used_at", at).Error
+}
+
+// renewSession은 토큰을 갱신한 세션의 마지막 사용 시각과 만료 시각을 연장합니다.
+func renewSession(familyID string, at, expiresAt time.Time) error {
+	return database.DB.Model(&models.Session{}).
+		Where("family_id = ?", familyID).
+		Updates(map[string]interface{}{"last_used_at": at, "expires_at": expiresAt}).Error
+}
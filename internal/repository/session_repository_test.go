@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSessionLifecycle은 세션 저장, 활성 세션 조회, 사용 시각 갱신, 종료를 테스트합니다.
+func TestSessionLifecycle(t *testing.T) {
+	setupTestDB()
+	database.DB.AutoMigrate(&models.RefreshToken{}, &models.Session{})
+	testUsers := createTestUsers()
+	defer func() {
+		database.DB.Exec("DELETE FROM sessions")
+		database.DB.Exec("DELETE FROM refresh_tokens")
+		cleanupTestData()
+	}()
+
+	userID := testUsers[0].ID
+	now := time.Now()
+	older := models.Session{UserID: userID, FamilyID: "family-a", LastUsedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}
+	newer := models.Session{UserID: userID, FamilyID: "family-b", LastUsedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}
+	expired := models.Session{UserID: userID, FamilyID: "family-c", LastUsedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)}
+	other := models.Session{UserID: testUsers[1].ID, FamilyID: "family-d", LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+	for _, session := range []*models.Session{&older, &newer, &expired, &other} {
+		assert.NoError(t, CreateSession(session))
+	}
+
+	// 만료되지 않은 사용자 세션만 최근 순서로 조회
+	sessions, err := GetActiveSessions(userID, now)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, newer.ID, sessions[0].ID)
+		assert.Equal(t, older.ID, sessions[1].ID)
+	}
+
+	// 마지막 사용 시각은 기준 시각보다 오래된 경우에만 갱신
+	assert.NoError(t, TouchSession("family-a", now, now.Add(-2*time.Hour)))
+	found, _ := GetSessionByID(older.ID)
+	assert.WithinDuration(t, now.Add(-time.Hour), found.LastUsedAt, time.Second)
+
+	assert.NoError(t, TouchSession("family-a", now, now.Add(-time.Minute)))
+	found, _ = GetSessionByID(older.ID)
+	assert.WithinDuration(t, now, found.LastUsedAt, time.Second)
+
+	// 토큰 갱신은 만료 시각을 연장
+	assert.NoError(t, RenewSession("family-a", now, now.Add(24*time.Hour)))
+	found, _ = GetSessionByID(older.ID)
+	assert.WithinDuration(t, now.Add(24*time.Hour), found.ExpiresAt, time.Second)
+
+	// 토큰 계열을 폐기하면 세션도 종료
+	assert.NoError(t, RevokeRefreshTokenFamily("family-a"))
+	found, _ = GetSessionByID(older.ID)
+	assert.NotNil(t, found.RevokedAt)
+
+	sessions, err = GetActiveSessions(userID, now)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, newer.ID, sessions[0].ID)
+	}
+}
+
+// TestRevokeUserRefreshTokens는 사용자의 세션과 사용자가 가장하여 만든 세션이 모두 종료되어 반환되는지 테스트합니다.
+func TestRevokeUserRefreshTokens(t *testing.T) {
+	setupTestDB()
+	database.DB.AutoMigrate(&models.RefreshToken{}, &models.Session{})
+	testUsers := createTestUsers()
+	defer func() {
+		database.DB.Exec("DELETE FROM sessions")
+		database.DB.Exec("DELETE FROM refresh_tokens")
+		cleanupTestData()
+	}()
+
+	userID, otherID := testUsers[0].ID, testUsers[1].ID
+	now := time.Now()
+	own := models.Session{UserID: userID, FamilyID: "revoke-own", LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+	impersonated := models.Session{UserID: otherID, FamilyID: "revoke-impersonated", ImpersonatorID: userID, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+	other := models.Session{UserID: otherID, FamilyID: "revoke-other", LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+	for _, session := range []*models.Session{&own, &impersonated, &other} {
+		assert.NoError(t, CreateSession(session))
+	}
+	assert.NoError(t, CreateRefreshToken(&models.RefreshToken{UserID: userID, TokenHash: "revoke-hash", FamilyID: "revoke-own", ExpiresAt: now.Add(time.Hour)}))
+
+	sessions, err := RevokeUserRefreshTokens(userID)
+	assert.NoError(t, err)
+	var familyIDs []string
+	for _, session := range sessions {
+		familyIDs = append(familyIDs, session.FamilyID)
+	}
+	assert.ElementsMatch(t, []string{"revoke-own", "revoke-impersonated"}, familyIDs)
+
+	found, _ := GetSessionByID(other.ID)
+	assert.Nil(t, found.RevokedAt)
+	found, _ = GetSessionByID(impersonated.ID)
+	assert.NotNil(t, found.RevokedAt)
+
+	var token models.RefreshToken
+	database.DB.Where("token_hash = ?", "revoke-hash").First(&token)
+	assert.NotNil(t, token.RevokedAt)
+
+	// 이미 종료된 세션은 다시 반환하지 않음
+	sessions, err = RevokeUserRefreshTokens(userID)
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+}
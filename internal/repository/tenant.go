@@ -0,0 +1,25 @@
+package repository
+
+import "gorm.io/gorm"
+
+// Tenant는 사용자 조회 범위를 제한할 조직입니다. All이 true이면 모든 조직을 조회합니다.
+type Tenant struct {
+	OrganizationID int64
+	All            bool
+}
+
+// AllTenants는 모든 조직을 조회하는 범위입니다.
+var AllTenants = Tenant{All: true}
+
+// Scope는 쿼리를 조직의 사용자로 제한하는 gorm 스코프입니다.
+func (t Tenant) Scope(db *gorm.DB) *gorm.DB {
+	if t.All {
+		return db
+	}
+	return db.Where("organization_id = ?", t.OrganizationID)
+}
+
+// Contains는 사용자가 조직 범위에 속하는지 확인합니다.
+func (t Tenant) Contains(organizationID int64) bool {
+	return t.All || t.OrganizationID == organizationID
+}
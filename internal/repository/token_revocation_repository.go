@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/database"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+)
+
+// 함수 변수 선언 - 테스트에서 모킹하기 위함
+var (
+	CreateTokenRevocation         = createTokenRevocation
+	GetActiveTokenRevocations     = getActiveTokenRevocations
+	DeleteExpiredTokenRevocations = deleteExpiredTokenRevocations
+	RevokeUserRefreshTokens       = revokeUserRefreshTokens
+)
+
+// createTokenRevocation은 토큰 폐기 기록을 저장합니다.
+func createTokenRevocation(revocation *models.TokenRevocation) error {
+	return database.DB.Create(revocation).Error
+}
+
+// getActiveTokenRevocations는 아직 만료되지 않은 폐기 기록을 조회합니다.
+func getActiveTokenRevocations(now time.Time) ([]models.TokenRevocation, error) {
+	var revocations []models.TokenRevocation
+	result := database.DB.Where("expires_at > ?", now).Find(&revocations)
+	return revocations, result.Error
+}
+
+// deleteExpiredTokenRevocations는 더 이상 필요 없는 만료된 폐기 기록을 삭제합니다.
+func deleteExpiredTokenRevocations(now time.Time) error {
+	return database.DB.Where("expires_at <= ?", now).Delete(&models.TokenRevocation{}).Error
+}
+
+// revokeUserRefreshTokens는 사용자의 모든 리프레시 토큰을 폐기하고, 사용자의 세션과 사용자가 가장하여 만든 세션을 모두 종료합니다.
+// 종료한 세션을 반환하므로 호출자는 세션에서 발급된 액세스 토큰도 폐기할 수 있습니다.
+func revokeUserRefreshTokens(userID int64) ([]models.Session, error) {
+	now := time.Now()
+	var sessions []models.Session
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("(user_id = ? OR impersonator_id = ?) AND revoked_at IS NULL", userID, userID).
+			Find(&sessions).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Session{}).
+			Where("(user_id = ? OR impersonator_id = ?) AND revoked_at IS NULL", userID, userID).
+			Update("revoked_at", now).Error
+	})
+	return sessions, err
+}
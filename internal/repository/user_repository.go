@@ -1,47 +1,83 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/choi-jiwoong/go-quickstart/internal/database"
 	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // 함수 변수 선언 - 테스트에서 모킹하기 위함
 var (
-	GetAllUsers       = getAllUsers
-	GetUserByID       = getUserByID
-	CreateUser        = createUser
-	UpdateUser        = updateUser
-	DeleteUser        = deleteUser
-	GetUserByUsername = getUserByUsername
+	GetAllUsers        = getAllUsers
+	GetUserByID        = getUserByID
+	GetTenantUserByID  = getTenantUserByID
+	CreateUser         = createUser
+	UpdateUser         = updateUser
+	DeleteUser         = deleteUser
+	GetUserByUsername  = getUserByUsername
+	UpdateUserPassword = updateUserPassword
+	ResetUserLockout   = resetUserLockout
+	GetUsersByEmail    = getUsersByEmail
+	SearchUsers        = searchUsers
 )
 
-// getAllUsers는 모든 사용자를 조회합니다.
-func getAllUsers() ([]models.User, error) {
+// getAllUsers는 조직 범위 안의 모든 사용자를 조회합니다.
+func getAllUsers(tenant Tenant) ([]models.User, error) {
 	var users []models.User
-	result := database.DB.Find(&users)
+	result := database.DB.Scopes(tenant.Scope).Find(&users)
 	return users, result.Error
 }
 
 // getUserByID는 ID로 사용자를 조회합니다.
+// 조직 범위를 확인하지 않으므로 인증된 사용자 본인을 조회할 때만 사용합니다.
 func getUserByID(id int64) (models.User, error) {
 	var user models.User
 	result := database.DB.First(&user, id)
 	return user, result.Error
 }
 
+// getTenantUserByID는 조직 범위 안에서 ID로 사용자를 조회합니다.
+func getTenantUserByID(tenant Tenant, id int64) (models.User, error) {
+	var user models.User
+	result := database.DB.Scopes(tenant.Scope).First(&user, id)
+	return user, result.Error
+}
+
 // createUser는 새 사용자를 생성합니다.
 func createUser(user *models.User) error {
-	return database.DB.Create(user).Error
+	return database.DB.Omit(clause.Associations).Create(user).Error
 }
 
 // updateUser는 사용자 정보를 업데이트합니다.
 func updateUser(user *models.User) error {
-	return database.DB.Save(user).Error
+	return database.DB.Omit(clause.Associations).Save(user).Error
 }
 
-// deleteUser는 사용자를 삭제합니다.
+// userOwnedModels는 사용자를 외래 키로 참조하는 테이블의 모델이며, 사용자를 삭제할 때 함께 삭제합니다.
+var userOwnedModels = []interface{}{
+	&models.RefreshToken{},
+	&models.Session{},
+	&models.RecoveryCode{},
+	&models.PasswordResetToken{},
+	&models.EmailVerificationToken{},
+	&models.PersonalAccessToken{},
+	&models.APIKey{},
+	&models.LoginHistory{},
+}
+
+// deleteUser는 사용자를 그룹 구성원 정보, 외부 계정 연결, 토큰과 세션, 로그인 기록과 함께 하나의 트랜잭션으로 삭제합니다.
 func deleteUser(id int64) error {
-	return database.DB.Delete(&models.User{}, id).Error
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range userOwnedModels {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Select("Groups", "Identities").Delete(&models.User{ID: id}).Error
+	})
 }
 
 // getUserByUsername은 사용자명으로 사용자를 조회합니다.
@@ -49,4 +85,45 @@ func getUserByUsername(username string) (models.User, error) {
 	var user models.User
 	result := database.DB.Where("username = ?", username).First(&user)
 	return user, result.Error
-}
\ No newline at end of file
+}
+
+// updateUserPassword는 사용자의 비밀번호 해시만 업데이트합니다.
+func updateUserPassword(id int64, passwordHash string) error {
+	return database.DB.Model(&models.User{}).Where("id = ?", id).Update("password", passwordHash).Error
+}
+
+// resetUserLockout은 현재 시각 이전의 로그인 실패를 잠금 계산에서 제외하여 계정 잠금을 해제합니다.
+func resetUserLockout(id int64) error {
+	return database.DB.Model(&models.User{}).Where("id = ?", id).Update("lockout_reset_at", time.Now()).Error
+}
+
+// getUsersByEmail은 이메일 주소로 사용자를 조회합니다.
+// 이메일 주소는 고유하지 않으므로 여러 사용자가 반환될 수 있습니다.
+func getUsersByEmail(email string) ([]models.User, error) {
+	var users []models.User
+	result := database.DB.Where("email = ?", email).Find(&users)
+	return users, result.Error
+}
+
+// searchUsers는 조직 범위 안에서 조건에 맞는 사용자를 ID 순으로 한 페이지 조회하고 전체 개수를 함께 반환합니다.
+// where가 비어 있으면 모든 사용자를 조회합니다.
+func searchUsers(tenant Tenant, where string, args []interface{}, offset, limit int) ([]models.User, int64, error) {
+	query := database.DB.Model(&models.User{}).Scopes(tenant.Scope)
+	if where != "" {
+		query = query.Where(where, args...)
+	}
+	// 개수 조회와 목록 조회에서 같은 조건을 재사용
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if limit == 0 {
+		return users, total, nil
+	}
+	result := query.Order("id").Offset(offset).Limit(limit).Find(&users)
+	return users, total, result.Error
+}
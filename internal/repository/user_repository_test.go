@@ -19,8 +19,11 @@ func setupTestDB() {
 		panic("테스트 데이터베이스 연결 실패")
 	}
 	
-	// 테이블 생성
-	err = database.DB.AutoMigrate(&models.User{})
+	// 테이블 생성 (사용자를 삭제할 때 함께 삭제되는 테이블 포함)
+	err = database.DB.AutoMigrate(&models.User{}, &models.Identity{})
+	if err == nil {
+		err = database.DB.AutoMigrate(userOwnedModels...)
+	}
 	if err != nil {
 		panic("테스트 테이블 마이그레이션 실패")
 	}
@@ -66,7 +69,7 @@ func TestGetAllUsers(t *testing.T) {
 	testUsers := createTestUsers()
 	defer cleanupTestData()
 	
-	users, err := GetAllUsers()
+	users, err := GetAllUsers(AllTenants)
 	
 	assert.NoError(t, err)
 	assert.Len(t, users, 2)
@@ -148,6 +151,49 @@ func TestDeleteUser(t *testing.T) {
 	assert.Equal(t, int64(0), count)
 }
 
+// TestDeleteUserWithOwnedRecords는 외래 키 제약이 적용된 데이터베이스에서 토큰과 세션이 있는 사용자를 삭제하는지 테스트합니다.
+func TestDeleteUserWithOwnedRecords(t *testing.T) {
+	var err error
+	database.DB, err = gorm.Open(sqlite.Open("file:deleteuser?mode=memory&cache=shared&_foreign_keys=1"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if err := database.DB.AutoMigrate(&models.User{}, &models.Identity{}); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if err := database.DB.AutoMigrate(userOwnedModels...); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	user := models.User{Username: "owner", Email: "owner@example.com", Password: "password", Role: "USER"}
+	other := models.User{Username: "bystander", Email: "bystander@example.com", Password: "password", Role: "USER"}
+	database.DB.Create(&user)
+	database.DB.Create(&other)
+
+	now := time.Now()
+	for _, u := range []models.User{user, other} {
+		familyID := u.Username + "-family"
+		assert.NoError(t, CreateSession(&models.Session{UserID: u.ID, FamilyID: familyID, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}))
+		assert.NoError(t, CreateRefreshToken(&models.RefreshToken{UserID: u.ID, FamilyID: familyID, TokenHash: u.Username + "-hash", ExpiresAt: now.Add(time.Hour)}))
+	}
+	assert.NoError(t, CreateLoginHistory(&models.LoginHistory{UserID: &user.ID, Success: true}))
+
+	assert.NoError(t, DeleteUser(user.ID))
+
+	// 삭제한 사용자의 기록만 함께 삭제됨
+	var users, sessions, tokens, histories int64
+	database.DB.Model(&models.User{}).Where("id = ?", user.ID).Count(&users)
+	database.DB.Model(&models.Session{}).Where("user_id = ?", user.ID).Count(&sessions)
+	database.DB.Model(&models.RefreshToken{}).Where("user_id = ?", user.ID).Count(&tokens)
+	database.DB.Model(&models.LoginHistory{}).Where("user_id = ?", user.ID).Count(&histories)
+	assert.Equal(t, int64(0), users+sessions+tokens+histories)
+
+	database.DB.Model(&models.Session{}).Where("user_id = ?", other.ID).Count(&sessions)
+	database.DB.Model(&models.RefreshToken{}).Where("user_id = ?", other.ID).Count(&tokens)
+	assert.Equal(t, int64(1), sessions)
+	assert.Equal(t, int64(1), tokens)
+}
+
 // TestGetUserByUsername은 GetUserByUsername 함수를 테스트합니다.
 func TestGetUserByUsername(t *testing.T) {
 	setupTestDB()
@@ -159,4 +205,27 @@ func TestGetUserByUsername(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testUsers[0].Username, user.Username)
 	assert.Equal(t, testUsers[0].Email, user.Email)
-}
\ No newline at end of file
+}
+
+// TestTenantScopedUsers는 조직 범위로 사용자 조회가 제한되는지 테스트합니다.
+func TestTenantScopedUsers(t *testing.T) {
+	setupTestDB()
+	defer cleanupTestData()
+
+	acme := models.User{Username: "acmeuser", Email: "user@acme.example", Password: "unused", Role: "USER", OrganizationID: 1}
+	other := models.User{Username: "otheruser", Email: "user@other.example", Password: "unused", Role: "USER", OrganizationID: 2}
+	database.DB.Create(&acme)
+	database.DB.Create(&other)
+
+	users, err := GetAllUsers(Tenant{OrganizationID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, acme.Username, users[0].Username)
+
+	_, err = GetTenantUserByID(Tenant{OrganizationID: 1}, other.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	user, err := GetTenantUserByID(AllTenants, other.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, other.Username, user.Username)
+}
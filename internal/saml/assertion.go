@@ -0,0 +1,91 @@
+package saml
+
+import (
+	"strings"
+	"time"
+)
+
+// Assertion은 검증된 어설션에서 로그인에 사용하는 정보입니다.
+type Assertion struct {
+	ID           string
+	InResponseTo string // IdP에서 시작한 로그인이면 비어 있음
+	NameID       string
+	NameIDFormat string
+	ExpiresAt    time.Time // 이 시각 이후에는 같은 어설션을 받을 수 없음
+	Attributes   map[string][]string
+}
+
+// Values는 이름 또는 FriendlyName이 일치하는 속성의 값 목록을 반환합니다.
+func (a Assertion) Values(name string) []string {
+	return a.Attributes[name]
+}
+
+// Value는 속성의 첫 번째 값을 반환합니다. 없으면 빈 문자열입니다.
+func (a Assertion) Value(name string) string {
+	if values := a.Values(name); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Email은 설정된 이메일 속성 값을 반환하고, 없으면 이메일 형식의 NameID를 반환합니다.
+func (a Assertion) Email(attribute string) string {
+	if email := a.Value(attribute); email != "" {
+		return email
+	}
+	if a.NameIDFormat == NameIDEmail || strings.Contains(a.NameID, "@") {
+		return a.NameID
+	}
+	return ""
+}
+
+// responseXML은 Response 요소 중 확인하는 항목입니다.
+type responseXML struct {
+	Destination  string `xml:"Destination,attr"`
+	InResponseTo string `xml:"InResponseTo,attr"`
+	Issuer       string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       struct {
+		StatusCode struct {
+			Value string `xml:"Value,attr"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusCode"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status"`
+}
+
+// assertionXML은 Assertion 요소 중 확인하는 항목입니다.
+type assertionXML struct {
+	ID      string `xml:"ID,attr"`
+	Version string `xml:"Version,attr"`
+	Issuer  string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID struct {
+			Format string `xml:"Format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		Confirmations []struct {
+			Method string                     `xml:"Method,attr"`
+			Data   subjectConfirmationDataXML `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter         time.Time `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AttributeStatements []struct {
+		Attributes []struct {
+			Name         string   `xml:"Name,attr"`
+			FriendlyName string   `xml:"FriendlyName,attr"`
+			Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Attribute"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement"`
+}
+
+// subjectConfirmationDataXML은 bearer 주체 확인 정보입니다.
+type subjectConfirmationDataXML struct {
+	InResponseTo string    `xml:"InResponseTo,attr"`
+	Recipient    string    `xml:"Recipient,attr"`
+	NotBefore    time.Time `xml:"NotBefore,attr"`
+	NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+}
@@ -0,0 +1,172 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	// 다이제스트와 서명 검증에 사용하는 해시 함수 등록
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// XML 서명 네임스페이스와 지원하는 알고리즘
+const (
+	nsDSig       = "http://www.w3.org/2000/09/xmldsig#"
+	nsExcC14N    = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	AlgExcC14N   = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	AlgEnveloped = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	AlgRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	AlgRSASHA512 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	AlgSHA256    = "http://www.w3.org/2001/04/xmlenc#sha256"
+	AlgSHA512    = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+// 알고리즘 URI별 해시 함수 (SHA-1은 허용하지 않음)
+var (
+	signatureHashes = map[string]crypto.Hash{AlgRSASHA256: crypto.SHA256, AlgRSASHA512: crypto.SHA512}
+	digestHashes    = map[string]crypto.Hash{AlgSHA256: crypto.SHA256, AlgSHA512: crypto.SHA512}
+)
+
+// errUnsigned는 요소에 서명이 없을 때 반환합니다.
+var errUnsigned = errors.New("서명이 없습니다")
+
+// signed는 요소에 직접 포함된 서명이 있는지 확인합니다.
+func signed(e *element) bool {
+	return len(e.childElements(nsDSig, "Signature")) > 0
+}
+
+// verifySignature는 요소에 직접 포함된 enveloped 서명을 IdP 인증서로 검증합니다.
+// 서명은 이 요소 자체(URI가 "#"+ID)를 참조해야 하며, 서명에 포함된 KeyInfo는 사용하지 않습니다.
+func verifySignature(e *element, certs []*x509.Certificate) error {
+	signatures := e.childElements(nsDSig, "Signature")
+	if len(signatures) == 0 {
+		return errUnsigned
+	} else if len(signatures) > 1 {
+		return errors.New("서명이 여러 개입니다")
+	}
+	signature := signatures[0]
+
+	id := e.attr("ID")
+	if id == "" {
+		return errors.New("서명된 요소에 ID가 없습니다")
+	}
+
+	signedInfo, err := signature.child(nsDSig, "SignedInfo")
+	if err != nil {
+		return err
+	}
+	method, err := signedInfo.child(nsDSig, "CanonicalizationMethod")
+	if err != nil {
+		return err
+	}
+	if method.attr("Algorithm") != AlgExcC14N {
+		return fmt.Errorf("지원하지 않는 정규화 알고리즘입니다: %s", method.attr("Algorithm"))
+	}
+	signatureMethod, err := signedInfo.child(nsDSig, "SignatureMethod")
+	if err != nil {
+		return err
+	}
+	signatureHash, ok := signatureHashes[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("지원하지 않는 서명 알고리즘입니다: %s", signatureMethod.attr("Algorithm"))
+	}
+
+	reference, err := signedInfo.child(nsDSig, "Reference")
+	if err != nil {
+		return err
+	}
+	if reference.attr("URI") != "#"+id {
+		return errors.New("서명이 참조하는 요소가 서명된 요소가 아닙니다")
+	}
+	prefixes, err := referenceTransforms(reference)
+	if err != nil {
+		return err
+	}
+	digestMethod, err := reference.child(nsDSig, "DigestMethod")
+	if err != nil {
+		return err
+	}
+	digestHash, ok := digestHashes[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("지원하지 않는 다이제스트 알고리즘입니다: %s", digestMethod.attr("Algorithm"))
+	}
+	digestValue, err := reference.child(nsDSig, "DigestValue")
+	if err != nil {
+		return err
+	}
+	expected, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("다이제스트 값 형식이 올바르지 않습니다: %w", err)
+	}
+
+	h := digestHash.New()
+	h.Write(canonicalize(e, signature, prefixes))
+	if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+		return errors.New("다이제스트가 일치하지 않습니다")
+	}
+
+	signatureValue, err := signature.child(nsDSig, "SignatureValue")
+	if err != nil {
+		return err
+	}
+	value, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("서명 값 형식이 올바르지 않습니다: %w", err)
+	}
+
+	h = signatureHash.New()
+	h.Write(canonicalize(signedInfo, nil, inclusivePrefixes(method)))
+	sum := h.Sum(nil)
+	for _, cert := range certs {
+		if key, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			if rsa.VerifyPKCS1v15(key, signatureHash, sum, value) == nil {
+				return nil
+			}
+		}
+	}
+	return errors.New("서명이 IdP 인증서와 일치하지 않습니다")
+}
+
+// referenceTransforms는 참조의 변환이 enveloped 서명과 배타적 정규화뿐인지 확인하고 정규화의 포괄 접두사 목록을 반환합니다.
+func referenceTransforms(reference *element) ([]string, error) {
+	transforms, err := reference.child(nsDSig, "Transforms")
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []string
+	canonical := false
+	for _, transform := range transforms.childElements(nsDSig, "Transform") {
+		switch transform.attr("Algorithm") {
+		case AlgEnveloped:
+		case AlgExcC14N:
+			canonical = true
+			prefixes = inclusivePrefixes(transform)
+		default:
+			return nil, fmt.Errorf("지원하지 않는 변환 알고리즘입니다: %s", transform.attr("Algorithm"))
+		}
+	}
+	if !canonical {
+		return nil, errors.New("참조에 배타적 정규화 변환이 없습니다")
+	}
+	return prefixes, nil
+}
+
+// inclusivePrefixes는 정규화 알고리즘 요소의 InclusiveNamespaces PrefixList를 반환합니다.
+func inclusivePrefixes(method *element) []string {
+	for _, ns := range method.childElements(nsExcC14N, "InclusiveNamespaces") {
+		return strings.Fields(ns.attr("PrefixList"))
+	}
+	return nil
+}
+
+// decodeBase64는 줄바꿈과 공백이 포함될 수 있는 base64 값을 디코딩합니다.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -0,0 +1,349 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// ErrInvalidResponse는 IdP 응답을 검증할 수 없을 때 반환합니다.
+var ErrInvalidResponse = errors.New("유효하지 않은 SAML 응답입니다")
+
+// IdP와 이 서비스의 시계 차이로 허용하는 시간
+const clockSkew = time.Minute
+
+// Provider는 SAML IdP 하나에 대한 서비스 제공자(SP)입니다.
+type Provider struct {
+	config config.SAMLProviderConfig
+	certs  []*x509.Certificate
+}
+
+// NewProvider는 설정으로 Provider를 생성합니다. IdP 인증서 파일에는 하나 이상의 PEM 인증서가 있어야 하며,
+// 인증서를 교체하는 동안에는 이전 인증서와 새 인증서를 함께 둘 수 있습니다.
+func NewProvider(cfg config.SAMLProviderConfig) (*Provider, error) {
+	if cfg.IDPEntityID == "" || cfg.IDPSSOURL == "" || cfg.IDPCertificateFile == "" {
+		return nil, errors.New("IdP 엔티티 ID, SSO 주소, 인증서 파일이 필요합니다")
+	}
+
+	data, err := os.ReadFile(cfg.IDPCertificateFile)
+	if err != nil {
+		return nil, fmt.Errorf("IdP 인증서 파일을 읽을 수 없습니다: %w", err)
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("IdP 인증서 파싱 실패: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("IdP 인증서 파일에 인증서가 없습니다")
+	}
+
+	return &Provider{config: cfg, certs: certs}, nil
+}
+
+// Name은 IdP 이름을 반환합니다.
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// IdentityProvider는 이 IdP로 로그인한 사용자의 외부 계정 공급자 이름을 반환합니다.
+func (p *Provider) IdentityProvider() string {
+	return IdentityPrefix + p.config.Name
+}
+
+// Config는 IdP 설정을 반환합니다.
+func (p *Provider) Config() config.SAMLProviderConfig {
+	return p.config
+}
+
+// metadataXML은 SP 메타데이터 문서입니다.
+type metadataXML struct {
+	XMLName  xml.Name `xml:"md:EntityDescriptor"`
+	XMLNS    string   `xml:"xmlns:md,attr"`
+	EntityID string   `xml:"entityID,attr"`
+	SP       struct {
+		AuthnRequestsSigned        bool     `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool     `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string   `xml:"protocolSupportEnumeration,attr"`
+		NameIDFormat               []string `xml:"md:NameIDFormat"`
+		ACS                        struct {
+			Binding   string `xml:"Binding,attr"`
+			Location  string `xml:"Location,attr"`
+			Index     int    `xml:"index,attr"`
+			IsDefault bool   `xml:"isDefault,attr"`
+		} `xml:"md:AssertionConsumerService"`
+	} `xml:"md:SPSSODescriptor"`
+}
+
+// Metadata는 IdP에 등록할 SP 메타데이터를 반환합니다.
+func (p *Provider) Metadata() ([]byte, error) {
+	doc := metadataXML{XMLNS: nsMetadata, EntityID: p.config.EntityID}
+	doc.SP.WantAssertionsSigned = true
+	doc.SP.ProtocolSupportEnumeration = nsProtocol
+	doc.SP.NameIDFormat = []string{NameIDPersistent, NameIDEmail}
+	doc.SP.ACS.Binding = BindingHTTPPost
+	doc.SP.ACS.Location = p.config.ACSURL
+	doc.SP.ACS.IsDefault = true
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// authnRequestXML은 IdP에 보내는 인증 요청입니다.
+type authnRequestXML struct {
+	XMLName                     xml.Name `xml:"samlp:AuthnRequest"`
+	XMLNSProtocol               string   `xml:"xmlns:samlp,attr"`
+	XMLNSAssertion              string   `xml:"xmlns:saml,attr"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	Issuer                      string   `xml:"saml:Issuer"`
+	NameIDPolicy                struct {
+		AllowCreate bool `xml:"AllowCreate,attr"`
+	} `xml:"samlp:NameIDPolicy"`
+}
+
+// AuthnRequestURL은 HTTP-Redirect 바인딩으로 인증 요청을 담은 IdP SSO 주소를 반환합니다.
+// IdP의 응답은 InResponseTo에 requestID를 담아 ACS 주소로 전달됩니다.
+func (p *Provider) AuthnRequestURL(requestID string) (string, error) {
+	request := authnRequestXML{
+		XMLNSProtocol:               nsProtocol,
+		XMLNSAssertion:              nsAssertion,
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                time.Now().UTC().Format(time.RFC3339),
+		Destination:                 p.config.IDPSSOURL,
+		AssertionConsumerServiceURL: p.config.ACSURL,
+		ProtocolBinding:             BindingHTTPPost,
+		Issuer:                      p.config.EntityID,
+	}
+	request.NameIDPolicy.AllowCreate = true
+
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	var compressed bytes.Buffer
+	writer, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	link, err := url.Parse(p.config.IDPSSOURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(compressed.Bytes()))
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// ParseResponse는 HTTP-POST 바인딩으로 받은 SAMLResponse 값을 검증하고 어설션을 반환합니다.
+// 응답 또는 어설션 중 하나 이상이 IdP 인증서로 서명되어 있어야 하며, 발급자, 대상, 수신 주소와 유효 기간을 확인합니다.
+// InResponseTo가 이 서비스가 보낸 요청인지와 어설션 재사용 여부는 호출하는 쪽에서 확인해야 합니다.
+func (p *Provider) ParseResponse(encoded string) (Assertion, error) {
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return Assertion{}, fmt.Errorf("%w: base64 형식이 아닙니다", ErrInvalidResponse)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return Assertion{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if !root.is(nsProtocol, "Response") {
+		return Assertion{}, fmt.Errorf("%w: Response 요소가 아닙니다", ErrInvalidResponse)
+	}
+	if len(root.childElements(nsAssertion, "EncryptedAssertion")) > 0 {
+		return Assertion{}, fmt.Errorf("%w: 암호화된 어설션은 지원하지 않습니다", ErrInvalidResponse)
+	}
+	assertionElement, err := root.child(nsAssertion, "Assertion")
+	if err != nil {
+		return Assertion{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	// 서명된 요소를 검증한 뒤에는 검증한 요소에서만 값을 읽음
+	responseSigned, assertionSigned := signed(root), signed(assertionElement)
+	if !responseSigned && !assertionSigned {
+		return Assertion{}, fmt.Errorf("%w: 응답과 어설션 모두 서명되지 않았습니다", ErrInvalidResponse)
+	}
+	if responseSigned {
+		if err := verifySignature(root, p.certs); err != nil {
+			return Assertion{}, fmt.Errorf("%w: 응답 서명 검증 실패: %v", ErrInvalidResponse, err)
+		}
+	}
+	if assertionSigned {
+		if err := verifySignature(assertionElement, p.certs); err != nil {
+			return Assertion{}, fmt.Errorf("%w: 어설션 서명 검증 실패: %v", ErrInvalidResponse, err)
+		}
+	}
+
+	var response responseXML
+	if err := xml.Unmarshal(canonicalize(root, nil, nil), &response); err != nil {
+		return Assertion{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	var assertion assertionXML
+	if err := xml.Unmarshal(canonicalize(assertionElement, nil, nil), &assertion); err != nil {
+		return Assertion{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	result, err := p.validate(response, assertion, time.Now())
+	if err != nil {
+		return Assertion{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return result, nil
+}
+
+// validate는 응답과 어설션의 내용을 확인하고 어설션 정보를 만듭니다.
+func (p *Provider) validate(response responseXML, assertion assertionXML, now time.Time) (Assertion, error) {
+	if response.Status.StatusCode.Value != StatusSuccess {
+		return Assertion{}, fmt.Errorf("IdP가 로그인을 거부했습니다: %s", response.Status.StatusCode.Value)
+	}
+	if response.Destination != "" && response.Destination != p.config.ACSURL {
+		return Assertion{}, fmt.Errorf("응답 수신 주소가 일치하지 않습니다: %s", response.Destination)
+	}
+	if response.Issuer != "" && strings.TrimSpace(response.Issuer) != p.config.IDPEntityID {
+		return Assertion{}, fmt.Errorf("응답 발급자가 일치하지 않습니다: %s", response.Issuer)
+	}
+	if assertion.Version != "2.0" {
+		return Assertion{}, fmt.Errorf("지원하지 않는 어설션 버전입니다: %s", assertion.Version)
+	}
+	if assertion.ID == "" {
+		return Assertion{}, errors.New("어설션 ID가 없습니다")
+	}
+	if strings.TrimSpace(assertion.Issuer) != p.config.IDPEntityID {
+		return Assertion{}, fmt.Errorf("어설션 발급자가 일치하지 않습니다: %s", assertion.Issuer)
+	}
+
+	nameID := strings.TrimSpace(assertion.Subject.NameID.Value)
+	if nameID == "" {
+		return Assertion{}, errors.New("NameID가 없습니다")
+	}
+
+	// 이 서비스로 보낸 bearer 확인 정보가 하나 이상 유효해야 함
+	var confirmation *subjectConfirmationDataXML
+	for i, sc := range assertion.Subject.Confirmations {
+		data := sc.Data
+		if sc.Method != ConfirmationBearer || data.Recipient != p.config.ACSURL {
+			continue
+		}
+		if data.NotOnOrAfter.IsZero() || !now.Before(data.NotOnOrAfter.Add(clockSkew)) {
+			continue
+		}
+		if !data.NotBefore.IsZero() && now.Add(clockSkew).Before(data.NotBefore) {
+			continue
+		}
+		confirmation = &assertion.Subject.Confirmations[i].Data
+		break
+	}
+	if confirmation == nil {
+		return Assertion{}, errors.New("유효한 bearer 주체 확인 정보가 없습니다")
+	}
+	if response.InResponseTo != "" && response.InResponseTo != confirmation.InResponseTo {
+		return Assertion{}, errors.New("응답과 어설션의 InResponseTo가 일치하지 않습니다")
+	}
+
+	conditions := assertion.Conditions
+	if conditions == nil {
+		return Assertion{}, errors.New("어설션에 조건이 없습니다")
+	}
+	if !conditions.NotBefore.IsZero() && now.Add(clockSkew).Before(conditions.NotBefore) {
+		return Assertion{}, errors.New("어설션이 아직 유효하지 않습니다")
+	}
+	if !conditions.NotOnOrAfter.IsZero() && !now.Before(conditions.NotOnOrAfter.Add(clockSkew)) {
+		return Assertion{}, errors.New("어설션이 만료되었습니다")
+	}
+	if len(conditions.AudienceRestrictions) == 0 {
+		return Assertion{}, errors.New("어설션에 대상 제한이 없습니다")
+	}
+	for _, restriction := range conditions.AudienceRestrictions {
+		if !containsTrimmed(restriction.Audiences, p.config.EntityID) {
+			return Assertion{}, errors.New("어설션 대상에 이 서비스가 없습니다")
+		}
+	}
+
+	// 재사용 방지 기록은 어설션을 더 이상 받을 수 없는 시각까지 유지
+	expiresAt := confirmation.NotOnOrAfter
+	if !conditions.NotOnOrAfter.IsZero() && conditions.NotOnOrAfter.Before(expiresAt) {
+		expiresAt = conditions.NotOnOrAfter
+	}
+
+	attributes := map[string][]string{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			var values []string
+			for _, v := range attr.Values {
+				values = append(values, strings.TrimSpace(v))
+			}
+			attributes[attr.Name] = append(attributes[attr.Name], values...)
+			if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+				attributes[attr.FriendlyName] = append(attributes[attr.FriendlyName], values...)
+			}
+		}
+	}
+
+	return Assertion{
+		ID:           assertion.ID,
+		InResponseTo: confirmation.InResponseTo,
+		NameID:       nameID,
+		NameIDFormat: assertion.Subject.NameID.Format,
+		ExpiresAt:    expiresAt.Add(clockSkew),
+		Attributes:   attributes,
+	}, nil
+}
+
+// MapRole은 역할 속성 값을 설정된 매핑으로 역할로 바꿉니다.
+// 속성 값 순서대로 처음 일치하는 역할을 사용하며, 일치하는 값이 없으면 기본 역할을 반환합니다.
+func (p *Provider) MapRole(assertion Assertion) string {
+	for _, value := range assertion.Values(p.config.RoleAttribute) {
+		if role, ok := p.config.RoleMapping[value]; ok {
+			return role
+		}
+	}
+	return p.config.DefaultRole
+}
+
+// containsTrimmed는 앞뒤 공백을 제외한 값이 목록에 있는지 확인합니다.
+func containsTrimmed(values []string, target string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package saml
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// SAML 네임스페이스와 식별자
+const (
+	nsAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	nsMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	StatusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	ConfirmationBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	NameIDPersistent    = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	NameIDEmail         = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDTransient     = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+)
+
+// IdentityPrefix는 SAML로 로그인한 외부 계정의 공급자 이름 앞에 붙입니다.
+// OpenID Connect 공급자와 이름이 같아도 서로 다른 외부 계정으로 연결됩니다.
+const IdentityPrefix = "saml:"
+
+// providers는 이름별로 설정된 IdP입니다.
+var providers = map[string]*Provider{}
+
+// Init은 설정에서 IdP를 구성합니다. IdP 인증서를 읽을 수 없으면 오류를 반환합니다.
+func Init(cfg *config.Config) error {
+	configured := map[string]*Provider{}
+	for _, pc := range cfg.SAMLProviders {
+		provider, err := NewProvider(pc)
+		if err != nil {
+			return fmt.Errorf("SAML IdP %s 설정 오류: %w", pc.Name, err)
+		}
+		configured[pc.Name] = provider
+	}
+	providers = configured
+	return nil
+}
+
+// SetProviders는 사용할 IdP를 교체합니다.
+func SetProviders(list ...*Provider) {
+	configured := map[string]*Provider{}
+	for _, p := range list {
+		configured[p.Name()] = p
+	}
+	providers = configured
+}
+
+// Get은 이름으로 IdP를 찾습니다.
+func Get(name string) (*Provider, bool) {
+	p, ok := providers[name]
+	return p, ok
+}
+
+// Names는 설정된 IdP 이름 목록을 이름순으로 반환합니다.
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRequestID는 인증 요청 ID를 생성합니다. XML ID는 숫자로 시작할 수 없으므로 밑줄을 붙입니다.
+func NewRequestID() (string, error) {
+	id, err := auth.RandomHex(20)
+	if err != nil {
+		return "", err
+	}
+	return "_" + id, nil
+}
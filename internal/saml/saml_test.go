@@ -0,0 +1,224 @@
+package saml_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml"
+	"github.com/choi-jiwoong/go-quickstart/internal/saml/samltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testEntityID = "https://sp.example.com/saml/corp/metadata"
+	testACSURL   = "https://sp.example.com/saml/corp/acs"
+)
+
+// newTestProvider는 모의 IdP와 그 IdP의 인증서를 신뢰하는 Provider를 생성합니다.
+func newTestProvider(t *testing.T) (*samltest.IdP, *saml.Provider) {
+	idp := samltest.NewIdP("https://idp.example.com")
+	certFile := filepath.Join(t.TempDir(), "idp.pem")
+	if err := idp.WriteCertificate(certFile); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	provider, err := saml.NewProvider(config.SAMLProviderConfig{
+		Name:               "corp",
+		EntityID:           testEntityID,
+		ACSURL:             testACSURL,
+		IDPEntityID:        idp.EntityID,
+		IDPSSOURL:          idp.SSOURL,
+		IDPCertificateFile: certFile,
+		RoleAttribute:      "groups",
+		RoleMapping:        map[string]string{"admins": "ADMIN", "support": "SUPPORT"},
+		DefaultRole:        "USER",
+	})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	return idp, provider
+}
+
+// validResponse는 테스트 Provider가 받아들이는 응답 내용을 반환합니다.
+func validResponse() samltest.Response {
+	return samltest.Response{
+		InResponseTo: "_request-1",
+		ACSURL:       testACSURL,
+		Audience:     testEntityID,
+		NameID:       "user-1001",
+		Attributes: map[string][]string{
+			"email":  {"alice@example.com"},
+			"groups": {"staff", "support"},
+		},
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<root xmlns="urn:default" xmlns:a="urn:a" xmlns:unused="urn:unused">
+  <a:child b="2" a:attr="x" xmlns:c="urn:c" ID="target" c:z="1">
+    text &amp; &lt;more> <!-- comment --><inner xmlns="" attr='q"t'/>
+    <a:empty/>
+  </a:child>
+  <item ID="default"><sub/><plain xmlns=""/></item>
+</root>`
+
+	tests := []struct {
+		name     string
+		id       string
+		expected string
+	}{
+		{
+			name: "사용한 접두사만 선언하고 속성은 네임스페이스와 이름 순",
+			id:   "target",
+			expected: "<a:child xmlns:a=\"urn:a\" xmlns:c=\"urn:c\" ID=\"target\" b=\"2\" a:attr=\"x\" c:z=\"1\">\n" +
+				"    text &amp; &lt;more&gt; <inner attr=\"q&quot;t\"></inner>\n" +
+				"    <a:empty></a:empty>\n" +
+				"  </a:child>",
+		},
+		{
+			name:     "기본 네임스페이스 선언과 해제",
+			id:       "default",
+			expected: `<item xmlns="urn:default" ID="default"><sub></sub><plain xmlns=""></plain></item>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, err := saml.Canonicalize([]byte(doc), tt.id)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(canonical))
+		})
+	}
+
+	// 포괄 접두사 목록에 있는 접두사는 사용하지 않아도 선언
+	canonical, err := saml.Canonicalize([]byte(doc), "default", "unused")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(canonical), `<item xmlns="urn:default" xmlns:unused="urn:unused" ID="default">`))
+
+	_, err = saml.Canonicalize([]byte(`<!DOCTYPE x [<!ENTITY e "v">]><x ID="a">&e;</x>`), "a")
+	assert.Error(t, err)
+}
+
+func TestParseResponse(t *testing.T) {
+	idp, provider := newTestProvider(t)
+
+	tests := []struct {
+		name   string
+		modify func(r *samltest.Response)
+	}{
+		{name: "어설션 서명", modify: func(r *samltest.Response) {}},
+		{name: "응답과 어설션 서명", modify: func(r *samltest.Response) { r.SignResponse = true }},
+		{name: "응답만 서명", modify: func(r *samltest.Response) { r.SignResponse, r.NoAssertionSignature = true, true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validResponse()
+			tt.modify(&r)
+			assertion, err := provider.ParseResponse(idp.Response(r))
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			assert.NotEmpty(t, assertion.ID)
+			assert.Equal(t, "_request-1", assertion.InResponseTo)
+			assert.Equal(t, "user-1001", assertion.NameID)
+			assert.Equal(t, "alice@example.com", assertion.Email("email"))
+			assert.Equal(t, []string{"staff", "support"}, assertion.Values("groups"))
+			assert.Equal(t, "SUPPORT", provider.MapRole(assertion))
+			assert.True(t, assertion.ExpiresAt.After(time.Now()))
+		})
+	}
+}
+
+func TestParseResponseErrors(t *testing.T) {
+	idp, provider := newTestProvider(t)
+	other := samltest.NewIdP(idp.EntityID)
+
+	replace := func(old, new string) func(doc string) string {
+		return func(doc string) string { return strings.Replace(doc, old, new, 1) }
+	}
+
+	tests := []struct {
+		name   string
+		idp    *samltest.IdP
+		modify func(r *samltest.Response)
+		tamper func(doc string) string
+	}{
+		{name: "서명 없음", modify: func(r *samltest.Response) { r.NoAssertionSignature = true }},
+		{name: "다른 키로 서명", idp: other},
+		{name: "서명 후 NameID 변조", tamper: replace(">user-1001<", ">admin<")},
+		{name: "서명 후 속성 변조", tamper: replace(">staff<", ">admins<")},
+		{
+			name: "서명되지 않은 어설션 추가",
+			tamper: func(doc string) string {
+				start := strings.Index(doc, "<saml:Assertion")
+				end := strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+				return doc[:end] + doc[start:end] + doc[end:]
+			},
+		},
+		{
+			name: "서명된 어설션을 다른 요소 안으로 옮기고 변조한 어설션 사용",
+			tamper: func(doc string) string {
+				start := strings.Index(doc, "<saml:Assertion")
+				end := strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+				original := doc[start:end]
+				forged := strings.Replace(original, ">user-1001<", ">admin<", 1)
+				return doc[:start] + "<samlp:Extensions>" + original + "</samlp:Extensions>" + forged + doc[end:]
+			},
+		},
+		{name: "다른 발급자", modify: func(r *samltest.Response) { r.Issuer = "https://evil.example.com" }},
+		{name: "다른 대상", modify: func(r *samltest.Response) { r.Audience = "https://other.example.com" }},
+		{name: "다른 수신 주소", modify: func(r *samltest.Response) { r.ACSURL = "https://other.example.com/acs" }},
+		{name: "만료된 어설션", modify: func(r *samltest.Response) { r.IssuedAt = time.Now().Add(-time.Hour) }},
+		{name: "아직 유효하지 않은 어설션", modify: func(r *samltest.Response) { r.IssuedAt = time.Now().Add(time.Hour) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := idp
+			if tt.idp != nil {
+				signer = tt.idp
+			}
+			r := validResponse()
+			if tt.modify != nil {
+				tt.modify(&r)
+			}
+			doc := string(signer.ResponseXML(r))
+			if tt.tamper != nil {
+				doc = tt.tamper(doc)
+			}
+
+			_, err := provider.ParseResponse(base64.StdEncoding.EncodeToString([]byte(doc)))
+			assert.True(t, errors.Is(err, saml.ErrInvalidResponse), "오류: %v", err)
+		})
+	}
+
+	_, err := provider.ParseResponse("not base64!")
+	assert.True(t, errors.Is(err, saml.ErrInvalidResponse))
+}
+
+func TestAuthnRequestAndMetadata(t *testing.T) {
+	idp, provider := newTestProvider(t)
+
+	location, err := provider.AuthnRequestURL("_request-1")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(location, idp.SSOURL+"?"))
+
+	request, err := samltest.ParseAuthnRequest(location)
+	assert.NoError(t, err)
+	assert.Equal(t, "_request-1", request.ID)
+	assert.Equal(t, testACSURL, request.AssertionConsumerServiceURL)
+	assert.Equal(t, testEntityID, request.Issuer)
+
+	metadata, err := provider.Metadata()
+	assert.NoError(t, err)
+	assert.Contains(t, string(metadata), `entityID="`+testEntityID+`"`)
+	assert.Contains(t, string(metadata), `Location="`+testACSURL+`"`)
+	assert.Contains(t, string(metadata), `WantAssertionsSigned="true"`)
+}
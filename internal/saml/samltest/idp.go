@@ -0,0 +1,239 @@
+// Package samltest는 테스트에서 사용하는 SAML IdP를 제공합니다.
+// 실제 IdP 없이 테스트용 키 쌍으로 서명한 응답을 만듭니다.
+package samltest
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/saml"
+)
+
+// 서명을 넣을 위치 표시
+const (
+	responseSignature  = "{{response-signature}}"
+	assertionSignature = "{{assertion-signature}}"
+)
+
+// IdP는 자체 서명 인증서와 키로 SAML 응답을 발급하는 모의 IdP입니다.
+type IdP struct {
+	EntityID string
+	SSOURL   string
+
+	key  *rsa.PrivateKey
+	cert []byte
+}
+
+// NewIdP는 새 키 쌍과 자체 서명 인증서를 가진 모의 IdP를 생성합니다.
+func NewIdP(entityID string) *IdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: entityID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	return &IdP{EntityID: entityID, SSOURL: "https://idp.example.com/sso", key: key, cert: cert}
+}
+
+// CertificatePEM은 IdP 서명 인증서를 PEM 형식으로 반환합니다.
+func (i *IdP) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.cert})
+}
+
+// WriteCertificate는 IdP 서명 인증서를 PEM 파일로 저장합니다.
+func (i *IdP) WriteCertificate(path string) error {
+	return os.WriteFile(path, i.CertificatePEM(), 0o600)
+}
+
+// Response는 발급할 응답의 내용입니다.
+type Response struct {
+	InResponseTo string // 비어 있으면 IdP에서 시작한 로그인
+	ACSURL       string // 응답 수신 주소와 bearer 확인 정보의 Recipient
+	Audience     string // 보통 SP 엔티티 ID
+	NameID       string
+	Attributes   map[string][]string
+
+	Issuer               string        // 비어 있으면 IdP 엔티티 ID
+	IssuedAt             time.Time     // 비어 있으면 현재 시각
+	Lifetime             time.Duration // 비어 있으면 5분
+	SignResponse         bool          // 응답 전체에도 서명
+	NoAssertionSignature bool          // 어설션에는 서명하지 않음
+}
+
+// Response는 서명된 응답을 HTTP-POST 바인딩의 SAMLResponse 값(base64)으로 반환합니다.
+func (i *IdP) Response(r Response) string {
+	return base64.StdEncoding.EncodeToString(i.ResponseXML(r))
+}
+
+// ResponseXML은 서명된 응답 XML을 반환합니다. 테스트에서 서명 후 내용을 변조할 때 사용합니다.
+func (i *IdP) ResponseXML(r Response) []byte {
+	issuer := r.Issuer
+	if issuer == "" {
+		issuer = i.EntityID
+	}
+	issuedAt := r.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now()
+	}
+	lifetime := r.Lifetime
+	if lifetime == 0 {
+		lifetime = 5 * time.Minute
+	}
+	responseID, assertionID := newID(), newID()
+	instant := issuedAt.UTC().Format(time.RFC3339)
+	expires := issuedAt.Add(lifetime).UTC().Format(time.RFC3339)
+
+	inResponseTo := ""
+	if r.InResponseTo != "" {
+		inResponseTo = fmt.Sprintf(` InResponseTo="%s"`, escape(r.InResponseTo))
+	}
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	fmt.Fprintf(&b, `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="%s" Version="2.0" IssueInstant="%s" Destination="%s"%s>`+"\n",
+		responseID, instant, escape(r.ACSURL), inResponseTo)
+	fmt.Fprintf(&b, "  <saml:Issuer>%s</saml:Issuer>%s\n", escape(issuer), responseSignature)
+	b.WriteString(`  <samlp:Status><samlp:StatusCode Value="` + saml.StatusSuccess + `"/></samlp:Status>` + "\n")
+	fmt.Fprintf(&b, `  <saml:Assertion xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" Version="2.0" ID="%s" IssueInstant="%s">`+"\n",
+		assertionID, instant)
+	fmt.Fprintf(&b, "    <saml:Issuer>%s</saml:Issuer>%s\n", escape(issuer), assertionSignature)
+	b.WriteString("    <saml:Subject>\n")
+	fmt.Fprintf(&b, `      <saml:NameID Format="%s">%s</saml:NameID>`+"\n", saml.NameIDPersistent, escape(r.NameID))
+	fmt.Fprintf(&b, `      <saml:SubjectConfirmation Method="%s"><saml:SubjectConfirmationData Recipient="%s" NotOnOrAfter="%s"%s/></saml:SubjectConfirmation>`+"\n",
+		saml.ConfirmationBearer, escape(r.ACSURL), expires, inResponseTo)
+	b.WriteString("    </saml:Subject>\n")
+	fmt.Fprintf(&b, `    <saml:Conditions NotBefore="%s" NotOnOrAfter="%s"><saml:AudienceRestriction><saml:Audience>%s</saml:Audience></saml:AudienceRestriction></saml:Conditions>`+"\n",
+		instant, expires, escape(r.Audience))
+	fmt.Fprintf(&b, `    <saml:AuthnStatement AuthnInstant="%s" SessionIndex="%s"/>`+"\n", instant, assertionID)
+	if len(r.Attributes) > 0 {
+		names := make([]string, 0, len(r.Attributes))
+		for name := range r.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString("    <saml:AttributeStatement>\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, `      <saml:Attribute Name="%s">`, escape(name))
+			for _, value := range r.Attributes[name] {
+				fmt.Fprintf(&b, `<saml:AttributeValue xsi:type="xs:string">%s</saml:AttributeValue>`, escape(value))
+			}
+			b.WriteString("</saml:Attribute>\n")
+		}
+		b.WriteString("    </saml:AttributeStatement>\n")
+	}
+	b.WriteString("  </saml:Assertion>\n")
+	b.WriteString("</samlp:Response>\n")
+
+	doc := b.String()
+	signature := ""
+	if !r.NoAssertionSignature {
+		signature = i.sign(strings.Replace(strings.Replace(doc, assertionSignature, "", 1), responseSignature, "", 1), assertionID)
+	}
+	doc = strings.Replace(doc, assertionSignature, signature, 1)
+
+	signature = ""
+	if r.SignResponse {
+		signature = i.sign(strings.Replace(doc, responseSignature, "", 1), responseID)
+	}
+	return []byte(strings.Replace(doc, responseSignature, signature, 1))
+}
+
+// sign은 문서에서 ID가 id인 요소에 대한 enveloped 서명 요소를 만듭니다.
+func (i *IdP) sign(doc, id string) string {
+	canonical, err := saml.Canonicalize([]byte(doc), id, "xs")
+	if err != nil {
+		panic(err)
+	}
+	digest := sha256.Sum256(canonical)
+
+	// 정규화된 형식으로 작성하여 그대로 서명
+	signedInfo := `<ds:SignedInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` +
+		`<ds:CanonicalizationMethod Algorithm="` + saml.AlgExcC14N + `"></ds:CanonicalizationMethod>` +
+		`<ds:SignatureMethod Algorithm="` + saml.AlgRSASHA256 + `"></ds:SignatureMethod>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="` + saml.AlgEnveloped + `"></ds:Transform>` +
+		`<ds:Transform Algorithm="` + saml.AlgExcC14N + `"><ec:InclusiveNamespaces xmlns:ec="http://www.w3.org/2001/10/xml-exc-c14n#" PrefixList="xs"></ec:InclusiveNamespaces></ds:Transform>` +
+		`</ds:Transforms><ds:DigestMethod Algorithm="` + saml.AlgSHA256 + `"></ds:DigestMethod>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue></ds:Reference></ds:SignedInfo>`
+
+	sum := sha256.Sum256([]byte(signedInfo))
+	value, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, sum[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` + signedInfo +
+		`<ds:SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(i.cert) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo>` +
+		`</ds:Signature>`
+}
+
+// AuthnRequest는 SP가 보낸 인증 요청 중 응답에 필요한 항목입니다.
+type AuthnRequest struct {
+	ID                          string `xml:"ID,attr"`
+	Destination                 string `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+}
+
+// ParseAuthnRequest는 SP가 리다이렉트한 IdP 주소에서 HTTP-Redirect 바인딩의 인증 요청을 꺼냅니다.
+func ParseAuthnRequest(location string) (AuthnRequest, error) {
+	link, err := url.Parse(location)
+	if err != nil {
+		return AuthnRequest{}, err
+	}
+	compressed, err := base64.StdEncoding.DecodeString(link.Query().Get("SAMLRequest"))
+	if err != nil {
+		return AuthnRequest{}, err
+	}
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		return AuthnRequest{}, err
+	}
+	var request AuthnRequest
+	err = xml.Unmarshal(data, &request)
+	return request, err
+}
+
+// newID는 응답과 어설션의 ID를 생성합니다.
+func newID() string {
+	id, err := saml.NewRequestID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// escape는 문자 데이터와 속성 값을 XML로 이스케이프합니다.
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,388 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xml 접두사에 항상 연결되는 네임스페이스
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// nsDecl은 요소에 선언된 네임스페이스입니다. 접두사가 비어 있으면 기본 네임스페이스입니다.
+type nsDecl struct {
+	prefix string
+	uri    string
+}
+
+// attribute는 네임스페이스 선언이 아닌 속성입니다.
+type attribute struct {
+	prefix string
+	local  string
+	value  string
+}
+
+// procInst는 요소 안의 처리 명령입니다.
+type procInst struct {
+	target string
+	inst   string
+}
+
+// element는 서명 검증과 정규화를 위해 원래의 접두사와 네임스페이스 선언을 그대로 유지하는 XML 요소입니다.
+// 자식은 *element, string(문자 데이터), procInst 중 하나이며 주석은 버립니다.
+type element struct {
+	parent     *element
+	prefix     string
+	local      string
+	namespaces []nsDecl
+	attrs      []attribute
+	children   []interface{}
+}
+
+// parseXML은 문서를 읽어 루트 요소를 반환합니다.
+// DTD가 있거나 선언되지 않은 접두사를 사용하는 문서는 거부합니다.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *element
+	var stack []*element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && root != nil {
+				return nil, errors.New("루트 요소가 여러 개입니다")
+			}
+			e := &element{prefix: t.Name.Space, local: t.Name.Local}
+			for _, a := range t.Attr {
+				switch {
+				case a.Name.Space == "xmlns":
+					e.namespaces = append(e.namespaces, nsDecl{prefix: a.Name.Local, uri: a.Value})
+				case a.Name.Space == "" && a.Name.Local == "xmlns":
+					e.namespaces = append(e.namespaces, nsDecl{uri: a.Value})
+				default:
+					e.attrs = append(e.attrs, attribute{prefix: a.Name.Space, local: a.Name.Local, value: a.Value})
+				}
+			}
+			if len(stack) > 0 {
+				e.parent = stack[len(stack)-1]
+				e.parent.children = append(e.parent.children, e)
+			} else {
+				root = e
+			}
+			if err := e.checkPrefixes(); err != nil {
+				return nil, err
+			}
+			stack = append(stack, e)
+
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, errors.New("시작 태그가 없는 종료 태그입니다")
+			}
+			top := stack[len(stack)-1]
+			if top.prefix != t.Name.Space || top.local != t.Name.Local {
+				return nil, fmt.Errorf("종료 태그가 일치하지 않습니다: %s", t.Name.Local)
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) == 0 {
+				if len(bytes.TrimSpace(t)) > 0 {
+					return nil, errors.New("루트 요소 밖에 문자 데이터가 있습니다")
+				}
+				continue
+			}
+			top := stack[len(stack)-1]
+			if n := len(top.children); n > 0 {
+				if text, ok := top.children[n-1].(string); ok {
+					top.children[n-1] = text + string(t)
+					continue
+				}
+			}
+			top.children = append(top.children, string(t))
+
+		case xml.ProcInst:
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				top.children = append(top.children, procInst{target: t.Target, inst: string(t.Inst)})
+			}
+
+		case xml.Directive:
+			return nil, errors.New("DTD가 포함된 문서는 허용하지 않습니다")
+		}
+	}
+
+	if root == nil || len(stack) > 0 {
+		return nil, errors.New("문서가 완전하지 않습니다")
+	}
+	return root, nil
+}
+
+// checkPrefixes는 요소와 속성의 접두사가 모두 선언되어 있는지 확인합니다.
+func (e *element) checkPrefixes() error {
+	if _, ok := e.lookupNamespace(e.prefix); !ok {
+		return fmt.Errorf("선언되지 않은 접두사입니다: %s", e.prefix)
+	}
+	for _, a := range e.attrs {
+		if a.prefix == "" {
+			continue
+		}
+		if _, ok := e.lookupNamespace(a.prefix); !ok {
+			return fmt.Errorf("선언되지 않은 접두사입니다: %s", a.prefix)
+		}
+	}
+	return nil
+}
+
+// lookupNamespace는 요소에서 접두사에 연결된 네임스페이스를 찾습니다.
+// 선언되지 않은 기본 네임스페이스는 빈 문자열입니다.
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for el := e; el != nil; el = el.parent {
+		for _, ns := range el.namespaces {
+			if ns.prefix == prefix {
+				return ns.uri, true
+			}
+		}
+	}
+	return "", prefix == ""
+}
+
+// namespace는 요소의 네임스페이스를 반환합니다.
+func (e *element) namespace() string {
+	uri, _ := e.lookupNamespace(e.prefix)
+	return uri
+}
+
+// is는 요소의 네임스페이스와 이름이 일치하는지 확인합니다.
+func (e *element) is(space, local string) bool {
+	return e.local == local && e.namespace() == space
+}
+
+// attr은 접두사가 없는 속성의 값을 반환합니다.
+func (e *element) attr(local string) string {
+	for _, a := range e.attrs {
+		if a.prefix == "" && a.local == local {
+			return a.value
+		}
+	}
+	return ""
+}
+
+// childElements는 네임스페이스와 이름이 일치하는 자식 요소를 반환합니다.
+func (e *element) childElements(space, local string) []*element {
+	var result []*element
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok && el.is(space, local) {
+			result = append(result, el)
+		}
+	}
+	return result
+}
+
+// child는 네임스페이스와 이름이 일치하는 자식 요소가 정확히 하나일 때 반환합니다.
+func (e *element) child(space, local string) (*element, error) {
+	children := e.childElements(space, local)
+	if len(children) != 1 {
+		return nil, fmt.Errorf("%s 요소가 하나가 아닙니다 (%d개)", local, len(children))
+	}
+	return children[0], nil
+}
+
+// text는 요소에 직접 포함된 문자 데이터를 반환합니다.
+func (e *element) text() string {
+	var sb strings.Builder
+	for _, child := range e.children {
+		if text, ok := child.(string); ok {
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
+
+// canonicalize는 요소를 주석 없는 배타적 XML 정규화(Exclusive XML Canonicalization 1.0) 형식으로 직렬화합니다.
+// exclude 요소와 그 하위 요소는 출력하지 않으며(enveloped 서명), inclusive에 나열한 접두사는
+// 사용하지 않더라도 포괄적 정규화처럼 출력합니다. 기본 네임스페이스는 "#default"로 지정합니다.
+func canonicalize(e *element, exclude *element, inclusive []string) []byte {
+	c := canonicalizer{exclude: exclude, inclusive: map[string]bool{}}
+	for _, prefix := range inclusive {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		c.inclusive[prefix] = true
+	}
+	c.writeElement(e, map[string]string{})
+	return c.buf.Bytes()
+}
+
+// canonicalizer는 정규화 출력 상태입니다.
+type canonicalizer struct {
+	buf       bytes.Buffer
+	exclude   *element
+	inclusive map[string]bool
+}
+
+// writeElement는 요소를 출력합니다. rendered는 출력된 상위 요소에서 이미 선언한 접두사와 네임스페이스입니다.
+func (c *canonicalizer) writeElement(e *element, rendered map[string]string) {
+	// 요소와 속성에서 실제로 사용하는 접두사와 포괄적으로 처리할 접두사
+	used := map[string]bool{e.prefix: true}
+	for _, a := range e.attrs {
+		if a.prefix != "" {
+			used[a.prefix] = true
+		}
+	}
+	for prefix := range c.inclusive {
+		if _, ok := e.lookupNamespace(prefix); ok {
+			used[prefix] = true
+		}
+	}
+
+	var decls []nsDecl
+	for prefix := range used {
+		if prefix == "xml" {
+			continue
+		}
+		uri, _ := e.lookupNamespace(prefix)
+		previous, ok := rendered[prefix]
+		if prefix == "" && uri == "" && previous == "" {
+			// 상위 요소에서 기본 네임스페이스를 출력하지 않았으면 xmlns=""도 출력하지 않음
+			continue
+		}
+		if !ok || previous != uri {
+			decls = append(decls, nsDecl{prefix: prefix, uri: uri})
+		}
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].prefix < decls[j].prefix })
+
+	attrs := make([]attribute, len(e.attrs))
+	copy(attrs, e.attrs)
+	attrURI := func(a attribute) string {
+		if a.prefix == "" {
+			return ""
+		}
+		uri, _ := e.lookupNamespace(a.prefix)
+		return uri
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		ui, uj := attrURI(attrs[i]), attrURI(attrs[j])
+		if ui != uj {
+			return ui < uj
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	c.buf.WriteByte('<')
+	c.buf.WriteString(qualifiedName(e.prefix, e.local))
+	if len(decls) > 0 {
+		next := make(map[string]string, len(rendered)+len(decls))
+		for prefix, uri := range rendered {
+			next[prefix] = uri
+		}
+		for _, ns := range decls {
+			if ns.prefix == "" {
+				c.buf.WriteString(` xmlns="`)
+			} else {
+				c.buf.WriteString(` xmlns:` + ns.prefix + `="`)
+			}
+			c.buf.WriteString(escapeAttr(ns.uri))
+			c.buf.WriteByte('"')
+			next[ns.prefix] = ns.uri
+		}
+		rendered = next
+	}
+	for _, a := range attrs {
+		c.buf.WriteString(" " + qualifiedName(a.prefix, a.local) + `="`)
+		c.buf.WriteString(escapeAttr(a.value))
+		c.buf.WriteByte('"')
+	}
+	c.buf.WriteByte('>')
+
+	for _, child := range e.children {
+		switch node := child.(type) {
+		case *element:
+			if node != c.exclude {
+				c.writeElement(node, rendered)
+			}
+		case string:
+			c.buf.WriteString(escapeText(node))
+		case procInst:
+			c.buf.WriteString("<?" + node.target)
+			if node.inst != "" {
+				c.buf.WriteString(" " + node.inst)
+			}
+			c.buf.WriteString("?>")
+		}
+	}
+
+	c.buf.WriteString("</" + qualifiedName(e.prefix, e.local) + ">")
+}
+
+// qualifiedName은 접두사와 이름으로 정규화된 이름을 만듭니다.
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+// 정규화 형식의 문자 데이터와 속성 값 이스케이프
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+// escapeText는 문자 데이터를 정규화 형식으로 이스케이프합니다.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// escapeAttr은 속성 값을 정규화 형식으로 이스케이프합니다.
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// Canonicalize는 문서에서 ID 속성이 id인 요소를 찾아 그 안의 서명 요소를 제외하고 배타적 XML 정규화 형식으로 직렬화합니다.
+// 서명 다이제스트를 계산할 때와 같은 결과이므로, 테스트용 IdP가 어설션에 서명할 때 사용합니다.
+// inclusive는 서명 변환의 InclusiveNamespaces PrefixList와 같습니다.
+func Canonicalize(doc []byte, id string, inclusive ...string) ([]byte, error) {
+	root, err := parseXML(doc)
+	if err != nil {
+		return nil, err
+	}
+	target := findByID(root, id)
+	if target == nil {
+		return nil, fmt.Errorf("ID가 %s인 요소가 없습니다", id)
+	}
+	var signature *element
+	if signatures := target.childElements(nsDSig, "Signature"); len(signatures) == 1 {
+		signature = signatures[0]
+	}
+	return canonicalize(target, signature, inclusive), nil
+}
+
+// findByID는 ID 속성이 id인 첫 번째 요소를 찾습니다.
+func findByID(e *element, id string) *element {
+	if e.attr("ID") == id {
+		return e
+	}
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok {
+			if found := findByID(el, id); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,586 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TypeInteger는 필터에서 정수 컬럼(리소스 ID)을 나타내는 유형입니다.
+const TypeInteger = "integer"
+
+// Column은 필터 속성에 대응하는 데이터베이스 컬럼입니다.
+// Type이 TypeBoolean이면 Name은 컬럼 대신 참인 경우의 SQL 조건식입니다.
+type Column struct {
+	Name      string
+	Type      string
+	CaseExact bool
+}
+
+// Resolver는 소문자로 바꾼 속성 경로(예: "emails.value")에 대응하는 컬럼을 찾습니다.
+type Resolver func(path string) (Column, bool)
+
+// Filter는 파싱한 SCIM 필터 식입니다 (RFC 7644 3.4.2.2).
+type Filter interface {
+	// SQL은 필터를 WHERE 조건식과 인자로 바꿉니다.
+	SQL(resolve Resolver) (string, []interface{}, error)
+	// Match는 속성 맵이 필터와 일치하는지 확인합니다. PATCH 경로의 값 필터에 사용합니다.
+	Match(resource map[string]interface{}) bool
+}
+
+// 비교 연산자
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true, "pr": true,
+}
+
+// invalidFilter는 필터 오류를 만듭니다.
+func invalidFilter(format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, ErrTypeInvalidFilter, format, args...)
+}
+
+// ParseFilter는 필터 문자열을 파싱합니다.
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, invalidFilter("필터를 해석할 수 없습니다: %s", p.tokens[p.pos].text)
+	}
+	return filter, nil
+}
+
+// token은 필터의 어휘 단위입니다. 문자열 리터럴은 quoted가 true이고 text에 해석된 값이 들어갑니다.
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize는 필터 문자열을 괄호, 문자열 리터럴, 단어로 나눕니다.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '(' || ch == ')' || ch == '[' || ch == ']':
+			tokens = append(tokens, token{text: string(ch)})
+			i++
+		case ch == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, invalidFilter("닫히지 않은 문자열이 있습니다")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &value); err != nil {
+				return nil, invalidFilter("잘못된 문자열입니다: %s", s[i:end+1])
+			}
+			tokens = append(tokens, token{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{text: s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parser는 토큰 목록을 재귀 하강 방식으로 파싱합니다. 우선순위는 not, and, or 순입니다.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek은 다음 토큰이 단어 word인지 확인합니다. 대소문자는 구분하지 않습니다.
+func (p *parser) peek(word string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, word)
+}
+
+// expect는 다음 토큰이 word이면 넘기고, 아니면 오류를 반환합니다.
+func (p *parser) expect(word string) error {
+	if !p.peek(word) {
+		return invalidFilter("%s이(가) 필요합니다", word)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("and") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Filter, error) {
+	if p.peek("not") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "(" && !p.tokens[p.pos+1].quoted {
+		p.pos++
+		inner, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parseGroup은 괄호로 묶인 필터를 파싱합니다.
+func (p *parser) parseGroup() (Filter, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	inner, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return inner, nil
+}
+
+func (p *parser) parsePrimary() (Filter, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, invalidFilter("필터가 완성되지 않았습니다")
+	}
+	if p.peek("(") {
+		return p.parseGroup()
+	}
+
+	attr := p.tokens[p.pos]
+	if attr.quoted || !validAttrPath(attr.text) {
+		return nil, invalidFilter("잘못된 속성 이름입니다: %s", attr.text)
+	}
+	p.pos++
+	path := stripSchema(attr.text)
+
+	// 값 경로: emails[type eq "work"]
+	if p.peek("[") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return valuePathFilter{attr: path, inner: inner}, nil
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].quoted || !compareOps[strings.ToLower(p.tokens[p.pos].text)] {
+		return nil, invalidFilter("%s 뒤에 비교 연산자가 필요합니다", attr.text)
+	}
+	op := strings.ToLower(p.tokens[p.pos].text)
+	p.pos++
+	if op == "pr" {
+		return compareFilter{attr: path, op: op}, nil
+	}
+
+	if p.pos >= len(p.tokens) {
+		return nil, invalidFilter("%s %s 뒤에 비교 값이 필요합니다", attr.text, op)
+	}
+	value, err := parseValue(p.tokens[p.pos])
+	if err != nil {
+		return nil, err
+	}
+	p.pos++
+	return compareFilter{attr: path, op: op, value: value}, nil
+}
+
+// validAttrPath는 속성 경로에 사용할 수 있는 문자만 있는지 확인합니다.
+func validAttrPath(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("._-:$", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseValue는 비교 값(문자열, 불리언, null, 숫자)을 해석합니다.
+func parseValue(t token) (interface{}, error) {
+	if t.quoted {
+		return t.text, nil
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseFloat(t.text, 64); err == nil {
+		return n, nil
+	}
+	return nil, invalidFilter("잘못된 비교 값입니다: %s", t.text)
+}
+
+// logicalFilter는 and, or 식입니다.
+type logicalFilter struct {
+	op          string
+	left, right Filter
+}
+
+func (f logicalFilter) SQL(resolve Resolver) (string, []interface{}, error) {
+	left, leftArgs, err := f.left.SQL(resolve)
+	if err != nil {
+		return "", nil, err
+	}
+	right, rightArgs, err := f.right.SQL(resolve)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + left + " " + f.op + " " + right + ")", append(leftArgs, rightArgs...), nil
+}
+
+func (f logicalFilter) Match(resource map[string]interface{}) bool {
+	if f.op == "AND" {
+		return f.left.Match(resource) && f.right.Match(resource)
+	}
+	return f.left.Match(resource) || f.right.Match(resource)
+}
+
+// notFilter는 not 식입니다.
+type notFilter struct {
+	inner Filter
+}
+
+func (f notFilter) SQL(resolve Resolver) (string, []interface{}, error) {
+	inner, args, err := f.inner.SQL(resolve)
+	if err != nil {
+		return "", nil, err
+	}
+	return "NOT (" + inner + ")", args, nil
+}
+
+func (f notFilter) Match(resource map[string]interface{}) bool {
+	return !f.inner.Match(resource)
+}
+
+// valuePathFilter는 복합 속성의 값에 대한 필터입니다 (예: emails[type eq "work"]).
+type valuePathFilter struct {
+	attr  string
+	inner Filter
+}
+
+func (f valuePathFilter) SQL(resolve Resolver) (string, []interface{}, error) {
+	prefix := strings.ToLower(f.attr) + "."
+	return f.inner.SQL(func(path string) (Column, bool) {
+		return resolve(prefix + path)
+	})
+}
+
+func (f valuePathFilter) Match(resource map[string]interface{}) bool {
+	for _, value := range attributeValues(resource, f.attr) {
+		if element, ok := value.(map[string]interface{}); ok && f.inner.Match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareFilter는 속성 비교 식입니다.
+type compareFilter struct {
+	attr  string
+	op    string
+	value interface{}
+}
+
+func (f compareFilter) SQL(resolve Resolver) (string, []interface{}, error) {
+	col, ok := resolve(strings.ToLower(f.attr))
+	if !ok {
+		return "", nil, invalidFilter("필터에 사용할 수 없는 속성입니다: %s", f.attr)
+	}
+
+	if f.op == "pr" {
+		switch col.Type {
+		case TypeString:
+			return "(" + col.Name + " IS NOT NULL AND " + col.Name + " <> '')", nil, nil
+		case TypeDateTime:
+			return col.Name + " IS NOT NULL", nil, nil
+		default:
+			return "1 = 1", nil, nil
+		}
+	}
+
+	// null 비교는 존재 여부 확인으로 처리
+	if f.value == nil {
+		present, args, err := compareFilter{attr: f.attr, op: "pr"}.SQL(resolve)
+		switch f.op {
+		case "eq":
+			return "NOT " + present, args, err
+		case "ne":
+			return present, args, err
+		}
+		return "", nil, invalidFilter("null은 eq, ne로만 비교할 수 있습니다")
+	}
+
+	switch col.Type {
+	case TypeString:
+		return f.stringSQL(col)
+	case TypeInteger:
+		return f.integerSQL(col)
+	case TypeBoolean:
+		b, ok := f.value.(bool)
+		if !ok || (f.op != "eq" && f.op != "ne") {
+			return "", nil, invalidFilter("%s은(는) true 또는 false와 eq, ne로만 비교할 수 있습니다", f.attr)
+		}
+		if b == (f.op == "eq") {
+			return "(" + col.Name + ")", nil, nil
+		}
+		return "NOT (" + col.Name + ")", nil, nil
+	case TypeDateTime:
+		s, _ := f.value.(string)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "", nil, invalidFilter("%s은(는) RFC 3339 형식의 시각과 비교해야 합니다", f.attr)
+		}
+		op, ok := sqlOps[f.op]
+		if !ok {
+			return "", nil, invalidFilter("%s에는 %s 연산자를 사용할 수 없습니다", f.attr, f.op)
+		}
+		return col.Name + " " + op + " ?", []interface{}{t}, nil
+	}
+	return "", nil, invalidFilter("필터에 사용할 수 없는 속성입니다: %s", f.attr)
+}
+
+// 순서 비교 연산자의 SQL 표현
+var sqlOps = map[string]string{"eq": "=", "ne": "<>", "gt": ">", "ge": ">=", "lt": "<", "le": "<="}
+
+// likeEscaper는 LIKE 패턴의 특수 문자를 이스케이프합니다. MySQL과 SQLite에서 같게 동작하도록 !를 이스케이프 문자로 사용합니다.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// stringSQL은 문자열 컬럼 비교를 만듭니다. caseExact가 아니면 대소문자를 구분하지 않습니다.
+func (f compareFilter) stringSQL(col Column) (string, []interface{}, error) {
+	s, ok := f.value.(string)
+	if !ok {
+		return "", nil, invalidFilter("%s은(는) 문자열과 비교해야 합니다", f.attr)
+	}
+	name := col.Name
+	if !col.CaseExact {
+		name = "LOWER(" + col.Name + ")"
+		s = strings.ToLower(s)
+	}
+
+	switch f.op {
+	case "co":
+		return name + " LIKE ? ESCAPE '!'", []interface{}{"%" + likeEscaper.Replace(s) + "%"}, nil
+	case "sw":
+		return name + " LIKE ? ESCAPE '!'", []interface{}{likeEscaper.Replace(s) + "%"}, nil
+	case "ew":
+		return name + " LIKE ? ESCAPE '!'", []interface{}{"%" + likeEscaper.Replace(s)}, nil
+	}
+	return name + " " + sqlOps[f.op] + " ?", []interface{}{s}, nil
+}
+
+// integerSQL은 리소스 ID 비교를 만듭니다. ID는 문자열로 전달되며, 숫자가 아닌 ID는 어떤 리소스와도 같지 않습니다.
+func (f compareFilter) integerSQL(col Column) (string, []interface{}, error) {
+	var id int64
+	var err error
+	switch v := f.value.(type) {
+	case string:
+		id, err = strconv.ParseInt(v, 10, 64)
+	case float64:
+		id = int64(v)
+	default:
+		err = fmt.Errorf("잘못된 ID")
+	}
+
+	op, ok := sqlOps[f.op]
+	if !ok {
+		return "", nil, invalidFilter("%s에는 %s 연산자를 사용할 수 없습니다", f.attr, f.op)
+	}
+	if err != nil {
+		switch f.op {
+		case "eq":
+			return "1 = 0", nil, nil
+		case "ne":
+			return "1 = 1", nil, nil
+		}
+		return "", nil, invalidFilter("%s은(는) 숫자로 된 ID와 비교해야 합니다", f.attr)
+	}
+	return col.Name + " " + op + " ?", []interface{}{id}, nil
+}
+
+func (f compareFilter) Match(resource map[string]interface{}) bool {
+	values := lookup(resource, f.attr)
+	if f.op == "pr" {
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	matched := false
+	for _, v := range values {
+		if compareValues(v, f.op, f.value) {
+			matched = true
+			break
+		}
+	}
+	if f.op == "ne" {
+		// ne는 일치하는 값이 하나도 없을 때 참
+		for _, v := range values {
+			if !compareValues(v, "ne", f.value) {
+				return false
+			}
+		}
+		return true
+	}
+	return matched
+}
+
+// compareValues는 속성 값 하나와 비교 값을 연산자로 비교합니다. 문자열은 대소문자를 구분하지 않습니다.
+func compareValues(actual interface{}, op string, expected interface{}) bool {
+	switch e := expected.(type) {
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return op == "ne"
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "ne":
+			return a != e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case bool:
+		a, ok := actual.(bool)
+		switch op {
+		case "eq":
+			return ok && a == e
+		case "ne":
+			return !ok || a != e
+		}
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return op == "ne"
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "ne":
+			return a != e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case nil:
+		switch op {
+		case "eq":
+			return actual == nil
+		case "ne":
+			return actual != nil
+		}
+	}
+	return false
+}
+
+// lookup은 점으로 구분된 속성 경로의 모든 값을 찾습니다. 속성 이름은 대소문자를 구분하지 않으며,
+// 다중 값 속성은 각 값을 펼칩니다. 복합 속성을 하위 속성 없이 비교하면 value 하위 속성을 사용합니다.
+func lookup(resource map[string]interface{}, path string) []interface{} {
+	name, rest, nested := strings.Cut(path, ".")
+
+	var values []interface{}
+	for _, item := range attributeValues(resource, name) {
+		element, complex := item.(map[string]interface{})
+		switch {
+		case nested && complex:
+			values = append(values, lookup(element, rest)...)
+		case nested:
+			// 단순 값에는 하위 속성이 없음
+		case complex:
+			values = append(values, lookup(element, "value")...)
+		default:
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// attributeValues는 대소문자를 구분하지 않고 최상위 속성의 값을 찾습니다. 다중 값 속성은 각 값을 반환합니다.
+func attributeValues(resource map[string]interface{}, name string) []interface{} {
+	for key, value := range resource {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		if items, multi := value.([]interface{}); multi {
+			return items
+		}
+		return []interface{}{value}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package scim
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testColumns는 필터 테스트에서 사용하는 속성과 컬럼입니다.
+var testColumns = map[string]Column{
+	"id":           {Name: "id", Type: TypeInteger},
+	"username":     {Name: "username", Type: TypeString},
+	"externalid":   {Name: "external_id", Type: TypeString, CaseExact: true},
+	"emails.value": {Name: "email", Type: TypeString},
+	"active":       {Name: "status = 'ACTIVE'", Type: TypeBoolean},
+	"meta.created": {Name: "created_at", Type: TypeDateTime},
+}
+
+func testResolver(path string) (Column, bool) {
+	column, ok := testColumns[path]
+	return column, ok
+}
+
+// TestFilterSQL은 필터를 WHERE 조건식으로 바꾸는 것을 테스트합니다.
+func TestFilterSQL(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		where  string
+		args   []interface{}
+	}{
+		{"문자열 일치는 대소문자 구분 없음", `userName eq "Alice"`, "LOWER(username) = ?", []interface{}{"alice"}},
+		{"대소문자를 구분하는 속성", `externalId eq "AbC"`, "external_id = ?", []interface{}{"AbC"}},
+		{"스키마 URN 접두사", `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bob"`, "LOWER(username) = ?", []interface{}{"bob"}},
+		{"연산자 대소문자", `userName EQ "bob"`, "LOWER(username) = ?", []interface{}{"bob"}},
+		{"포함 검색은 LIKE 특수 문자 이스케이프", `userName co "a_b%"`, "LOWER(username) LIKE ? ESCAPE '!'", []interface{}{"%a!_b!%%"}},
+		{"시작 검색", `userName sw "ad"`, "LOWER(username) LIKE ? ESCAPE '!'", []interface{}{"ad%"}},
+		{"끝 검색", `emails.value ew "@example.com"`, "LOWER(email) LIKE ? ESCAPE '!'", []interface{}{"%@example.com"}},
+		{"값 경로", `emails[value eq "a@example.com"]`, "LOWER(email) = ?", []interface{}{"a@example.com"}},
+		{"존재 여부", `externalId pr`, "(external_id IS NOT NULL AND external_id <> '')", nil},
+		{"null 비교", `externalId eq null`, "NOT (external_id IS NOT NULL AND external_id <> '')", nil},
+		{"불리언 참", `active eq true`, "(status = 'ACTIVE')", nil},
+		{"불리언 거짓", `active eq false`, "NOT (status = 'ACTIVE')", nil},
+		{"숫자 ID", `id eq "12"`, "id = ?", []interface{}{int64(12)}},
+		{"숫자가 아닌 ID", `id eq "abc"`, "1 = 0", nil},
+		{"and와 or 우선순위", `userName eq "a" or userName eq "b" and active eq true`,
+			"(LOWER(username) = ? OR (LOWER(username) = ? AND (status = 'ACTIVE')))", []interface{}{"a", "b"}},
+		{"괄호와 not", `not (userName eq "a" or userName eq "b")`,
+			"NOT ((LOWER(username) = ? OR LOWER(username) = ?))", []interface{}{"a", "b"}},
+		{"이스케이프된 따옴표", `userName eq "a\"b"`, "LOWER(username) = ?", []interface{}{`a"b`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			where, args, err := filter.SQL(testResolver)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			assert.Equal(t, tt.where, where)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+// TestFilterErrors는 잘못된 필터가 invalidFilter 오류로 거부되는지 테스트합니다.
+func TestFilterErrors(t *testing.T) {
+	filters := []string{
+		`userName`,
+		`userName eq`,
+		`userName xx "a"`,
+		`userName eq "a`,
+		`(userName eq "a"`,
+		`userName eq "a" and`,
+		`userName eq "a" extra`,
+		`title eq "a"`,
+		`active eq "yes"`,
+		`active co true`,
+		`userName eq 1`,
+		`meta.created gt "어제"`,
+		`id co "1"`,
+	}
+
+	for _, raw := range filters {
+		t.Run(raw, func(t *testing.T) {
+			filter, err := ParseFilter(raw)
+			if err == nil {
+				_, _, err = filter.SQL(testResolver)
+			}
+			scimErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("SCIM 오류가 필요합니다: %v", err)
+			}
+			assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+			assert.Equal(t, ErrTypeInvalidFilter, scimErr.ScimType)
+		})
+	}
+}
+
+// TestFilterMatch는 속성 맵에 대한 필터 일치 확인을 테스트합니다.
+func TestFilterMatch(t *testing.T) {
+	resource := map[string]interface{}{
+		"userName": "Alice",
+		"active":   true,
+		"emails": []interface{}{
+			map[string]interface{}{"value": "alice@work.com", "type": "work"},
+			map[string]interface{}{"value": "alice@home.com", "type": "home"},
+		},
+	}
+
+	tests := []struct {
+		filter string
+		match  bool
+	}{
+		{`username eq "alice"`, true},
+		{`userName ne "alice"`, false},
+		{`active eq true`, true},
+		{`emails eq "alice@home.com"`, true},
+		{`emails.type eq "other"`, false},
+		{`emails[type eq "work" and value co "work"]`, true},
+		{`emails[type eq "work" and value co "home"]`, false},
+		{`title pr`, false},
+		{`not (active eq false)`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filter, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			assert.Equal(t, tt.match, filter.Match(resource))
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package scim
+
+import (
+	"net/http"
+	"strings"
+)
+
+// PatchRequest는 PATCH 요청 본문입니다 (RFC 7644 3.5.2).
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation은 PATCH 연산 하나입니다. Op는 add, replace, remove 중 하나이며 대소문자를 구분하지 않습니다.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// patchPath는 파싱한 PATCH 경로입니다 (예: emails[type eq "work"].value).
+// 스키마에 없는 속성(name, title 등)은 저장하지 않으므로 ignored가 true이고 연산을 무시합니다.
+type patchPath struct {
+	attr    Attribute
+	filter  Filter
+	sub     string
+	ignored bool
+}
+
+// invalidPath는 경로 오류를 만듭니다.
+func invalidPath(format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, ErrTypeInvalidPath, format, args...)
+}
+
+// noTarget은 대상이 없는 연산 오류를 만듭니다.
+func noTarget(format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, ErrTypeNoTarget, format, args...)
+}
+
+// parsePath는 PATCH 경로를 속성, 값 필터, 하위 속성으로 나눕니다.
+func parsePath(schema Schema, path string) (patchPath, error) {
+	path = stripSchema(strings.TrimSpace(path))
+
+	var p patchPath
+	name := path
+	if open := strings.Index(path, "["); open >= 0 {
+		close := strings.LastIndex(path, "]")
+		if close < open {
+			return p, invalidPath("잘못된 경로입니다: %s", path)
+		}
+		filter, err := ParseFilter(path[open+1 : close])
+		if err != nil {
+			return p, invalidPath("잘못된 경로 필터입니다: %s", path)
+		}
+		p.filter = filter
+		name = path[:open]
+		rest := path[close+1:]
+		if rest != "" {
+			if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+				return p, invalidPath("잘못된 경로입니다: %s", path)
+			}
+			p.sub = rest[1:]
+		}
+	} else {
+		name, p.sub, _ = strings.Cut(path, ".")
+	}
+
+	attr, ok := schema.Attribute(name)
+	if !ok {
+		if !validAttrPath(name) {
+			return p, invalidPath("잘못된 경로입니다: %s", path)
+		}
+		p.ignored = true
+		return p, nil
+	}
+	if p.filter != nil && !attr.MultiValued {
+		return p, invalidPath("값 필터는 다중 값 속성에만 사용할 수 있습니다: %s", attr.Name)
+	}
+	if p.sub != "" {
+		sub, ok := attr.SubAttribute(p.sub)
+		if !ok {
+			return p, invalidPath("지원하지 않는 하위 속성입니다: %s.%s", attr.Name, p.sub)
+		}
+		p.sub = sub.Name
+	}
+	p.attr = attr
+	return p, nil
+}
+
+// Apply는 PATCH 연산을 순서대로 리소스 속성 맵에 적용한 결과를 반환합니다.
+// doc은 스키마의 정규 속성 이름을 사용해야 하며, 원본은 변경하지 않습니다.
+func Apply(schema Schema, doc map[string]interface{}, ops []PatchOperation) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(doc))
+	for key, value := range doc {
+		result[key] = value
+	}
+
+	if len(ops) == 0 {
+		return nil, NewError(http.StatusBadRequest, ErrTypeInvalidValue, "PATCH 연산이 없습니다")
+	}
+	for _, op := range ops {
+		var err error
+		switch strings.ToLower(op.Op) {
+		case "add":
+			err = applySet(schema, result, op, true)
+		case "replace":
+			err = applySet(schema, result, op, false)
+		case "remove":
+			err = applyRemove(schema, result, op)
+		default:
+			err = NewError(http.StatusBadRequest, ErrTypeInvalidSyntax, "지원하지 않는 PATCH 연산입니다: %s", op.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// checkMutable은 읽기 전용 속성을 변경하려는지 확인합니다.
+func checkMutable(attr Attribute) error {
+	if attr.Mutability == "readOnly" {
+		return NewError(http.StatusBadRequest, ErrTypeMutability, "%s은(는) 변경할 수 없는 속성입니다", attr.Name)
+	}
+	return nil
+}
+
+// applySet은 add와 replace 연산을 적용합니다. add는 다중 값 속성에 값을 추가하고, replace는 교체합니다.
+func applySet(schema Schema, doc map[string]interface{}, op PatchOperation, add bool) error {
+	if op.Path == "" {
+		values, ok := op.Value.(map[string]interface{})
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "경로가 없는 연산의 값은 객체여야 합니다")
+		}
+		for key, value := range values {
+			// 일부 ID 공급자는 값 객체의 키에 경로를 씀 (예: "emails[type eq \"work\"].value")
+			if strings.ContainsAny(stripSchema(key), ".[") {
+				if err := applySet(schema, doc, PatchOperation{Path: key, Value: value}, add); err != nil {
+					return err
+				}
+				continue
+			}
+			name := schema.CanonicalName(key)
+			if name == "schemas" || name == "id" || name == "meta" {
+				continue
+			}
+			attr, ok := schema.Attribute(name)
+			if !ok {
+				continue
+			}
+			if err := setAttribute(doc, attr, value, add); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path, err := parsePath(schema, op.Path)
+	if err != nil || path.ignored {
+		return err
+	}
+	if err := checkMutable(path.attr); err != nil {
+		return err
+	}
+	value := canonicalValue(path.attr, op.Value)
+
+	switch {
+	case path.filter == nil && path.sub == "":
+		return setAttribute(doc, path.attr, value, add)
+	case path.filter == nil:
+		// 복합 속성의 하위 속성: 다중 값이면 모든 값에 적용
+		if !path.attr.MultiValued {
+			element, _ := doc[path.attr.Name].(map[string]interface{})
+			doc[path.attr.Name] = withField(element, path.sub, value)
+			return nil
+		}
+		elements := elementsOf(doc[path.attr.Name])
+		for i, element := range elements {
+			elements[i] = withField(element, path.sub, value)
+		}
+		doc[path.attr.Name] = toList(elements)
+		return nil
+	}
+
+	elements := elementsOf(doc[path.attr.Name])
+	matched := false
+	for i, element := range elements {
+		if !path.filter.Match(element) {
+			continue
+		}
+		matched = true
+		if path.sub != "" {
+			elements[i] = withField(element, path.sub, value)
+			continue
+		}
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "%s의 값은 객체여야 합니다", op.Path)
+		}
+		for key, v := range fields {
+			element = withField(element, key, v)
+		}
+		elements[i] = element
+	}
+
+	if !matched {
+		// emails[type eq "work"].value처럼 일치 조건이 명확하면 새 값을 추가
+		seed, ok := seedElement(path.filter)
+		if !ok || path.sub == "" {
+			return noTarget("%s와 일치하는 값이 없습니다", op.Path)
+		}
+		seed, _ = canonicalValue(path.attr, seed).(map[string]interface{})
+		elements = append(elements, withField(seed, path.sub, value))
+	}
+	doc[path.attr.Name] = toList(elements)
+	return nil
+}
+
+// setAttribute는 최상위 속성 값을 설정합니다. add이고 다중 값 속성이면 기존 값에 추가합니다.
+func setAttribute(doc map[string]interface{}, attr Attribute, value interface{}, add bool) error {
+	if err := checkMutable(attr); err != nil {
+		return err
+	}
+	value = canonicalValue(attr, value)
+	if !attr.MultiValued {
+		if existing, ok := doc[attr.Name].(map[string]interface{}); ok && add {
+			if fields, ok := value.(map[string]interface{}); ok {
+				for key, v := range fields {
+					existing = withField(existing, key, v)
+				}
+				value = existing
+			}
+		}
+		doc[attr.Name] = value
+		return nil
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	if add {
+		current, _ := doc[attr.Name].([]interface{})
+		for _, v := range values {
+			if !containsValue(current, v) {
+				current = append(current, v)
+			}
+		}
+		values = current
+	}
+	doc[attr.Name] = values
+	return nil
+}
+
+// applyRemove는 remove 연산을 적용합니다.
+func applyRemove(schema Schema, doc map[string]interface{}, op PatchOperation) error {
+	if op.Path == "" {
+		return noTarget("remove 연산에는 경로가 필요합니다")
+	}
+	path, err := parsePath(schema, op.Path)
+	if err != nil || path.ignored {
+		return err
+	}
+	if err := checkMutable(path.attr); err != nil {
+		return err
+	}
+
+	if path.filter == nil && path.sub == "" {
+		// 일부 ID 공급자는 members 경로와 제거할 값 목록을 함께 보냄
+		if removals, ok := op.Value.([]interface{}); ok && path.attr.MultiValued {
+			current, _ := doc[path.attr.Name].([]interface{})
+			var kept []interface{}
+			for _, element := range current {
+				if !containsValue(removals, element) {
+					kept = append(kept, element)
+				}
+			}
+			doc[path.attr.Name] = toList(elementsOfList(kept))
+			return nil
+		}
+		delete(doc, path.attr.Name)
+		return nil
+	}
+
+	if !path.attr.MultiValued {
+		if element, ok := doc[path.attr.Name].(map[string]interface{}); ok {
+			delete(element, path.sub)
+		}
+		return nil
+	}
+
+	var kept []map[string]interface{}
+	for _, element := range elementsOf(doc[path.attr.Name]) {
+		if path.filter != nil && !path.filter.Match(element) {
+			kept = append(kept, element)
+			continue
+		}
+		if path.sub != "" {
+			delete(element, path.sub)
+			kept = append(kept, element)
+		}
+	}
+	doc[path.attr.Name] = toList(kept)
+	return nil
+}
+
+// elementsOf는 다중 값 복합 속성의 값을 복사한 맵 목록으로 바꿉니다. 맵이 아닌 값은 무시합니다.
+func elementsOf(value interface{}) []map[string]interface{} {
+	list, _ := value.([]interface{})
+	return elementsOfList(list)
+}
+
+func elementsOfList(list []interface{}) []map[string]interface{} {
+	elements := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if element, ok := item.(map[string]interface{}); ok {
+			elements = append(elements, withField(element, "", nil))
+		}
+	}
+	return elements
+}
+
+// toList는 맵 목록을 JSON 배열 값으로 바꿉니다.
+func toList(elements []map[string]interface{}) []interface{} {
+	list := make([]interface{}, len(elements))
+	for i, element := range elements {
+		list[i] = element
+	}
+	return list
+}
+
+// withField는 맵을 복사하여 필드를 설정합니다. 필드 이름이 비어 있으면 복사만 합니다.
+func withField(element map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(element)+1)
+	for k, v := range element {
+		out[k] = v
+	}
+	if key != "" {
+		out[key] = value
+	}
+	return out
+}
+
+// containsValue는 목록에 같은 값이 있는지 확인합니다. 복합 값은 value 하위 속성으로 비교합니다.
+func containsValue(list []interface{}, value interface{}) bool {
+	key := valueKey(value)
+	for _, item := range list {
+		if valueKey(item) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// valueKey는 값 비교에 사용할 문자열을 반환합니다.
+func valueKey(value interface{}) interface{} {
+	if element, ok := value.(map[string]interface{}); ok {
+		for key, v := range element {
+			if strings.EqualFold(key, "value") {
+				return v
+			}
+		}
+		return nil
+	}
+	return value
+}
+
+// seedElement는 eq 비교를 and로 묶은 필터에서 새 값의 필드를 만듭니다.
+func seedElement(filter Filter) (map[string]interface{}, bool) {
+	switch f := filter.(type) {
+	case compareFilter:
+		if f.op != "eq" || strings.Contains(f.attr, ".") {
+			return nil, false
+		}
+		return map[string]interface{}{f.attr: f.value}, true
+	case logicalFilter:
+		if f.op != "AND" {
+			return nil, false
+		}
+		left, ok := seedElement(f.left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := seedElement(f.right)
+		if !ok {
+			return nil, false
+		}
+		for key, value := range right {
+			left[key] = value
+		}
+		return left, true
+	}
+	return nil, false
+}
@@ -0,0 +1,184 @@
+package scim
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testUser는 PATCH 테스트에서 사용하는 사용자 리소스입니다.
+func testUser() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":  []interface{}{SchemaUser},
+		"id":       "1",
+		"userName": "alice",
+		"active":   true,
+		"emails": []interface{}{
+			map[string]interface{}{"value": "alice@work.com", "type": "work", "primary": true},
+		},
+	}
+}
+
+// testGroup은 PATCH 테스트에서 사용하는 그룹 리소스입니다.
+func testGroup() map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":     []interface{}{SchemaGroup},
+		"id":          "10",
+		"displayName": "개발팀",
+		"members": []interface{}{
+			map[string]interface{}{"value": "1", "type": "User"},
+			map[string]interface{}{"value": "2", "type": "User"},
+		},
+	}
+}
+
+// TestApplyUser는 사용자 속성에 대한 PATCH 연산을 테스트합니다.
+func TestApplyUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		ops   []PatchOperation
+		check func(t *testing.T, doc map[string]interface{})
+	}{
+		{
+			name: "경로 없는 replace와 문자열 불리언",
+			ops:  []PatchOperation{{Op: "Replace", Value: map[string]interface{}{"Active": "False", "externalId": "ext-1"}}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				assert.Equal(t, false, doc["active"])
+				assert.Equal(t, "ext-1", doc["externalId"])
+			},
+		},
+		{
+			name: "경로가 있는 replace",
+			ops:  []PatchOperation{{Op: "replace", Path: "userName", Value: "alice2"}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				assert.Equal(t, "alice2", doc["userName"])
+			},
+		},
+		{
+			name: "값 필터와 하위 속성",
+			ops:  []PatchOperation{{Op: "replace", Path: `emails[type eq "work"].value`, Value: "new@work.com"}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				emails := doc["emails"].([]interface{})
+				assert.Len(t, emails, 1)
+				assert.Equal(t, "new@work.com", emails[0].(map[string]interface{})["value"])
+			},
+		},
+		{
+			name: "일치하는 값이 없으면 필터 조건으로 새 값 추가",
+			ops:  []PatchOperation{{Op: "add", Path: `emails[type eq "home"].value`, Value: "alice@home.com"}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				emails := doc["emails"].([]interface{})
+				assert.Len(t, emails, 2)
+				assert.Equal(t, map[string]interface{}{"type": "home", "value": "alice@home.com"}, emails[1])
+			},
+		},
+		{
+			name: "값 객체의 키에 쓴 경로",
+			ops:  []PatchOperation{{Op: "replace", Value: map[string]interface{}{`emails[type eq "work"].value`: "key@work.com"}}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				emails := doc["emails"].([]interface{})
+				assert.Equal(t, "key@work.com", emails[0].(map[string]interface{})["value"])
+			},
+		},
+		{
+			name: "스키마에 없는 속성은 무시",
+			ops: []PatchOperation{
+				{Op: "replace", Path: "name.givenName", Value: "Alice"},
+				{Op: "add", Value: map[string]interface{}{"title": "개발자"}},
+			},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				assert.Equal(t, testUser(), doc)
+			},
+		},
+		{
+			name: "속성 제거",
+			ops:  []PatchOperation{{Op: "remove", Path: "externalId"}, {Op: "remove", Path: `emails[type eq "work"]`}},
+			check: func(t *testing.T, doc map[string]interface{}) {
+				assert.NotContains(t, doc, "externalId")
+				assert.Empty(t, doc["emails"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := testUser()
+			doc, err := Apply(UserSchema, original, tt.ops)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			tt.check(t, doc)
+			assert.Equal(t, testUser(), original, "원본은 변경하지 않아야 합니다")
+		})
+	}
+}
+
+// TestApplyGroupMembers는 그룹 구성원에 대한 PATCH 연산을 테스트합니다.
+func TestApplyGroupMembers(t *testing.T) {
+	members := func(doc map[string]interface{}) []string {
+		var values []string
+		for _, member := range doc["members"].([]interface{}) {
+			values = append(values, member.(map[string]interface{})["value"].(string))
+		}
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		ops      []PatchOperation
+		expected []string
+	}{
+		{"구성원 추가는 중복 없이", []PatchOperation{{Op: "add", Path: "members", Value: []interface{}{
+			map[string]interface{}{"value": "2"}, map[string]interface{}{"value": "3"},
+		}}}, []string{"1", "2", "3"}},
+		{"필터로 구성원 제거", []PatchOperation{{Op: "remove", Path: `members[value eq "1"]`}}, []string{"2"}},
+		{"값 목록으로 구성원 제거", []PatchOperation{{Op: "remove", Path: "members", Value: []interface{}{
+			map[string]interface{}{"value": "2"},
+		}}}, []string{"1"}},
+		{"구성원 교체", []PatchOperation{{Op: "replace", Path: "members", Value: []interface{}{
+			map[string]interface{}{"value": "5"},
+		}}}, []string{"5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Apply(GroupSchema, testGroup(), tt.ops)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			assert.Equal(t, tt.expected, members(doc))
+		})
+	}
+}
+
+// TestApplyErrors는 잘못된 PATCH 연산이 SCIM 오류로 거부되는지 테스트합니다.
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		ops      []PatchOperation
+		scimType string
+	}{
+		{"연산 없음", nil, ErrTypeInvalidValue},
+		{"지원하지 않는 연산", []PatchOperation{{Op: "move", Path: "userName"}}, ErrTypeInvalidSyntax},
+		{"경로 없는 remove", []PatchOperation{{Op: "remove"}}, ErrTypeNoTarget},
+		{"읽기 전용 속성", []PatchOperation{{Op: "replace", Path: "roles", Value: "ADMIN"}}, ErrTypeMutability},
+		{"ID 변경", []PatchOperation{{Op: "replace", Path: "id", Value: "2"}}, ErrTypeMutability},
+		{"잘못된 값 필터", []PatchOperation{{Op: "replace", Path: `emails[type eq].value`, Value: "x"}}, ErrTypeInvalidPath},
+		{"단일 값 속성의 값 필터", []PatchOperation{{Op: "replace", Path: `userName[value eq "a"]`, Value: "x"}}, ErrTypeInvalidPath},
+		{"일치하는 값이 없는 필터", []PatchOperation{{Op: "replace", Path: `emails[type eq "home"]`, Value: map[string]interface{}{"value": "x"}}}, ErrTypeNoTarget},
+		{"경로 없는 연산의 값이 객체가 아님", []PatchOperation{{Op: "add", Value: "x"}}, ErrTypeInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply(UserSchema, testUser(), tt.ops)
+			scimErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("SCIM 오류가 필요합니다: %v", err)
+			}
+			assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+			assert.Equal(t, tt.scimType, scimErr.ScimType)
+		})
+	}
+}
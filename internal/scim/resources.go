@@ -0,0 +1,59 @@
+package scim
+
+// User는 사용자 리소스 표현입니다 (RFC 7643 4.1). 이 서비스가 저장하는 속성만 포함합니다.
+type User struct {
+	Schemas    []string     `json:"schemas"`
+	ID         string       `json:"id,omitempty"`
+	ExternalID string       `json:"externalId,omitempty"`
+	UserName   string       `json:"userName"`
+	Active     *bool        `json:"active,omitempty"`
+	Password   string       `json:"password,omitempty"`
+	Emails     []MultiValue `json:"emails,omitempty"`
+	Roles      []MultiValue `json:"roles,omitempty"`
+	Groups     []Member     `json:"groups,omitempty"`
+	Meta       *Meta        `json:"meta,omitempty"`
+}
+
+// PrimaryEmail은 주 이메일 주소를 반환합니다. 주 이메일 주소가 없으면 첫 번째 이메일 주소를 반환합니다.
+func (u User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// Group은 그룹 리소스 표현입니다 (RFC 7643 4.2).
+type Group struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []Member `json:"members,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// MultiValue는 이메일, 역할처럼 여러 값을 가질 수 있는 속성의 값 하나입니다.
+type MultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Member는 다른 리소스를 가리키는 값입니다. Type은 User 또는 Group입니다.
+type Member struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// 구성원 리소스 유형
+const (
+	MemberTypeUser  = "User"
+	MemberTypeGroup = "Group"
+)
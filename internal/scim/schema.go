@@ -0,0 +1,271 @@
+package scim
+
+import (
+	"strings"
+)
+
+// 속성 유형
+const (
+	TypeString    = "string"
+	TypeBoolean   = "boolean"
+	TypeComplex   = "complex"
+	TypeDateTime  = "dateTime"
+	TypeReference = "reference"
+)
+
+// Attribute는 스키마의 속성 정의입니다 (RFC 7643 7).
+type Attribute struct {
+	Name          string      `json:"name"`
+	Type          string      `json:"type"`
+	MultiValued   bool        `json:"multiValued"`
+	Description   string      `json:"description,omitempty"`
+	Required      bool        `json:"required"`
+	CaseExact     bool        `json:"caseExact"`
+	Mutability    string      `json:"mutability"`
+	Returned      string      `json:"returned"`
+	Uniqueness    string      `json:"uniqueness"`
+	SubAttributes []Attribute `json:"subAttributes,omitempty"`
+}
+
+// Schema는 리소스 스키마 정의입니다.
+type Schema struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Attributes  []Attribute `json:"attributes"`
+}
+
+// 모든 리소스에 공통인 속성의 정규 이름 (RFC 7643 3.1)
+var commonAttributes = []string{"schemas", "id", "externalId", "meta"}
+
+// 공통 속성 중 PATCH 경로로 지정할 수 있는 속성의 정의
+var commonAttributeDefs = []Attribute{
+	{Name: "id", Type: TypeString, CaseExact: true, Mutability: "readOnly", Returned: "always", Uniqueness: "server"},
+	{Name: "externalId", Type: TypeString, CaseExact: true, Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+	{Name: "meta", Type: TypeComplex, Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+}
+
+// attribute는 자주 쓰는 속성 정의를 만듭니다.
+func attribute(name, typ, mutability string) Attribute {
+	return Attribute{Name: name, Type: typ, Mutability: mutability, Returned: "default", Uniqueness: "none"}
+}
+
+// memberAttributes는 다른 리소스를 가리키는 복합 속성의 하위 속성입니다.
+func memberAttributes(mutability string) []Attribute {
+	return []Attribute{
+		attribute("value", TypeString, mutability),
+		attribute("$ref", TypeReference, mutability),
+		attribute("display", TypeString, "readOnly"),
+		attribute("type", TypeString, mutability),
+	}
+}
+
+// UserSchema는 사용자 리소스의 스키마입니다. 이 서비스가 저장하는 속성만 정의합니다.
+var UserSchema = Schema{
+	ID:          SchemaUser,
+	Name:        "User",
+	Description: "사용자 계정",
+	Attributes: []Attribute{
+		{Name: "userName", Type: TypeString, Required: true, Mutability: "readWrite", Returned: "always", Uniqueness: "server",
+			Description: "로그인에 사용하는 사용자명 (3~50자)"},
+		{Name: "active", Type: TypeBoolean, Mutability: "readWrite", Returned: "default", Uniqueness: "none",
+			Description: "로그인할 수 있는 계정인지 여부"},
+		{Name: "password", Type: TypeString, Mutability: "writeOnly", Returned: "never", Uniqueness: "none",
+			Description: "비밀번호 (비밀번호 정책 적용)"},
+		{Name: "emails", Type: TypeComplex, MultiValued: true, Required: true, Mutability: "readWrite", Returned: "default", Uniqueness: "none",
+			Description: "이메일 주소, 주 이메일 주소 하나만 저장됩니다",
+			SubAttributes: []Attribute{
+				attribute("value", TypeString, "readWrite"),
+				attribute("type", TypeString, "readWrite"),
+				attribute("primary", TypeBoolean, "readWrite"),
+			}},
+		{Name: "roles", Type: TypeComplex, MultiValued: true, Mutability: "readOnly", Returned: "default", Uniqueness: "none",
+			Description: "사용자의 역할, 역할은 관리 API로만 변경할 수 있습니다",
+			SubAttributes: []Attribute{
+				attribute("value", TypeString, "readOnly"),
+				attribute("primary", TypeBoolean, "readOnly"),
+			}},
+		{Name: "groups", Type: TypeComplex, MultiValued: true, Mutability: "readOnly", Returned: "default", Uniqueness: "none",
+			Description:   "사용자가 직접 또는 하위 그룹을 통해 속한 그룹",
+			SubAttributes: memberAttributes("readOnly")},
+	},
+}
+
+// GroupSchema는 그룹 리소스의 스키마입니다.
+var GroupSchema = Schema{
+	ID:          SchemaGroup,
+	Name:        "Group",
+	Description: "사용자 그룹",
+	Attributes: []Attribute{
+		{Name: "displayName", Type: TypeString, Required: true, Mutability: "readWrite", Returned: "always", Uniqueness: "server",
+			Description: "조직 안에서 고유한 그룹 이름 (2~100자)"},
+		{Name: "members", Type: TypeComplex, MultiValued: true, Mutability: "readWrite", Returned: "default", Uniqueness: "none",
+			Description:   "그룹 구성원, type이 Group이면 하위 그룹입니다",
+			SubAttributes: memberAttributes("immutable")},
+	},
+}
+
+// Schemas는 지원하는 리소스 스키마 목록입니다.
+var Schemas = []Schema{UserSchema, GroupSchema}
+
+// Resource는 /Schemas 엔드포인트에서 반환하는 스키마 리소스 표현을 만듭니다.
+func (s Schema) Resource(baseURL string) map[string]interface{} {
+	return map[string]interface{}{
+		"schemas":     []string{SchemaSchema},
+		"id":          s.ID,
+		"name":        s.Name,
+		"description": s.Description,
+		"attributes":  s.Attributes,
+		"meta": Meta{
+			ResourceType: "Schema",
+			Location:     baseURL + "/Schemas/" + s.ID,
+		},
+	}
+}
+
+// Attribute는 이름으로 최상위 속성 정의를 찾습니다. id, externalId, meta 공통 속성도 찾으며 대소문자는 구분하지 않습니다.
+func (s Schema) Attribute(name string) (Attribute, bool) {
+	if attr, ok := findAttribute(s.Attributes, name); ok {
+		return attr, true
+	}
+	return findAttribute(commonAttributeDefs, name)
+}
+
+// SubAttribute는 이름으로 하위 속성 정의를 찾습니다. 대소문자는 구분하지 않습니다.
+func (a Attribute) SubAttribute(name string) (Attribute, bool) {
+	return findAttribute(a.SubAttributes, name)
+}
+
+// findAttribute는 대소문자를 구분하지 않고 속성 정의를 찾습니다.
+func findAttribute(attributes []Attribute, name string) (Attribute, bool) {
+	for _, attr := range attributes {
+		if strings.EqualFold(attr.Name, name) {
+			return attr, true
+		}
+	}
+	return Attribute{}, false
+}
+
+// CanonicalName은 속성 이름을 스키마에 정의된 정규 이름으로 바꿉니다.
+// 스키마 URN 접두사는 제거하며, 정의되지 않은 이름은 그대로 반환합니다.
+func (s Schema) CanonicalName(name string) string {
+	name = stripSchema(name)
+	for _, common := range commonAttributes {
+		if strings.EqualFold(common, name) {
+			return common
+		}
+	}
+	if attr, ok := s.Attribute(name); ok {
+		return attr.Name
+	}
+	return name
+}
+
+// Canonicalize는 속성 이름을 스키마의 정규 이름으로 바꾸고, 문자열로 전달된 불리언 값을 불리언으로 바꿉니다.
+// SCIM 속성 이름은 대소문자를 구분하지 않으며, 일부 ID 공급자는 불리언을 "True"처럼 문자열로 보냅니다.
+func (s Schema) Canonicalize(doc map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(doc))
+	for key, value := range doc {
+		name := s.CanonicalName(key)
+		if attr, ok := s.Attribute(name); ok {
+			value = canonicalValue(attr, value)
+		}
+		out[name] = value
+	}
+	return out
+}
+
+// canonicalValue는 속성 정의에 맞게 값의 하위 속성 이름과 불리언 값을 정규화합니다.
+func canonicalValue(attr Attribute, value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			values[i] = canonicalValue(attr, item)
+		}
+		return values
+	case map[string]interface{}:
+		if attr.Type != TypeComplex {
+			return v
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if sub, ok := attr.SubAttribute(key); ok {
+				out[sub.Name] = canonicalValue(sub, item)
+			} else {
+				out[key] = item
+			}
+		}
+		return out
+	case string:
+		if attr.Type == TypeBoolean {
+			switch strings.ToLower(v) {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// stripSchema는 속성 이름 앞의 핵심 스키마 URN 접두사를 제거합니다.
+func stripSchema(name string) string {
+	for _, schema := range []string{SchemaUser, SchemaGroup} {
+		if len(name) > len(schema) && strings.EqualFold(name[:len(schema)+1], schema+":") {
+			return name[len(schema)+1:]
+		}
+	}
+	return name
+}
+
+// ServiceProviderConfig는 이 서비스가 지원하는 SCIM 기능을 설명하는 문서를 반환합니다.
+func ServiceProviderConfig(baseURL string) map[string]interface{} {
+	supported := func(ok bool) map[string]bool {
+		return map[string]bool{"supported": ok}
+	}
+	return map[string]interface{}{
+		"schemas":          []string{SchemaServiceProviderConfig},
+		"documentationUri": "",
+		"patch":            supported(true),
+		"bulk":             map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":           map[string]interface{}{"supported": true, "maxResults": MaxResults},
+		"changePassword":   supported(true),
+		"sort":             supported(false),
+		"etag":             supported(true),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "SCIM 전용 토큰을 Authorization: Bearer 헤더로 전달합니다",
+			"primary":     true,
+		}},
+		"meta": Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     baseURL + "/ServiceProviderConfig",
+		},
+	}
+}
+
+// ResourceTypes는 지원하는 리소스 유형 목록을 반환합니다.
+func ResourceTypes(baseURL string) []interface{} {
+	resourceType := func(name, endpoint, schema string) map[string]interface{} {
+		return map[string]interface{}{
+			"schemas":  []string{SchemaResourceType},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+			"meta": Meta{
+				ResourceType: "ResourceType",
+				Location:     baseURL + "/ResourceTypes/" + name,
+			},
+		}
+	}
+	return []interface{}{
+		resourceType("User", "/Users", SchemaUser),
+		resourceType("Group", "/Groups", SchemaGroup),
+	}
+}
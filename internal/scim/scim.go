@@ -0,0 +1,212 @@
+// Package scim은 인사 시스템과 ID 공급자가 계정을 프로비저닝하는 SCIM 2.0 프로토콜(RFC 7643, RFC 7644)의
+// 스키마, 필터, PATCH 연산과 오류 형식을 제공합니다. 사용자와 그룹 모델과의 변환은 API 핸들러에서 담당합니다.
+package scim
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 스키마와 메시지 URN
+const (
+	SchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	SchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// ContentType은 SCIM 요청과 응답의 미디어 유형입니다.
+const ContentType = "application/scim+json"
+
+// 목록 조회 한 번에 반환하는 최대 리소스 수와 기본값
+const (
+	MaxResults     = 200
+	DefaultResults = 100
+)
+
+// 오류 응답의 scimType 값 (RFC 7644 3.12)
+const (
+	ErrTypeInvalidFilter = "invalidFilter"
+	ErrTypeUniqueness    = "uniqueness"
+	ErrTypeMutability    = "mutability"
+	ErrTypeInvalidSyntax = "invalidSyntax"
+	ErrTypeInvalidPath   = "invalidPath"
+	ErrTypeNoTarget      = "noTarget"
+	ErrTypeInvalidValue  = "invalidValue"
+)
+
+// Error는 SCIM 오류 응답입니다.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+// NewError는 상태 코드와 scimType, 메시지로 오류를 생성합니다.
+func NewError(status int, scimType, format string, args ...interface{}) *Error {
+	return &Error{Status: status, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Error는 오류 메시지를 반환합니다.
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// MarshalJSON은 RFC 7644 3.12 형식으로 오류를 직렬화합니다. status는 문자열입니다.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{
+		"schemas": []string{SchemaError},
+		"status":  fmt.Sprint(e.Status),
+		"detail":  e.Detail,
+	}
+	if e.ScimType != "" {
+		body["scimType"] = e.ScimType
+	}
+	return json.Marshal(body)
+}
+
+// ErrNotFound는 리소스를 찾을 수 없을 때의 오류를 반환합니다.
+func ErrNotFound(resourceType, id string) *Error {
+	return NewError(http.StatusNotFound, "", "%s %s을(를) 찾을 수 없습니다", resourceType, id)
+}
+
+// Meta는 리소스의 메타데이터입니다.
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+	Version      string     `json:"version,omitempty"`
+}
+
+// ListResponse는 목록 조회 응답입니다. StartIndex는 1부터 시작합니다.
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse는 한 페이지의 리소스로 목록 조회 응답을 만듭니다.
+func NewListResponse(resources []interface{}, total int64, startIndex int) ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// Pagination은 startIndex와 count 쿼리 파라미터를 조회 위치와 개수로 바꿉니다.
+// 잘못된 값은 RFC 7644 3.4.2.4에 따라 가장 가까운 유효한 값으로 처리합니다.
+func Pagination(startIndex, count string) (start, offset, limit int) {
+	start = 1
+	if n, err := strconv.Atoi(startIndex); err == nil && n > 1 {
+		start = n
+	}
+	limit = DefaultResults
+	if n, err := strconv.Atoi(count); err == nil {
+		limit = n
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	if limit > MaxResults {
+		limit = MaxResults
+	}
+	return start, start - 1, limit
+}
+
+// ETag는 리소스 표현의 해시로 약한 ETag를 만듭니다.
+// 리소스의 meta.version과 ETag 헤더에 같은 값을 사용합니다.
+func ETag(resource interface{}) (string, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:8]) + `"`, nil
+}
+
+// MatchETag는 If-Match 또는 If-None-Match 헤더 값에 ETag가 포함되어 있는지 확인합니다.
+// 약한 비교를 사용하므로 W/ 접두사는 무시합니다.
+func MatchETag(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Project는 attributes와 excludedAttributes 쿼리 파라미터에 따라 리소스의 최상위 속성을 고릅니다.
+// schemas, id, meta는 항상 포함됩니다.
+func Project(resource map[string]interface{}, attributes, excluded string) map[string]interface{} {
+	if attributes == "" && excluded == "" {
+		return resource
+	}
+
+	names := func(list string) map[string]bool {
+		set := map[string]bool{}
+		for _, name := range strings.Split(list, ",") {
+			if name = strings.ToLower(stripSchema(strings.TrimSpace(name))); name != "" {
+				// 하위 속성을 지정하면 상위 속성 전체를 대상으로 함
+				name, _, _ = strings.Cut(name, ".")
+				set[name] = true
+			}
+		}
+		return set
+	}
+	include, exclude := names(attributes), names(excluded)
+
+	projected := map[string]interface{}{}
+	for key, value := range resource {
+		lower := strings.ToLower(key)
+		always := lower == "schemas" || lower == "id" || lower == "meta"
+		if !always && ((len(include) > 0 && !include[lower]) || exclude[lower]) {
+			continue
+		}
+		projected[key] = value
+	}
+	return projected
+}
+
+// ToMap은 리소스를 JSON 속성 맵으로 바꿉니다.
+func ToMap(resource interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// FromMap은 속성 맵을 스키마의 속성 이름으로 정규화한 뒤 리소스 구조체로 바꿉니다.
+func FromMap(schema Schema, doc map[string]interface{}, resource interface{}) error {
+	data, err := json.Marshal(schema.Canonicalize(doc))
+	if err != nil {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidSyntax, "잘못된 요청 형식입니다: %v", err)
+	}
+	if err := json.Unmarshal(data, resource); err != nil {
+		return NewError(http.StatusBadRequest, ErrTypeInvalidValue, "잘못된 속성 값입니다: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPagination은 startIndex와 count 파라미터 해석을 테스트합니다.
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		startIndex, count    string
+		start, offset, limit int
+	}{
+		{"", "", 1, 0, DefaultResults},
+		{"11", "10", 11, 10, 10},
+		{"0", "-5", 1, 0, 0},
+		{"abc", "100000", 1, 0, MaxResults},
+	}
+
+	for _, tt := range tests {
+		start, offset, limit := Pagination(tt.startIndex, tt.count)
+		assert.Equal(t, tt.start, start)
+		assert.Equal(t, tt.offset, offset)
+		assert.Equal(t, tt.limit, limit)
+	}
+}
+
+// TestETag는 ETag 생성과 If-Match 비교를 테스트합니다.
+func TestETag(t *testing.T) {
+	etag, err := ETag(User{UserName: "alice"})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	other, _ := ETag(User{UserName: "bob"})
+
+	assert.NotEqual(t, etag, other)
+	assert.True(t, MatchETag(etag, etag))
+	assert.True(t, MatchETag(`"x", `+etag[2:], etag), "약한 비교는 W/ 접두사를 무시합니다")
+	assert.True(t, MatchETag("*", etag))
+	assert.False(t, MatchETag(other, etag))
+	assert.False(t, MatchETag("", etag))
+}
+
+// TestProject는 attributes와 excludedAttributes에 따른 속성 선택을 테스트합니다.
+func TestProject(t *testing.T) {
+	resource := map[string]interface{}{
+		"schemas": []string{SchemaUser}, "id": "1", "meta": Meta{},
+		"userName": "alice", "emails": []interface{}{}, "groups": []interface{}{},
+	}
+
+	projected := Project(resource, "userName,emails.value", "")
+	assert.ElementsMatch(t, []string{"schemas", "id", "meta", "userName", "emails"}, keys(projected))
+
+	projected = Project(resource, "", "urn:ietf:params:scim:schemas:core:2.0:User:groups")
+	assert.ElementsMatch(t, []string{"schemas", "id", "meta", "userName", "emails"}, keys(projected))
+}
+
+func keys(m map[string]interface{}) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
+
+// TestErrorJSON은 오류 응답 형식을 테스트합니다.
+func TestErrorJSON(t *testing.T) {
+	data, err := json.Marshal(NewError(http.StatusConflict, ErrTypeUniqueness, "이미 사용 중인 %s입니다", "사용자명"))
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal(data, &body)
+	assert.Equal(t, "409", body["status"])
+	assert.Equal(t, ErrTypeUniqueness, body["scimType"])
+	assert.Equal(t, "이미 사용 중인 사용자명입니다", body["detail"])
+	assert.Equal(t, []interface{}{SchemaError}, body["schemas"])
+}
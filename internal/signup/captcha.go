@@ -0,0 +1,64 @@
+package signup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CaptchaVerifier는 캡차 응답 토큰을 확인하는 구현이 제공해야 하는 인터페이스입니다.
+type CaptchaVerifier interface {
+	// Verify는 클라이언트가 제출한 캡차 토큰이 유효한지 확인합니다.
+	Verify(token, remoteIP string) (bool, error)
+}
+
+// SiteVerifyCaptcha는 siteverify 형식의 HTTP API로 캡차를 확인하는 CaptchaVerifier 구현입니다.
+// reCAPTCHA, hCaptcha, Cloudflare Turnstile이 같은 요청/응답 형식을 사용합니다.
+type SiteVerifyCaptcha struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+// NewSiteVerifyCaptcha는 확인 API 주소와 비밀 키로 SiteVerifyCaptcha를 생성합니다.
+func NewSiteVerifyCaptcha(verifyURL, secret string) *SiteVerifyCaptcha {
+	return &SiteVerifyCaptcha{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Verify는 확인 API에 토큰을 보내 유효성을 확인합니다.
+func (v *SiteVerifyCaptcha) Verify(token, remoteIP string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	resp, err := v.client.PostForm(v.verifyURL, form)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("캡차 확인 API 응답 오류: %s", resp.Status)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
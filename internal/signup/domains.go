@@ -0,0 +1,76 @@
+package signup
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DomainList는 이메일 도메인 집합입니다. 목록에 있는 도메인의 하위 도메인도 포함된 것으로 봅니다.
+type DomainList struct {
+	domains map[string]struct{}
+}
+
+// NewDomainList는 도메인 목록으로 DomainList를 생성합니다.
+func NewDomainList(domains []string) *DomainList {
+	list := &DomainList{domains: make(map[string]struct{}, len(domains))}
+	for _, domain := range domains {
+		if domain = normalizeDomain(domain); domain != "" {
+			list.domains[domain] = struct{}{}
+		}
+	}
+	return list
+}
+
+// LoadDomainList는 한 줄에 하나씩 도메인이 적힌 파일을 읽습니다.
+// 빈 줄과 '#'으로 시작하는 주석 줄은 무시됩니다.
+func LoadDomainList(path string) (*DomainList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("도메인 목록 파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("도메인 목록 파일 읽기 실패: %w", err)
+	}
+	return NewDomainList(domains), nil
+}
+
+// Len은 목록에 포함된 도메인 수를 반환합니다.
+func (l *DomainList) Len() int {
+	return len(l.domains)
+}
+
+// Contains는 도메인 또는 그 상위 도메인이 목록에 있는지 확인합니다.
+func (l *DomainList) Contains(domain string) bool {
+	domain = normalizeDomain(domain)
+	for domain != "" {
+		if _, ok := l.domains[domain]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			return false
+		}
+		domain = parent
+	}
+	return false
+}
+
+// normalizeDomain은 도메인을 소문자로 바꾸고 앞뒤 공백과 '@', 마지막 '.'을 제거합니다.
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "@")
+	return strings.TrimSuffix(domain, ".")
+}
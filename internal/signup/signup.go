@@ -0,0 +1,156 @@
+package signup
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+)
+
+// 회원 가입 모드
+const (
+	ModeOpen     = "open"     // 누구나 가입 가능
+	ModeInvite   = "invite"   // 초대 코드가 있어야 가입 가능
+	ModeDomain   = "domain"   // 허용된 이메일 도메인만 가입 가능
+	ModeDisabled = "disabled" // 가입 불가
+)
+
+// 가입 정책 위반 오류
+var (
+	ErrSignupDisabled   = errors.New("회원 가입이 비활성화되어 있습니다")
+	ErrInvalidInvite    = errors.New("유효하지 않은 초대 코드입니다")
+	ErrDomainNotAllowed = errors.New("가입이 허용되지 않은 이메일 도메인입니다")
+	ErrDisposableEmail  = errors.New("일회용 이메일 주소는 사용할 수 없습니다")
+	ErrCaptchaFailed    = errors.New("캡차 확인에 실패했습니다")
+	ErrInvalidEmail     = errors.New("잘못된 이메일 주소입니다")
+)
+
+// Policy는 회원 가입 허용 규칙입니다.
+type Policy struct {
+	Mode           string
+	InviteCodes    []string
+	AllowedDomains *DomainList
+	Disposable     *DomainList
+}
+
+// 현재 적용 중인 가입 정책과 캡차 확인 구현
+var (
+	policy  = &Policy{Mode: ModeDisabled}
+	captcha CaptchaVerifier
+)
+
+// Init은 설정에서 가입 정책과 캡차 확인을 구성하고 일회용 도메인 목록을 로드합니다.
+func Init(cfg *config.Config) error {
+	p := &Policy{
+		Mode:           cfg.SignupMode,
+		InviteCodes:    cfg.SignupInviteCodes,
+		AllowedDomains: NewDomainList(cfg.SignupAllowedDomains),
+	}
+
+	switch p.Mode {
+	case ModeOpen, ModeDisabled:
+	case ModeInvite:
+		if len(p.InviteCodes) == 0 {
+			return errors.New("초대 가입 모드에는 SIGNUP_INVITE_CODES가 필요합니다")
+		}
+	case ModeDomain:
+		if p.AllowedDomains.Len() == 0 {
+			return errors.New("도메인 가입 모드에는 SIGNUP_ALLOWED_DOMAINS가 필요합니다")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 회원 가입 모드입니다: %s", cfg.SignupMode)
+	}
+
+	if cfg.DisposableDomainsFile != "" {
+		list, err := LoadDomainList(cfg.DisposableDomainsFile)
+		if err != nil {
+			return err
+		}
+		p.Disposable = list
+	}
+
+	policy = p
+	captcha = nil
+	if cfg.CaptchaVerifyURL != "" {
+		captcha = NewSiteVerifyCaptcha(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)
+	}
+	return nil
+}
+
+// SetPolicy는 가입 정책을 교체합니다.
+func SetPolicy(p *Policy) {
+	policy = p
+}
+
+// SetCaptchaVerifier는 캡차 확인 구현을 교체합니다. nil이면 캡차 확인을 하지 않습니다.
+func SetCaptchaVerifier(verifier CaptchaVerifier) {
+	captcha = verifier
+}
+
+// Enabled는 회원 가입이 허용되어 있는지 확인합니다.
+func Enabled() bool {
+	return policy.Mode != ModeDisabled
+}
+
+// Check는 가입 모드, 초대 코드, 이메일 도메인 규칙을 검사합니다.
+func Check(email, inviteCode string) error {
+	return policy.Check(email, inviteCode)
+}
+
+// VerifyCaptcha는 캡차 응답 토큰을 확인합니다. 캡차가 설정되지 않았으면 항상 통과합니다.
+func VerifyCaptcha(token, remoteIP string) error {
+	if captcha == nil {
+		return nil
+	}
+	ok, err := captcha.Verify(token, remoteIP)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrCaptchaFailed
+	}
+	return nil
+}
+
+// Check는 가입 모드, 초대 코드, 이메일 도메인 규칙을 검사합니다.
+func (p *Policy) Check(email, inviteCode string) error {
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok || domain == "" {
+		return ErrInvalidEmail
+	}
+
+	switch p.Mode {
+	case ModeOpen:
+	case ModeInvite:
+		if !p.validInvite(inviteCode) {
+			return ErrInvalidInvite
+		}
+	case ModeDomain:
+		if !p.AllowedDomains.Contains(domain) {
+			return ErrDomainNotAllowed
+		}
+	default:
+		return ErrSignupDisabled
+	}
+
+	if p.Disposable != nil && p.Disposable.Contains(domain) {
+		return ErrDisposableEmail
+	}
+	return nil
+}
+
+// validInvite는 초대 코드가 설정된 코드 중 하나와 일치하는지 확인합니다.
+func (p *Policy) validInvite(code string) bool {
+	if code == "" {
+		return false
+	}
+	valid := false
+	for _, candidate := range p.InviteCodes {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(code)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
@@ -0,0 +1,83 @@
+package signup
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPolicyModes는 가입 모드별 허용 규칙을 테스트합니다.
+func TestPolicyModes(t *testing.T) {
+	disposable := NewDomainList([]string{"mailinator.com"})
+
+	open := &Policy{Mode: ModeOpen, Disposable: disposable}
+	assert.NoError(t, open.Check("alice@example.com", ""))
+	assert.ErrorIs(t, open.Check("alice@mailinator.com", ""), ErrDisposableEmail)
+	assert.ErrorIs(t, open.Check("alice@eu.mailinator.com", ""), ErrDisposableEmail)
+	assert.ErrorIs(t, open.Check("alice", ""), ErrInvalidEmail)
+
+	invite := &Policy{Mode: ModeInvite, InviteCodes: []string{"welcome-2024"}}
+	assert.NoError(t, invite.Check("alice@example.com", "welcome-2024"))
+	assert.ErrorIs(t, invite.Check("alice@example.com", "wrong"), ErrInvalidInvite)
+	assert.ErrorIs(t, invite.Check("alice@example.com", ""), ErrInvalidInvite)
+
+	domain := &Policy{Mode: ModeDomain, AllowedDomains: NewDomainList([]string{"Example.com"})}
+	assert.NoError(t, domain.Check("alice@example.com", ""))
+	assert.NoError(t, domain.Check("alice@dev.EXAMPLE.com", ""))
+	assert.ErrorIs(t, domain.Check("alice@example.org", ""), ErrDomainNotAllowed)
+	assert.ErrorIs(t, domain.Check("alice@notexample.com", ""), ErrDomainNotAllowed)
+
+	disabled := &Policy{Mode: ModeDisabled}
+	assert.ErrorIs(t, disabled.Check("alice@example.com", ""), ErrSignupDisabled)
+}
+
+// TestInit은 설정 검증과 일회용 도메인 목록 로드를 테스트합니다.
+func TestInit(t *testing.T) {
+	t.Cleanup(func() { SetPolicy(&Policy{Mode: ModeDisabled}) })
+
+	path := filepath.Join(t.TempDir(), "disposable.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# 일회용 도메인\nmailinator.com\n\nTempMail.org\n"), 0o600))
+
+	err := Init(&config.Config{SignupMode: ModeOpen, DisposableDomainsFile: path})
+	assert.NoError(t, err)
+	assert.True(t, Enabled())
+	assert.Equal(t, 2, policy.Disposable.Len())
+	assert.ErrorIs(t, Check("alice@tempmail.org", ""), ErrDisposableEmail)
+
+	assert.Error(t, Init(&config.Config{SignupMode: ModeInvite}))
+	assert.Error(t, Init(&config.Config{SignupMode: ModeDomain}))
+	assert.Error(t, Init(&config.Config{SignupMode: "everyone"}))
+	assert.Error(t, Init(&config.Config{SignupMode: ModeOpen, DisposableDomainsFile: "missing.txt"}))
+
+	assert.NoError(t, Init(&config.Config{SignupMode: ModeDisabled}))
+	assert.False(t, Enabled())
+}
+
+// TestSiteVerifyCaptcha는 siteverify 형식 API로 캡차를 확인하는지 테스트합니다.
+func TestSiteVerifyCaptcha(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("secret") == "captcha-secret" && r.PostForm.Get("response") == "valid" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	SetCaptchaVerifier(NewSiteVerifyCaptcha(server.URL, "captcha-secret"))
+	t.Cleanup(func() { SetCaptchaVerifier(nil) })
+
+	assert.NoError(t, VerifyCaptcha("valid", "127.0.0.1"))
+	assert.ErrorIs(t, VerifyCaptcha("invalid", "127.0.0.1"), ErrCaptchaFailed)
+	assert.ErrorIs(t, VerifyCaptcha("", "127.0.0.1"), ErrCaptchaFailed)
+
+	// 캡차가 설정되지 않으면 항상 통과
+	SetCaptchaVerifier(nil)
+	assert.NoError(t, VerifyCaptcha("", ""))
+}
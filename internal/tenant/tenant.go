@@ -0,0 +1,42 @@
+package tenant
+
+import (
+	"github.com/choi-jiwoong/go-quickstart/internal/auth"
+	"github.com/choi-jiwoong/go-quickstart/internal/config"
+	"github.com/choi-jiwoong/go-quickstart/internal/models"
+	"github.com/choi-jiwoong/go-quickstart/internal/repository"
+)
+
+// defaultOrganizationID는 회원 가입한 사용자와 조직이 없던 기존 사용자가 속하는 조직입니다.
+var defaultOrganizationID int64
+
+// Init은 기본 조직을 등록하고 조직이 지정되지 않은 사용자를 기본 조직에 배정합니다.
+func Init(cfg *config.Config) error {
+	org, err := repository.EnsureOrganization(cfg.DefaultOrganization)
+	if err != nil {
+		return err
+	}
+	if err := repository.AssignUnorganizedUsers(org.ID); err != nil {
+		return err
+	}
+	defaultOrganizationID = org.ID
+	return nil
+}
+
+// DefaultOrganizationID는 기본 조직의 ID를 반환합니다.
+func DefaultOrganizationID() int64 {
+	return defaultOrganizationID
+}
+
+// CrossTenant는 사용자가 모든 조직의 데이터를 다룰 수 있는지 확인합니다.
+func CrossTenant(user models.User) bool {
+	return auth.HasPermission(user, auth.PermOrgsManage)
+}
+
+// Of는 사용자가 조회할 수 있는 조직 범위를 반환합니다.
+func Of(user models.User) repository.Tenant {
+	if CrossTenant(user) {
+		return repository.AllTenants
+	}
+	return repository.Tenant{OrganizationID: user.OrganizationID}
+}
@@ -0,0 +1,217 @@
+// Package signing은 API 키로 HTTP 요청에 HMAC-SHA256 서명을 붙입니다.
+// 서버도 같은 패키지로 서명 대상 문자열을 만들므로, 클라이언트는 이 패키지를 사용하면 정규화 규칙을 따로 구현할 필요가 없습니다.
+//
+// 서명 대상은 다음 항목을 줄바꿈으로 연결한 문자열입니다.
+//
+//	GQS-HMAC-SHA256
+//	<Unix 시각(초)>
+//	<nonce>
+//	<대문자 메서드>
+//	<이스케이프된 경로>
+//	<키와 값으로 정렬한 쿼리 문자열>
+//	<본문 SHA-256 (16진수)>
+//
+// 서명은 API 키 비밀 값으로 계산한 HMAC-SHA256의 16진수 값이며 Authorization 헤더로 보냅니다.
+//
+//	Authorization: GQS-HMAC-SHA256 KeyId=gqk_..., Timestamp=1700000000, Nonce=..., Signature=...
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm은 서명 방식 이름이며 Authorization 헤더의 인증 방식으로 사용합니다.
+const Algorithm = "GQS-HMAC-SHA256"
+
+// ErrInvalidAuthorization은 Authorization 헤더가 서명 형식이 아닐 때 반환됩니다.
+var ErrInvalidAuthorization = errors.New("잘못된 서명 헤더 형식입니다")
+
+// Authorization은 Authorization 헤더에 담긴 서명 정보입니다.
+type Authorization struct {
+	KeyID     string
+	Timestamp int64
+	Nonce     string
+	Signature string
+}
+
+// String은 Authorization 헤더 값을 반환합니다.
+func (a Authorization) String() string {
+	return fmt.Sprintf("%s KeyId=%s, Timestamp=%d, Nonce=%s, Signature=%s", Algorithm, a.KeyID, a.Timestamp, a.Nonce, a.Signature)
+}
+
+// Time은 서명 시각을 반환합니다.
+func (a Authorization) Time() time.Time {
+	return time.Unix(a.Timestamp, 0)
+}
+
+// IsSigned는 Authorization 헤더 값이 이 서명 방식인지 확인합니다.
+func IsSigned(header string) bool {
+	return strings.HasPrefix(header, Algorithm+" ")
+}
+
+// ParseAuthorization은 Authorization 헤더 값에서 서명 정보를 꺼냅니다.
+func ParseAuthorization(header string) (Authorization, error) {
+	params, ok := strings.CutPrefix(header, Algorithm+" ")
+	if !ok {
+		return Authorization{}, ErrInvalidAuthorization
+	}
+
+	var a Authorization
+	for _, param := range strings.Split(params, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || value == "" {
+			return Authorization{}, ErrInvalidAuthorization
+		}
+		switch name {
+		case "KeyId":
+			a.KeyID = value
+		case "Timestamp":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Authorization{}, ErrInvalidAuthorization
+			}
+			a.Timestamp = ts
+		case "Nonce":
+			a.Nonce = value
+		case "Signature":
+			a.Signature = value
+		default:
+			return Authorization{}, ErrInvalidAuthorization
+		}
+	}
+	if a.KeyID == "" || a.Timestamp == 0 || a.Nonce == "" || a.Signature == "" {
+		return Authorization{}, ErrInvalidAuthorization
+	}
+	return a, nil
+}
+
+// HashBody는 요청 본문의 SHA-256 값을 16진수로 반환합니다.
+func HashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// CanonicalQuery는 쿼리 문자열을 키와 값 순서로 정렬하여 다시 인코딩합니다.
+// 프록시 등에서 매개변수 순서나 인코딩이 바뀌어도 같은 문자열이 됩니다.
+func CanonicalQuery(rawQuery string) (string, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(pairs, "&"), nil
+}
+
+// StringToSign은 서명 대상 문자열을 만듭니다.
+func StringToSign(method, escapedPath, rawQuery string, timestamp int64, nonce, bodyHash string) (string, error) {
+	query, err := CanonicalQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+	if escapedPath == "" {
+		escapedPath = "/"
+	}
+	return strings.Join([]string{
+		Algorithm,
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+		strings.ToUpper(method),
+		escapedPath,
+		query,
+		bodyHash,
+	}, "\n"), nil
+}
+
+// Compute는 서명 대상 문자열의 HMAC-SHA256 값을 16진수로 반환합니다.
+func Compute(secret, stringToSign string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify는 서명이 일치하는지 일정한 시간에 비교합니다.
+func Verify(secret, stringToSign, signature string) bool {
+	expected := Compute(secret, stringToSign)
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
+
+// Signer는 API 키로 요청에 서명합니다.
+type Signer struct {
+	KeyID  string
+	Secret string
+
+	// Now는 서명 시각을 정합니다. 비어 있으면 현재 시각을 사용합니다.
+	Now func() time.Time
+}
+
+// Sign은 요청 본문을 읽어 서명을 계산하고 Authorization 헤더를 설정합니다.
+// 본문은 읽은 뒤 다시 읽을 수 있도록 되돌려 놓습니다.
+func (s Signer) Sign(req *http.Request) error {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		req.Body.Close()
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+	a := Authorization{KeyID: s.KeyID, Timestamp: now().Unix(), Nonce: nonce}
+
+	stringToSign, err := StringToSign(req.Method, req.URL.EscapedPath(), req.URL.RawQuery, a.Timestamp, a.Nonce, HashBody(body))
+	if err != nil {
+		return err
+	}
+	a.Signature = Compute(s.Secret, stringToSign)
+	req.Header.Set("Authorization", a.String())
+	return nil
+}
+
+// newNonce는 요청마다 다른 임의 값을 생성합니다.
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
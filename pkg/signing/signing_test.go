@@ -0,0 +1,108 @@
+package signing
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCanonicalQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "빈 쿼리", input: "", expected: ""},
+		{name: "키와 값 정렬", input: "b=2&a=3&a=1", expected: "a=1&a=3&b=2"},
+		{name: "인코딩 통일", input: "q=hello%20world&name=%ED%99%8D", expected: "name=%ED%99%8D&q=hello+world"},
+		{name: "값 없는 키", input: "flag&x=1", expected: "flag=&x=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CanonicalQuery(tt.input)
+			if err != nil {
+				t.Fatalf("예상하지 못한 오류: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("CanonicalQuery(%q) = %q, 예상 값 %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStringToSign(t *testing.T) {
+	result, err := StringToSign("post", "/user/7", "b=2&a=1", 1700000000, "abc", HashBody([]byte("{}")))
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	expected := "GQS-HMAC-SHA256\n1700000000\nabc\nPOST\n/user/7\na=1&b=2\n" +
+		"44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+	if result != expected {
+		t.Errorf("StringToSign() = %q, 예상 값 %q", result, expected)
+	}
+
+	// RFC 4231 테스트 케이스 2
+	if got := Compute("Jefe", "what do ya want for nothing?"); got != "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843" {
+		t.Errorf("Compute() = %q", got)
+	}
+}
+
+func TestParseAuthorization(t *testing.T) {
+	a := Authorization{KeyID: "gqk_1", Timestamp: 1700000000, Nonce: "n1", Signature: "abcd"}
+	parsed, err := ParseAuthorization(a.String())
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if parsed != a {
+		t.Errorf("ParseAuthorization() = %+v, 예상 값 %+v", parsed, a)
+	}
+
+	invalid := []string{
+		"Bearer token",
+		"GQS-HMAC-SHA256 KeyId=gqk_1, Timestamp=1700000000, Nonce=n1",
+		"GQS-HMAC-SHA256 KeyId=gqk_1, Timestamp=soon, Nonce=n1, Signature=abcd",
+		"GQS-HMAC-SHA256 KeyId=gqk_1, Timestamp=1700000000, Nonce=n1, Signature=abcd, Extra=1",
+	}
+	for _, header := range invalid {
+		if _, err := ParseAuthorization(header); err != ErrInvalidAuthorization {
+			t.Errorf("ParseAuthorization(%q) 오류 = %v", header, err)
+		}
+	}
+}
+
+func TestSignerSign(t *testing.T) {
+	signer := Signer{KeyID: "gqk_1", Secret: "secret", Now: func() time.Time { return time.Unix(1700000000, 0) }}
+	req, _ := http.NewRequest("PUT", "https://api.example.com/user/7?b=2&a=1", strings.NewReader(`{"email":"a@example.com"}`))
+	if err := signer.Sign(req); err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+
+	// 서명 후에도 본문을 읽을 수 있음
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"email":"a@example.com"}` {
+		t.Errorf("본문 = %q", body)
+	}
+
+	a, err := ParseAuthorization(req.Header.Get("Authorization"))
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if a.KeyID != "gqk_1" || a.Timestamp != 1700000000 || a.Nonce == "" {
+		t.Errorf("Authorization = %+v", a)
+	}
+
+	stringToSign, _ := StringToSign("PUT", "/user/7", "a=1&b=2", a.Timestamp, a.Nonce, HashBody(body))
+	if !Verify("secret", stringToSign, a.Signature) {
+		t.Error("서명이 일치하지 않습니다")
+	}
+	if Verify("other", stringToSign, a.Signature) {
+		t.Error("다른 비밀 값으로 서명이 일치합니다")
+	}
+	tampered, _ := StringToSign("PUT", "/user/8", "a=1&b=2", a.Timestamp, a.Nonce, HashBody(body))
+	if Verify("secret", tampered, a.Signature) {
+		t.Error("변조된 요청의 서명이 일치합니다")
+	}
+}
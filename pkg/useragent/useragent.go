@@ -0,0 +1,130 @@
+// Package useragent는 User-Agent 헤더에서 브라우저, 운영체제, 기기 종류를 추정합니다.
+// 세션 목록에 기기를 알아볼 수 있게 표시하기 위한 것이며, 모든 클라이언트를 정확히 구분하지는 않습니다.
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 기기 종류
+const (
+	TypeDesktop = "desktop"
+	TypeMobile  = "mobile"
+	TypeTablet  = "tablet"
+	TypeBot     = "bot"
+	TypeOther   = "other"
+)
+
+// Device는 User-Agent에서 추정한 기기 정보입니다. 알 수 없는 항목은 비어 있습니다.
+type Device struct {
+	Browser        string `json:"browser,omitempty"`
+	BrowserVersion string `json:"browser_version,omitempty"`
+	OS             string `json:"os,omitempty"`
+	OSVersion      string `json:"os_version,omitempty"`
+	Type           string `json:"type"`
+}
+
+// String은 "Chrome 120 (Windows 10)"처럼 사람이 읽을 수 있는 기기 설명을 반환합니다.
+func (d Device) String() string {
+	name := strings.TrimSpace(d.Browser + " " + d.BrowserVersion)
+	system := strings.TrimSpace(d.OS + " " + d.OSVersion)
+	switch {
+	case name == "" && system == "":
+		return "알 수 없는 기기"
+	case name == "":
+		return system
+	case system == "":
+		return name
+	}
+	return name + " (" + system + ")"
+}
+
+// pattern은 User-Agent에서 이름과 버전을 찾는 규칙입니다.
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// 브라우저 규칙. 다른 브라우저 이름을 함께 포함하는 경우가 많으므로 구체적인 것부터 확인합니다.
+// 예: Edge와 Opera는 Chrome과 Safari를, Chrome은 Safari를 함께 표시합니다.
+var browsers = []pattern{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+)[.\d]* (?:Mobile/\S+ )?Safari/`)},
+	{"curl", regexp.MustCompile(`^curl/(\d+)`)},
+	{"Go", regexp.MustCompile(`^Go-http-client/(\d+)`)},
+	{"Postman", regexp.MustCompile(`PostmanRuntime/(\d+)`)},
+	{"okhttp", regexp.MustCompile(`okhttp/(\d+)`)},
+}
+
+// 운영체제 규칙. iOS와 Android는 데스크톱 운영체제 이름을 함께 포함할 수 있으므로 먼저 확인합니다.
+var systems = []pattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS (\d+)`)},
+	{"Android", regexp.MustCompile(`Android (\d+)`)},
+	{"Windows", regexp.MustCompile(`Windows NT (\d+\.\d+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)`)},
+	{"ChromeOS", regexp.MustCompile(`CrOS \S+ (\d+)`)},
+	{"Linux", regexp.MustCompile(`Linux`)},
+}
+
+// Windows NT 버전과 제품 이름 (Windows 11도 NT 10.0으로 표시됨)
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+}
+
+// bot은 검색 엔진 크롤러 등 자동화된 클라이언트를 나타내는 문자열입니다.
+var bot = regexp.MustCompile(`(?i)bot|crawler|spider|slurp`)
+
+// Parse는 User-Agent 헤더 값에서 기기 정보를 추정합니다.
+func Parse(ua string) Device {
+	var d Device
+	for _, p := range browsers {
+		if m := p.re.FindStringSubmatch(ua); m != nil {
+			d.Browser, d.BrowserVersion = p.name, m[1]
+			break
+		}
+	}
+	for _, p := range systems {
+		m := p.re.FindStringSubmatch(ua)
+		if m == nil {
+			continue
+		}
+		d.OS = p.name
+		switch p.name {
+		case "Windows":
+			d.OSVersion = windowsVersions[m[1]]
+		case "macOS":
+			d.OSVersion = m[1] + "." + m[2]
+		case "Linux":
+			// 배포판마다 형식이 달라 버전은 표시하지 않음
+		default:
+			d.OSVersion = m[1]
+		}
+		break
+	}
+	d.Type = deviceType(ua, d)
+	return d
+}
+
+// deviceType은 기기 종류를 추정합니다.
+func deviceType(ua string, d Device) string {
+	switch {
+	case bot.MatchString(ua):
+		return TypeBot
+	case strings.Contains(ua, "iPad") || strings.Contains(ua, "Tablet") ||
+		(d.OS == "Android" && !strings.Contains(ua, "Mobile")):
+		return TypeTablet
+	case strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod"):
+		return TypeMobile
+	case d.OS == "Windows" || d.OS == "macOS" || d.OS == "Linux" || d.OS == "ChromeOS":
+		return TypeDesktop
+	}
+	return TypeOther
+}
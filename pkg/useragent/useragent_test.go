@@ -0,0 +1,93 @@
+package useragent
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		ua       string
+		expected Device
+	}{
+		{
+			name:     "Windows Chrome",
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected: Device{Browser: "Chrome", BrowserVersion: "120", OS: "Windows", OSVersion: "10", Type: TypeDesktop},
+		},
+		{
+			name:     "Windows Edge",
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			expected: Device{Browser: "Edge", BrowserVersion: "120", OS: "Windows", OSVersion: "10", Type: TypeDesktop},
+		},
+		{
+			name:     "macOS Safari",
+			ua:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15",
+			expected: Device{Browser: "Safari", BrowserVersion: "17", OS: "macOS", OSVersion: "10.15", Type: TypeDesktop},
+		},
+		{
+			name:     "Linux Firefox",
+			ua:       "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			expected: Device{Browser: "Firefox", BrowserVersion: "121", OS: "Linux", Type: TypeDesktop},
+		},
+		{
+			name:     "iPhone Safari",
+			ua:       "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+			expected: Device{Browser: "Safari", BrowserVersion: "17", OS: "iOS", OSVersion: "17", Type: TypeMobile},
+		},
+		{
+			name:     "iPad Chrome",
+			ua:       "Mozilla/5.0 (iPad; CPU OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/120.0.6099.119 Mobile/15E148 Safari/604.1",
+			expected: Device{Browser: "Chrome", BrowserVersion: "120", OS: "iOS", OSVersion: "17", Type: TypeTablet},
+		},
+		{
+			name:     "Android Samsung Internet",
+			ua:       "Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+			expected: Device{Browser: "Samsung Internet", BrowserVersion: "23", OS: "Android", OSVersion: "14", Type: TypeMobile},
+		},
+		{
+			name:     "Android 태블릿",
+			ua:       "Mozilla/5.0 (Linux; Android 13; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected: Device{Browser: "Chrome", BrowserVersion: "120", OS: "Android", OSVersion: "13", Type: TypeTablet},
+		},
+		{
+			name:     "curl",
+			ua:       "curl/8.4.0",
+			expected: Device{Browser: "curl", BrowserVersion: "8", Type: TypeOther},
+		},
+		{
+			name:     "검색 엔진",
+			ua:       "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: Device{Type: TypeBot},
+		},
+		{
+			name:     "빈 값",
+			ua:       "",
+			expected: Device{Type: TypeOther},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.ua); got != tt.expected {
+				t.Errorf("Parse(%q) = %+v, 예상 %+v", tt.ua, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeviceString(t *testing.T) {
+	tests := []struct {
+		device   Device
+		expected string
+	}{
+		{Device{Browser: "Chrome", BrowserVersion: "120", OS: "Windows", OSVersion: "10"}, "Chrome 120 (Windows 10)"},
+		{Device{Browser: "curl", BrowserVersion: "8"}, "curl 8"},
+		{Device{OS: "Linux"}, "Linux"},
+		{Device{}, "알 수 없는 기기"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.device.String(); got != tt.expected {
+			t.Errorf("String() = %q, 예상 %q", got, tt.expected)
+		}
+	}
+}
@@ -2,16 +2,35 @@
 CREATE DATABASE IF NOT EXISTS MAIN;
 USE MAIN;
 
+-- 조직 테이블 생성
+CREATE TABLE IF NOT EXISTS organizations (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    name       VARCHAR(100) NOT NULL,
+    CONSTRAINT UK_organization_name UNIQUE (name)
+);
+
 -- 사용자 테이블 생성
 CREATE TABLE IF NOT EXISTS users (
     id         BIGINT AUTO_INCREMENT PRIMARY KEY,
     created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
     email      VARCHAR(100) NOT NULL,
     password   VARCHAR(255) NOT NULL,
-    role       VARCHAR(20)  NOT NULL,
+    role       VARCHAR(50)  NOT NULL,
     updated_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
     username   VARCHAR(50)  NOT NULL,
-    CONSTRAINT UK_username UNIQUE (username)
+    lockout_reset_at DATETIME(6) NULL,
+    mfa_enabled    BOOLEAN     NOT NULL DEFAULT FALSE,
+    totp_secret    VARCHAR(64) NULL,
+    totp_last_step BIGINT      NOT NULL DEFAULT 0,
+    status         VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    organization_id BIGINT     NOT NULL,
+    external_id     VARCHAR(255) NULL,
+    CONSTRAINT UK_username UNIQUE (username),
+    INDEX IDX_user_organization (organization_id),
+    INDEX IDX_user_external_id (external_id),
+    CONSTRAINT FK_user_organization FOREIGN KEY (organization_id) REFERENCES organizations (id)
 );
 
 -- 로그인 히스토리 테이블 생성
@@ -22,12 +41,351 @@ CREATE TABLE IF NOT EXISTS login_history (
     login_time DATETIME(6)  NOT NULL,
     success    BIT          NOT NULL,
     user_agent VARCHAR(255) NULL,
-    user_id    BIGINT       NOT NULL,
+    user_id    BIGINT       NULL,
+    INDEX IDX_login_history_user_time (user_id, login_time),
     CONSTRAINT FK_login_history_user FOREIGN KEY (user_id) REFERENCES users (id)
 );
 
+-- 리프레시 토큰 테이블 생성
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id    BIGINT      NOT NULL,
+    family_id  VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    used_at    DATETIME(6) NULL,
+    revoked_at DATETIME(6) NULL,
+    CONSTRAINT UK_refresh_token_hash UNIQUE (token_hash),
+    INDEX IDX_refresh_token_user (user_id),
+    INDEX IDX_refresh_token_family (family_id),
+    CONSTRAINT FK_refresh_token_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 로그인 세션 테이블 생성 (family_id는 리프레시 토큰 계열 및 액세스 토큰의 sid와 같음)
+CREATE TABLE IF NOT EXISTS sessions (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at      DATETIME(6)  DEFAULT CURRENT_TIMESTAMP(6),
+    user_id         BIGINT       NOT NULL,
+    family_id       VARCHAR(64)  NOT NULL,
+    ip_address      VARCHAR(50)  NULL,
+    user_agent      VARCHAR(255) NULL,
+    impersonator_id BIGINT       NOT NULL DEFAULT 0,
+    last_used_at    DATETIME(6)  NOT NULL,
+    expires_at      DATETIME(6)  NOT NULL,
+    revoked_at      DATETIME(6)  NULL,
+    CONSTRAINT UK_session_family UNIQUE (family_id),
+    INDEX IDX_session_user (user_id),
+    CONSTRAINT FK_session_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 사용자 가장 감사 테이블 생성 (사용자가 삭제되어도 기록을 남기도록 외래 키 없음)
+CREATE TABLE IF NOT EXISTS impersonation_audits (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6)  DEFAULT CURRENT_TIMESTAMP(6),
+    actor_id   BIGINT       NOT NULL,
+    target_id  BIGINT       NOT NULL,
+    session_id VARCHAR(64)  NOT NULL,
+    reason     VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(50)  NULL,
+    expires_at DATETIME(6)  NOT NULL,
+    INDEX IDX_impersonation_audit_actor (actor_id),
+    INDEX IDX_impersonation_audit_target (target_id),
+    INDEX IDX_impersonation_audit_session (session_id)
+);
+
+-- 토큰 폐기 테이블 생성 (jti와 session_id가 모두 비어 있으면 사용자 단위 폐기)
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    jti        VARCHAR(64) NULL,
+    session_id VARCHAR(64) NULL,
+    user_id    BIGINT      NOT NULL,
+    revoked_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    INDEX IDX_token_revocation_jti (jti),
+    INDEX IDX_token_revocation_session (session_id),
+    INDEX IDX_token_revocation_user (user_id),
+    INDEX IDX_token_revocation_expires (expires_at)
+);
+
+-- 2단계 인증 복구 코드 테이블 생성
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id    BIGINT      NOT NULL,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    DATETIME(6) NULL,
+    INDEX IDX_recovery_code_user (user_id),
+    INDEX IDX_recovery_code_hash (code_hash),
+    CONSTRAINT FK_recovery_code_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 비밀번호 재설정 토큰 테이블 생성
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id    BIGINT      NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    used_at    DATETIME(6) NULL,
+    CONSTRAINT UK_password_reset_token_hash UNIQUE (token_hash),
+    INDEX IDX_password_reset_token_user (user_id),
+    CONSTRAINT FK_password_reset_token_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 이메일 인증 토큰 테이블 생성
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id    BIGINT      NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    used_at    DATETIME(6) NULL,
+    CONSTRAINT UK_email_verification_token_hash UNIQUE (token_hash),
+    INDEX IDX_email_verification_token_user (user_id),
+    CONSTRAINT FK_email_verification_token_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 가입 초대 테이블 생성
+CREATE TABLE IF NOT EXISTS invitations (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at      DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    organization_id BIGINT       NOT NULL,
+    email           VARCHAR(100) NOT NULL,
+    role            VARCHAR(50)  NOT NULL,
+    invited_by      BIGINT       NOT NULL,
+    token_hash      VARCHAR(64)  NOT NULL,
+    expires_at      DATETIME(6)  NOT NULL,
+    accepted_at     DATETIME(6)  NULL,
+    revoked_at      DATETIME(6)  NULL,
+    user_id         BIGINT       NULL,
+    CONSTRAINT UK_invitation_token_hash UNIQUE (token_hash),
+    INDEX IDX_invitation_organization (organization_id),
+    INDEX IDX_invitation_email (email)
+);
+
+-- 개인 액세스 토큰 테이블 생성
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at   DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id      BIGINT       NOT NULL,
+    name         VARCHAR(100) NOT NULL,
+    token_hash   VARCHAR(64)  NOT NULL,
+    token_prefix VARCHAR(16)  NOT NULL,
+    scopes       TEXT         NULL,
+    expires_at   DATETIME(6)  NOT NULL,
+    last_used_at DATETIME(6)  NULL,
+    revoked_at   DATETIME(6)  NULL,
+    CONSTRAINT UK_personal_access_token_hash UNIQUE (token_hash),
+    INDEX IDX_personal_access_token_user (user_id),
+    CONSTRAINT FK_personal_access_token_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 서명 요청용 API 키 테이블 생성
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at   DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id      BIGINT       NOT NULL,
+    name         VARCHAR(100) NOT NULL,
+    key_id       VARCHAR(32)  NOT NULL,
+    secret       VARCHAR(64)  NOT NULL,
+    scopes       TEXT         NULL,
+    expires_at   DATETIME(6)  NOT NULL,
+    last_used_at DATETIME(6)  NULL,
+    revoked_at   DATETIME(6)  NULL,
+    CONSTRAINT UK_api_key_key_id UNIQUE (key_id),
+    INDEX IDX_api_key_user (user_id),
+    CONSTRAINT FK_api_key_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- API 키 서명 요청 nonce 테이블 생성
+CREATE TABLE IF NOT EXISTS api_nonces (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    key_id     VARCHAR(32) NOT NULL,
+    nonce      VARCHAR(64) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    CONSTRAINT UK_api_nonce_key_nonce UNIQUE (key_id, nonce),
+    INDEX IDX_api_nonce_expires (expires_at)
+);
+
+-- 메일 발송 대기열 테이블 생성
+CREATE TABLE IF NOT EXISTS outbox_mails (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at      DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    recipient       VARCHAR(255)  NOT NULL,
+    subject         VARCHAR(255)  NOT NULL,
+    body            TEXT          NOT NULL,
+    status          VARCHAR(20)   NOT NULL,
+    attempts        INT           NOT NULL DEFAULT 0,
+    last_error      VARCHAR(1000) NULL,
+    next_attempt_at DATETIME(6)   NOT NULL,
+    sent_at         DATETIME(6)   NULL,
+    INDEX IDX_outbox_mail_status (status),
+    INDEX IDX_outbox_mail_next_attempt (next_attempt_at)
+);
+
+-- 권한 테이블 생성
+CREATE TABLE IF NOT EXISTS permissions (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NULL,
+    CONSTRAINT UK_permission_name UNIQUE (name)
+);
+
+-- 역할 테이블 생성
+CREATE TABLE IF NOT EXISTS roles (
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at  DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    name        VARCHAR(50)  NOT NULL,
+    description VARCHAR(255) NULL,
+    builtin     BOOLEAN      NOT NULL DEFAULT FALSE,
+    CONSTRAINT UK_role_name UNIQUE (name)
+);
+
+-- 역할별 권한 테이블 생성
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id       BIGINT NOT NULL,
+    permission_id BIGINT NOT NULL,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT FK_role_permission_role FOREIGN KEY (role_id) REFERENCES roles (id),
+    CONSTRAINT FK_role_permission_permission FOREIGN KEY (permission_id) REFERENCES permissions (id)
+);
+
+-- 그룹 테이블 생성
+CREATE TABLE IF NOT EXISTS `groups` (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at      DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at      DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+    organization_id BIGINT       NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    description     VARCHAR(255) NULL,
+    role            VARCHAR(50)  NULL,
+    external_id     VARCHAR(255) NULL,
+    CONSTRAINT UK_group_organization_name UNIQUE (organization_id, name),
+    INDEX IDX_group_external_id (external_id),
+    CONSTRAINT FK_group_organization FOREIGN KEY (organization_id) REFERENCES organizations (id)
+);
+
+-- 그룹 구성원 테이블 생성
+CREATE TABLE IF NOT EXISTS group_users (
+    group_id BIGINT NOT NULL,
+    user_id  BIGINT NOT NULL,
+    PRIMARY KEY (group_id, user_id),
+    CONSTRAINT FK_group_user_group FOREIGN KEY (group_id) REFERENCES `groups` (id),
+    CONSTRAINT FK_group_user_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 하위 그룹 테이블 생성
+CREATE TABLE IF NOT EXISTS group_subgroups (
+    parent_id BIGINT NOT NULL,
+    child_id  BIGINT NOT NULL,
+    PRIMARY KEY (parent_id, child_id),
+    CONSTRAINT FK_group_subgroup_parent FOREIGN KEY (parent_id) REFERENCES `groups` (id),
+    CONSTRAINT FK_group_subgroup_child FOREIGN KEY (child_id) REFERENCES `groups` (id)
+);
+
+-- 외부 계정 연결 테이블 생성
+CREATE TABLE IF NOT EXISTS identities (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at    DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    user_id       BIGINT       NOT NULL,
+    provider      VARCHAR(50)  NOT NULL,
+    subject       VARCHAR(255) NOT NULL,
+    email         VARCHAR(100) NULL,
+    last_login_at DATETIME(6)  NULL,
+    CONSTRAINT UK_identity_provider_subject UNIQUE (provider, subject),
+    CONSTRAINT FK_identity_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+-- 외부 로그인 요청 테이블 생성
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at    DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    provider      VARCHAR(50)  NOT NULL,
+    state_hash    VARCHAR(64)  NOT NULL,
+    nonce         VARCHAR(64)  NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    expires_at    DATETIME(6)  NOT NULL,
+    CONSTRAINT UK_oidc_login_state_hash UNIQUE (state_hash)
+);
+
+-- SAML 인증 요청 테이블 생성
+CREATE TABLE IF NOT EXISTS saml_login_requests (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    provider   VARCHAR(50) NOT NULL,
+    request_id VARCHAR(64) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    CONSTRAINT UK_saml_login_request_id UNIQUE (request_id),
+    INDEX IDX_saml_login_request_expires (expires_at)
+);
+
+-- 사용한 SAML 어설션 테이블 생성 (재사용 방지)
+CREATE TABLE IF NOT EXISTS saml_assertions (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at   DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    provider     VARCHAR(50)  NOT NULL,
+    assertion_id VARCHAR(255) NOT NULL,
+    expires_at   DATETIME(6)  NOT NULL,
+    CONSTRAINT UK_saml_assertion_provider_id UNIQUE (provider, assertion_id),
+    INDEX IDX_saml_assertion_expires (expires_at)
+);
+
+-- OAuth2 클라이언트 테이블 생성
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at    DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    client_id     VARCHAR(64)  NOT NULL,
+    secret_hash   VARCHAR(64)  NULL,
+    name          VARCHAR(100) NOT NULL,
+    redirect_uris TEXT         NULL,
+    grant_types   TEXT         NULL,
+    scopes        TEXT         NULL,
+    public        BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_by    BIGINT       NOT NULL,
+    CONSTRAINT UK_oauth_client_client_id UNIQUE (client_id)
+);
+
+-- OAuth2 인가 코드 테이블 생성
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at     DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    code_hash      VARCHAR(64)  NOT NULL,
+    client_id      VARCHAR(64)  NOT NULL,
+    user_id        BIGINT       NOT NULL,
+    redirect_uri   VARCHAR(500) NOT NULL,
+    scope          VARCHAR(500) NOT NULL,
+    nonce          VARCHAR(255) NULL,
+    code_challenge VARCHAR(128) NOT NULL,
+    expires_at     DATETIME(6)  NOT NULL,
+    CONSTRAINT UK_oauth_code_hash UNIQUE (code_hash),
+    INDEX IDX_oauth_code_client (client_id),
+    INDEX IDX_oauth_code_expires_at (expires_at)
+);
+
+-- SCIM 프로비저닝 토큰 테이블 생성
+CREATE TABLE IF NOT EXISTS scim_tokens (
+    id              BIGINT AUTO_INCREMENT PRIMARY KEY,
+    created_at      DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+    name            VARCHAR(100) NOT NULL,
+    token_hash      VARCHAR(64)  NOT NULL,
+    token_prefix    VARCHAR(16)  NOT NULL,
+    organization_id BIGINT       NOT NULL,
+    created_by      BIGINT       NOT NULL,
+    last_used_at    DATETIME(6)  NULL,
+    revoked_at      DATETIME(6)  NULL,
+    CONSTRAINT UK_scim_token_hash UNIQUE (token_hash),
+    INDEX IDX_scim_token_organization (organization_id),
+    CONSTRAINT FK_scim_token_organization FOREIGN KEY (organization_id) REFERENCES organizations (id)
+);
+
 -- 샘플 데이터 삽입
-INSERT INTO users (email, password, role, username)
+INSERT INTO organizations (id, name)
+VALUES (1, 'default');
+
+INSERT INTO users (email, password, role, username, organization_id)
 VALUES 
-    ('admin@example.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', 'ADMIN', 'admin'),
-    ('user@example.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', 'USER', 'user');
\ No newline at end of file
+    ('admin@example.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', 'SUPER_ADMIN', 'admin', 1),
+    ('user@example.com', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy', 'USER', 'user', 1);
\ No newline at end of file